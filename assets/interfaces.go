@@ -209,6 +209,14 @@ type LocationHierarchy interface {
 	FindByName(name string, level envs.LocationLevel, parent *envs.Location) []*envs.Location
 }
 
+// RootedLocationHierarchy is implemented by location hierarchies which can provide their root location, allowing all
+// their locations to be visited
+type RootedLocationHierarchy interface {
+	LocationHierarchy
+
+	Root() *envs.Location
+}
+
 // Resthook is a set of URLs which are subscribed to the named event.
 //
 //   {
@@ -222,6 +230,7 @@ type LocationHierarchy interface {
 type Resthook interface {
 	Slug() string
 	Subscribers() []string
+	SubscriberCount() int
 }
 
 // TemplateUUID is the UUID of a template
@@ -301,3 +310,8 @@ type Source interface {
 	Templates() ([]Template, error)
 	Ticketers() ([]Ticketer, error)
 }
+
+// FlowEnumerator is implemented by sources which can enumerate all of their flows
+type FlowEnumerator interface {
+	FlowUUIDs() []FlowUUID
+}
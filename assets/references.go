@@ -365,6 +365,43 @@ func (r *TicketerReference) String() string {
 
 var _ UUIDReference = (*TicketerReference)(nil)
 
+//------------------------------------------------------------------------------------------
+// Utility functions
+//------------------------------------------------------------------------------------------
+
+// ReferenceKey returns a key for the given reference which is the same for all references to the same asset, i.e. they
+// have the same type and identity. Variable references only have the same key if all their fields are equal.
+func ReferenceKey(r Reference) string {
+	if r.Variable() {
+		return fmt.Sprintf("%s:variable:%#v", r.Type(), r)
+	}
+	return r.Type() + ":" + r.Identity()
+}
+
+// ReferencesEqual returns whether the given references are to the same asset, i.e. they have the same type and
+// identity. Variable references are only considered equal if all their fields are equal.
+func ReferencesEqual(a, b Reference) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return ReferenceKey(a) == ReferenceKey(b)
+}
+
+// UniqueReferences returns the given references with duplicates removed, preserving the order of first occurrences
+func UniqueReferences(refs []Reference) []Reference {
+	seen := make(map[string]bool, len(refs))
+	unique := make([]Reference, 0, len(refs))
+
+	for _, ref := range refs {
+		key := ReferenceKey(ref)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, ref)
+		}
+	}
+	return unique
+}
+
 //------------------------------------------------------------------------------------------
 // Callbacks for missing assets
 //------------------------------------------------------------------------------------------
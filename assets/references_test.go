@@ -148,3 +148,36 @@ func TestTypedReference(t *testing.T) {
 	assert.Equal(t, `{"uuid":"61602f3e-f603-4c70-8a8f-c477505bf4bf","name":"Bobs"}`, string(refJSON))
 	assert.Equal(t, `{"uuid":"61602f3e-f603-4c70-8a8f-c477505bf4bf","name":"Bobs","type":"group"}`, string(typedJSON))
 }
+
+func TestReferencesEqual(t *testing.T) {
+	group1 := assets.NewGroupReference("61602f3e-f603-4c70-8a8f-c477505bf4bf", "Bobs")
+	group2 := assets.NewGroupReference("61602f3e-f603-4c70-8a8f-c477505bf4bf", "Bobz") // same UUID, different name
+	group3 := assets.NewGroupReference("2aad21f6-30b7-42c5-bd7f-1b720c154817", "Jims")
+	label1 := assets.NewLabelReference("61602f3e-f603-4c70-8a8f-c477505bf4bf", "Spam") // same UUID as group1
+	field1 := assets.NewFieldReference("age", "Age")
+	global1 := assets.NewGlobalReference("age", "Age") // same key as field1
+	varGroup1 := assets.NewVariableGroupReference("@fields.district")
+	varGroup2 := assets.NewVariableGroupReference("@fields.district")
+	varGroup3 := assets.NewVariableGroupReference("@fields.state")
+
+	assert.True(t, assets.ReferencesEqual(group1, group1))
+	assert.True(t, assets.ReferencesEqual(group1, group2))
+	assert.False(t, assets.ReferencesEqual(group1, group3))
+	assert.False(t, assets.ReferencesEqual(group1, label1))
+	assert.False(t, assets.ReferencesEqual(field1, global1))
+	assert.True(t, assets.ReferencesEqual(varGroup1, varGroup2))
+	assert.False(t, assets.ReferencesEqual(varGroup1, varGroup3))
+	assert.False(t, assets.ReferencesEqual(varGroup1, group1))
+	assert.False(t, assets.ReferencesEqual(group1, nil))
+	assert.True(t, assets.ReferencesEqual(nil, nil))
+
+	assert.Equal(t, "group:61602f3e-f603-4c70-8a8f-c477505bf4bf", assets.ReferenceKey(group2))
+	assert.Equal(t, assets.ReferenceKey(varGroup1), assets.ReferenceKey(varGroup2))
+	assert.NotEqual(t, assets.ReferenceKey(varGroup1), assets.ReferenceKey(varGroup3))
+
+	assert.Equal(t,
+		[]assets.Reference{group1, label1, field1, global1, group3, varGroup1},
+		assets.UniqueReferences([]assets.Reference{group1, label1, group2, field1, global1, field1, group3, varGroup1, varGroup2}),
+	)
+	assert.Equal(t, []assets.Reference{}, assets.UniqueReferences(nil))
+}
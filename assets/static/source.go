@@ -3,7 +3,11 @@ package static
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/assets/static/types"
@@ -41,9 +45,47 @@ func NewSource(data json.RawMessage) (*StaticSource, error) {
 	if err := utils.UnmarshalAndValidate(data, &s.s); err != nil {
 		return nil, errors.Wrap(err, "unable to read assets")
 	}
+	if err := s.checkUniqueness(); err != nil {
+		return nil, errors.Wrap(err, "unable to read assets")
+	}
 	return s, nil
 }
 
+// checks that no two assets of the same type have the same UUID (or key for fields and globals)
+func (s *StaticSource) checkUniqueness() error {
+	problems := make([]string, 0)
+	check := func(assetType string, count int, id func(int) string) {
+		seen := make(map[string]bool, count)
+		dupes := make([]string, 0)
+		for i := 0; i < count; i++ {
+			v := id(i)
+			if seen[v] && !utils.StringSliceContains(dupes, v, true) {
+				dupes = append(dupes, v)
+			}
+			seen[v] = true
+		}
+		if len(dupes) > 0 {
+			problems = append(problems, fmt.Sprintf("duplicate %s identifiers: %s", assetType, strings.Join(dupes, ", ")))
+		}
+	}
+
+	check("channel", len(s.s.Channels), func(i int) string { return string(s.s.Channels[i].UUID()) })
+	check("classifier", len(s.s.Classifiers), func(i int) string { return string(s.s.Classifiers[i].UUID()) })
+	check("field", len(s.s.Fields), func(i int) string { return s.s.Fields[i].Key() })
+	check("flow", len(s.s.Flows), func(i int) string { return string(s.s.Flows[i].UUID()) })
+	check("global", len(s.s.Globals), func(i int) string { return s.s.Globals[i].Key() })
+	check("group", len(s.s.Groups), func(i int) string { return string(s.s.Groups[i].UUID()) })
+	check("label", len(s.s.Labels), func(i int) string { return string(s.s.Labels[i].UUID()) })
+	check("resthook", len(s.s.Resthooks), func(i int) string { return s.s.Resthooks[i].Slug() })
+	check("template", len(s.s.Templates), func(i int) string { return string(s.s.Templates[i].UUID()) })
+	check("ticketer", len(s.s.Ticketers), func(i int) string { return string(s.s.Ticketers[i].UUID()) })
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
 // LoadSource loads a new static source from the given JSON file
 func LoadSource(path string) (*StaticSource, error) {
 	data, err := ioutil.ReadFile(path)
@@ -53,7 +95,40 @@ func LoadSource(path string) (*StaticSource, error) {
 	return NewSource(data)
 }
 
+// the asset types which can be loaded from separate files in a directory
+var assetFileTypes = []string{"channels", "classifiers", "fields", "flows", "globals", "groups", "labels", "locations", "resthooks", "templates", "ticketers"}
+
+// NewSourceFromFiles loads a new static source from the JSON files in the given directory, where each file is named
+// after the asset type it contains, e.g. channels.json, and contains an array of those assets. Missing files are
+// treated as having no assets of that type.
+func NewSourceFromFiles(dir string) (*StaticSource, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, errors.Wrapf(err, "error reading directory '%s'", dir)
+	}
+
+	all := make(map[string]json.RawMessage, len(assetFileTypes))
+
+	for _, assetType := range assetFileTypes {
+		path := filepath.Join(dir, assetType+".json")
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "error reading file '%s'", path)
+		}
+		all[assetType] = data
+	}
+
+	data, err := json.Marshal(all)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading files in '%s'", dir)
+	}
+	return NewSource(data)
+}
+
 var _ assets.Source = (*StaticSource)(nil)
+var _ assets.FlowEnumerator = (*StaticSource)(nil)
 
 // Channels returns all channel assets
 func (s *StaticSource) Channels() ([]assets.Channel, error) {
@@ -92,6 +167,15 @@ func (s *StaticSource) Flow(uuid assets.FlowUUID) (assets.Flow, error) {
 	return nil, errors.Errorf("no such flow with UUID '%s'", uuid)
 }
 
+// FlowUUIDs returns the UUIDs of all flow assets
+func (s *StaticSource) FlowUUIDs() []assets.FlowUUID {
+	uuids := make([]assets.FlowUUID, len(s.s.Flows))
+	for i := range s.s.Flows {
+		uuids[i] = s.s.Flows[i].UUID()
+	}
+	return uuids
+}
+
 // Globals returns all global assets
 func (s *StaticSource) Globals() ([]assets.Global, error) {
 	set := make([]assets.Global, len(s.s.Globals))
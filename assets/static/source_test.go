@@ -0,0 +1,153 @@
+package static_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows/engine"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSource(t *testing.T) {
+	source, err := static.NewSource([]byte(`{
+		"fields": [
+			{"uuid": "d66a7823-eada-40e5-9a3a-57239d4690bf", "key": "gender", "name": "Gender", "type": "text"}
+		],
+		"groups": [
+			{"uuid": "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", "name": "Testers"},
+			{"uuid": "4f1f98fc-27a7-4a69-bbdb-24744ba739a9", "name": "Males"}
+		]
+	}`))
+	require.NoError(t, err)
+
+	groups, err := source.Groups()
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(groups))
+
+	// two groups with the same UUID
+	_, err = static.NewSource([]byte(`{
+		"groups": [
+			{"uuid": "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", "name": "Testers"},
+			{"uuid": "4f1f98fc-27a7-4a69-bbdb-24744ba739a9", "name": "Males"},
+			{"uuid": "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", "name": "Testers 2"},
+			{"uuid": "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", "name": "Testers 3"}
+		]
+	}`))
+	assert.EqualError(t, err, "unable to read assets: duplicate group identifiers: b7cf0d83-f1c9-411c-96fd-c511a4cfa86d")
+
+	// duplicates across different asset types are all reported
+	_, err = static.NewSource([]byte(`{
+		"fields": [
+			{"uuid": "d66a7823-eada-40e5-9a3a-57239d4690bf", "key": "gender", "name": "Gender", "type": "text"},
+			{"uuid": "f1b5aea6-6586-41c7-9020-1a6326cc6565", "key": "gender", "name": "Sex", "type": "text"}
+		],
+		"labels": [
+			{"uuid": "3f65d88a-95dc-4140-9451-943e94e06fea", "name": "Spam"},
+			{"uuid": "3f65d88a-95dc-4140-9451-943e94e06fea", "name": "Spam"}
+		]
+	}`))
+	assert.EqualError(t, err, "unable to read assets: duplicate field identifiers: gender; duplicate label identifiers: 3f65d88a-95dc-4140-9451-943e94e06fea")
+
+	// same UUID for assets of different types is fine
+	_, err = static.NewSource([]byte(`{
+		"groups": [{"uuid": "3f65d88a-95dc-4140-9451-943e94e06fea", "name": "Spammers"}],
+		"labels": [{"uuid": "3f65d88a-95dc-4140-9451-943e94e06fea", "name": "Spam"}]
+	}`))
+	assert.NoError(t, err)
+}
+
+func TestNewSourceFromFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "assets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// an empty directory gives an empty source
+	source, err := static.NewSourceFromFiles(dir)
+	require.NoError(t, err)
+
+	groups, err := source.Groups()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(groups))
+
+	// write only some of the asset files
+	err = ioutil.WriteFile(filepath.Join(dir, "fields.json"), []byte(`[
+		{"uuid": "d66a7823-eada-40e5-9a3a-57239d4690bf", "key": "gender", "name": "Gender", "type": "text"}
+	]`), 0644)
+	require.NoError(t, err)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "groups.json"), []byte(`[
+		{"uuid": "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", "name": "Testers"},
+		{"uuid": "4f1f98fc-27a7-4a69-bbdb-24744ba739a9", "name": "Males"}
+	]`), 0644)
+	require.NoError(t, err)
+
+	source, err = static.NewSourceFromFiles(dir)
+	require.NoError(t, err)
+
+	fields, err := source.Fields()
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(fields))
+	assert.Equal(t, "gender", fields[0].Key())
+
+	groups, err = source.Groups()
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(groups))
+	assert.Equal(t, "Testers", groups[0].Name())
+
+	channels, err := source.Channels()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(channels))
+
+	assert.Equal(t, 0, len(source.FlowUUIDs()))
+
+	// assets are still validated
+	err = ioutil.WriteFile(filepath.Join(dir, "labels.json"), []byte(`[
+		{"uuid": "3f65d88a-95dc-4140-9451-943e94e06fea", "name": "Spam"},
+		{"uuid": "3f65d88a-95dc-4140-9451-943e94e06fea", "name": "Spam"}
+	]`), 0644)
+	require.NoError(t, err)
+
+	_, err = static.NewSourceFromFiles(dir)
+	assert.EqualError(t, err, "unable to read assets: duplicate label identifiers: 3f65d88a-95dc-4140-9451-943e94e06fea")
+
+	// error if directory doesn't exist
+	_, err = static.NewSourceFromFiles(filepath.Join(dir, "xxx"))
+	assert.Error(t, err)
+}
+
+func TestClassifiers(t *testing.T) {
+	source, err := static.NewSource([]byte(`{
+		"classifiers": [
+			{"uuid": "1c06c884-39dd-4ce4-ad9f-9a01cbe6c000", "name": "Booking", "type": "wit", "intents": ["book_flight", "book_hotel"]},
+			{"uuid": "ff2a817c-040a-4eb2-8404-7d92e8b79dd0", "name": "Support", "type": "luis", "intents": ["complain"]}
+		]
+	}`))
+	require.NoError(t, err)
+
+	classifiers, err := source.Classifiers()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(classifiers))
+	assert.Equal(t, assets.ClassifierUUID("1c06c884-39dd-4ce4-ad9f-9a01cbe6c000"), classifiers[0].UUID())
+	assert.Equal(t, "Booking", classifiers[0].Name())
+	assert.Equal(t, "wit", classifiers[0].Type())
+	assert.Equal(t, []string{"book_flight", "book_hotel"}, classifiers[0].Intents())
+
+	// and can be retrieved by UUID from session assets
+	sa, err := engine.NewSessionAssets(envs.NewBuilder().Build(), source, nil)
+	require.NoError(t, err)
+
+	support := sa.Classifiers().Get("ff2a817c-040a-4eb2-8404-7d92e8b79dd0")
+	require.NotNil(t, support)
+	assert.Equal(t, "Support", support.Name())
+	assert.Equal(t, "luis", support.Type())
+	assert.Equal(t, []string{"complain"}, support.Intents())
+
+	assert.Nil(t, sa.Classifiers().Get("33c829ad-7cc8-4a43-a8ed-0a2a6fdf9e5a"))
+}
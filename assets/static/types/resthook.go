@@ -20,3 +20,6 @@ func (r *Resthook) Slug() string { return r.Slug_ }
 
 // Subscribers returns the subscribers to the resthook
 func (r *Resthook) Subscribers() []string { return r.Subscribers_ }
+
+// SubscriberCount returns the number of subscribers to the resthook
+func (r *Resthook) SubscriberCount() int { return len(r.Subscribers_) }
@@ -12,4 +12,8 @@ func TestResthook(t *testing.T) {
 	hook := types.NewResthook("new-contact", []string{"http://example.com"})
 	assert.Equal(t, "new-contact", hook.Slug())
 	assert.Equal(t, []string{"http://example.com"}, hook.Subscribers())
+	assert.Equal(t, 1, hook.SubscriberCount())
+
+	hook = types.NewResthook("unpopular", []string{})
+	assert.Equal(t, 0, hook.SubscriberCount())
 }
@@ -49,6 +49,9 @@ func TestGenerateDocs(t *testing.T) {
 
 	functions := readJSONOutput(t, outputDir, "en-us", "functions.json").([]interface{})
 	assert.Equal(t, 80, len(functions))
+
+	schema := readJSONOutput(t, outputDir, "en-us", "schema.json").(map[string]interface{})
+	assert.Contains(t, schema, "definitions")
 }
 
 func readJSONOutput(t *testing.T, file ...string) interface{} {
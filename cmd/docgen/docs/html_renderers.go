@@ -430,10 +430,10 @@ func eventsForAction(action flows.Action, msgSession flows.Session, voiceSession
 	modifierLog := func(flows.Modifier) {}
 
 	eventList := make([]flows.Event, 0)
-	eventLog := func(e flows.Event) {
+	eventLog := flows.EventCallback(func(e flows.Event) {
 		e.SetStepUUID(step.UUID())
 		eventList = append(eventList, e)
-	}
+	})
 
 	err = action.Execute(run, step, modifierLog, eventLog)
 	if err != nil {
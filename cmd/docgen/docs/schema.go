@@ -0,0 +1,222 @@
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/goflow/flows/actions"
+	"github.com/nyaruka/goflow/flows/definition"
+	"github.com/nyaruka/goflow/flows/routers"
+	"github.com/nyaruka/goflow/flows/routers/waits"
+
+	"github.com/pkg/errors"
+)
+
+func init() {
+	RegisterGenerator(&schemaGenerator{})
+}
+
+type schemaGenerator struct{}
+
+func (g *schemaGenerator) Name() string {
+	return "flow definition schema"
+}
+
+func (g *schemaGenerator) Generate(baseDir, outputDir string, items map[string][]*TaggedItem, gettext func(string) string) error {
+	outputPath, err := WriteFlowSchema(outputDir)
+	if err != nil {
+		return err
+	}
+	fmt.Printf(" > flow definition schema written to %s\n", outputPath)
+	return nil
+}
+
+// WriteFlowSchema writes the flow definition schema to schema.json in the given directory and returns its path
+func WriteFlowSchema(outputDir string) (string, error) {
+	schema, err := FlowSchema()
+	if err != nil {
+		return "", err
+	}
+
+	outputPath := path.Join(outputDir, "schema.json")
+	if err := ioutil.WriteFile(outputPath, schema, 0644); err != nil {
+		return "", err
+	}
+	return outputPath, nil
+}
+
+// fields which are read as raw JSON or via custom unmarshaling, and the definitions their values should be validated against
+var schemaFieldRefs = map[string]string{
+	"nodes":      "node",
+	"exits":      "exit",
+	"actions":    "action",
+	"router":     "router",
+	"wait":       "wait",
+	"categories": "category",
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+var marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+type jsonSchema map[string]interface{}
+
+// FlowSchema generates a JSON Schema (draft-07) for flow definitions by reflecting over the types they are read from
+func FlowSchema() ([]byte, error) {
+	defs := make(map[string]jsonSchema)
+
+	for name, t := range definition.EnvelopeTypes() {
+		defs[name] = objectSchema(t)
+	}
+	defs["category"] = objectSchema(routers.CategoryEnvelopeType())
+
+	actionTypes := make(map[string]reflect.Type)
+	for name, fn := range actions.RegisteredTypes() {
+		actionTypes[name] = reflect.TypeOf(fn())
+	}
+	addTypedDefinitions(defs, "action", actionTypes)
+
+	routerTypes := routers.EnvelopeTypes()
+	for _, name := range routers.RegisteredTypes() {
+		if routerTypes[name] == nil {
+			return nil, errors.Errorf("no envelope type for router type '%s'", name)
+		}
+	}
+	addTypedDefinitions(defs, "router", routerTypes)
+	addTypedDefinitions(defs, "wait", waits.EnvelopeTypes())
+
+	return jsonx.MarshalPretty(jsonSchema{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"title":       "Flow",
+		"$ref":        "#/definitions/flow",
+		"definitions": defs,
+	})
+}
+
+// adds a definition for each of the given types, e.g. action_send_msg, and a definition which is one of them, e.g. action
+func addTypedDefinitions(defs map[string]jsonSchema, base string, types map[string]reflect.Type) {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	oneOf := make([]jsonSchema, len(names))
+	for i, name := range names {
+		def := objectSchema(types[name])
+		def["properties"].(map[string]jsonSchema)["type"] = jsonSchema{"const": name}
+
+		defs[base+"_"+name] = def
+		oneOf[i] = jsonSchema{"$ref": "#/definitions/" + base + "_" + name}
+	}
+
+	defs[base] = jsonSchema{"oneOf": oneOf}
+}
+
+// generates an object schema from the JSON tagged fields of a struct type, using validate tags to determine which are required
+func objectSchema(t reflect.Type) jsonSchema {
+	properties := make(map[string]jsonSchema)
+	required := make([]string, 0)
+
+	addStructFields(t, properties, &required)
+
+	schema := jsonSchema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+func addStructFields(t reflect.Type, properties map[string]jsonSchema, required *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+
+		// embedded structs without a JSON name have their fields promoted
+		if f.Anonymous && name == "" {
+			addStructFields(f.Type, properties, required)
+			continue
+		}
+		if f.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		var prop jsonSchema
+		if ref, isRef := schemaFieldRefs[name]; isRef {
+			prop = jsonSchema{"$ref": "#/definitions/" + ref}
+			if f.Type.Kind() == reflect.Slice && f.Type != rawMessageType {
+				prop = jsonSchema{"type": []string{"array", "null"}, "items": prop}
+			}
+		} else {
+			prop = typeSchema(f.Type)
+		}
+
+		for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+			if rule == "required" {
+				*required = append(*required, name)
+			} else if strings.HasPrefix(rule, "min=") && prop["type"] != nil && f.Type.Kind() == reflect.Slice {
+				if min, err := strconv.Atoi(rule[4:]); err == nil {
+					prop["minItems"] = min
+				}
+			}
+		}
+
+		properties[name] = prop
+	}
+}
+
+// generates the schema for a value of the given type
+func typeSchema(t reflect.Type) jsonSchema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	// raw JSON and types with their own JSON encoding could be anything
+	if t == rawMessageType || (t.Kind() == reflect.Struct && (t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType))) {
+		return jsonSchema{}
+	}
+
+	var schema jsonSchema
+
+	switch t.Kind() {
+	case reflect.String:
+		schema = jsonSchema{"type": "string"}
+	case reflect.Bool:
+		schema = jsonSchema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema = jsonSchema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		schema = jsonSchema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		schema = jsonSchema{"type": "array", "items": typeSchema(t.Elem())}
+		nullable = nullable || t.Kind() == reflect.Slice
+	case reflect.Map:
+		schema = jsonSchema{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+		nullable = true
+	case reflect.Struct:
+		schema = objectSchema(t)
+	default:
+		return jsonSchema{}
+	}
+
+	if nullable {
+		schema["type"] = []string{schema["type"].(string), "null"}
+	}
+	return schema
+}
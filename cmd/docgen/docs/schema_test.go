@@ -0,0 +1,102 @@
+package docs_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/buger/jsonparser"
+	"github.com/nyaruka/goflow/cmd/docgen/docs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+func TestFlowSchema(t *testing.T) {
+	schemaJSON, err := docs.FlowSchema()
+	require.NoError(t, err)
+
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schemaJSON))
+	require.NoError(t, err)
+
+	validate := func(flowJSON []byte) []string {
+		result, err := schema.Validate(gojsonschema.NewBytesLoader(flowJSON))
+		require.NoError(t, err)
+
+		errs := make([]string, len(result.Errors()))
+		for i, e := range result.Errors() {
+			errs[i] = e.String()
+		}
+		return errs
+	}
+
+	// sample flows which between them use every action type and both types of router and wait
+	for _, file := range []string{"all_actions.json", "ivr_dial.json", "subflow.json", "two_questions.json"} {
+		assetsJSON, err := ioutil.ReadFile("../../../test/testdata/runner/" + file)
+		require.NoError(t, err)
+
+		_, err = jsonparser.ArrayEach(assetsJSON, func(flowJSON []byte, dataType jsonparser.ValueType, offset int, err error) {
+			assert.Equal(t, []string{}, validate(flowJSON), "unexpected errors validating flows in %s", file)
+		}, "flows")
+		require.NoError(t, err)
+	}
+
+	// and some invalid flows
+	assert.NotEqual(t, []string{}, validate([]byte(`{"name": "Missing UUID", "spec_version": "13.1.0", "language": "eng", "type": "messaging", "nodes": []}`)))
+	assert.NotEqual(t, []string{}, validate([]byte(`{
+		"uuid": "76f0a02f-3b75-4b86-9064-e9195e1b3a02", "name": "Bad Action", "spec_version": "13.1.0", "language": "eng", "type": "messaging",
+		"nodes": [
+			{
+				"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507",
+				"actions": [{"uuid": "e97cd6d5-3354-4dbd-85bc-6c1f87849eec", "type": "send_msg", "text": 123}],
+				"exits": [{"uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6"}]
+			}
+		]
+	}`)))
+	assert.NotEqual(t, []string{}, validate([]byte(`{
+		"uuid": "76f0a02f-3b75-4b86-9064-e9195e1b3a02", "name": "Unknown Action", "spec_version": "13.1.0", "language": "eng", "type": "messaging",
+		"nodes": [
+			{
+				"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507",
+				"actions": [{"uuid": "e97cd6d5-3354-4dbd-85bc-6c1f87849eec", "type": "do_magic"}],
+				"exits": [{"uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6"}]
+			}
+		]
+	}`)))
+	assert.NotEqual(t, []string{}, validate([]byte(`{
+		"uuid": "76f0a02f-3b75-4b86-9064-e9195e1b3a02", "name": "No Exits", "spec_version": "13.1.0", "language": "eng", "type": "messaging",
+		"nodes": [{"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507", "exits": []}]
+	}`)))
+}
+
+func TestWriteFlowSchema(t *testing.T) {
+	outputDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(outputDir)
+
+	outputPath, err := docs.WriteFlowSchema(outputDir)
+	require.NoError(t, err)
+	assert.Equal(t, path.Join(outputDir, "schema.json"), outputPath)
+
+	// check file isn't executable
+	info, err := os.Stat(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0), info.Mode().Perm()&0111)
+
+	// and is a loadable schema which accepts a valid flow
+	schemaJSON, err := ioutil.ReadFile(outputPath)
+	require.NoError(t, err)
+
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schemaJSON))
+	require.NoError(t, err)
+
+	result, err := schema.Validate(gojsonschema.NewBytesLoader([]byte(`{
+		"uuid": "76f0a02f-3b75-4b86-9064-e9195e1b3a02", "name": "Valid", "spec_version": "13.1.0", "language": "eng", "type": "messaging",
+		"nodes": [{"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507", "exits": [{"uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6"}]}]
+	}`)))
+	require.NoError(t, err)
+	assert.True(t, result.Valid(), "unexpected errors: %v", result.Errors())
+}
@@ -3,11 +3,11 @@ package main_test
 import (
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	main "github.com/nyaruka/goflow/cmd/flowxgettext"
 	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/test"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,7 +15,7 @@ import (
 
 func TestFlowXGetText(t *testing.T) {
 	defer dates.SetNowSource(dates.DefaultNowSource)
-	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2020, 3, 25, 13, 57, 30, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2020-03-25T13:57:30.123456789Z")))
 
 	out := &strings.Builder{}
 
@@ -2,12 +2,12 @@ package contactql_test
 
 import (
 	"testing"
-	"time"
 
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/assets/static/types"
 	"github.com/nyaruka/goflow/contactql"
 	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/test"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -30,7 +30,7 @@ func TestEvaluateQuery(t *testing.T) {
 		"whatsapp": []interface{}{},
 		"gender":   []interface{}{"male"},
 		"age":      []interface{}{decimal.NewFromFloat(36)},
-		"dob":      []interface{}{time.Date(1981, 5, 28, 13, 30, 23, 0, time.UTC)},
+		"dob":      []interface{}{test.MustParseTime("1981-05-28T13:30:23Z")},
 		"state":    []interface{}{"Kigali"},
 		"district": []interface{}{"Gasabo"},
 		"ward":     []interface{}{"Ndera"},
@@ -333,7 +333,7 @@ func ParseQuery(env envs.Environment, text string, resolver Resolver) (*ContactQ
 	text = strings.TrimSpace(text)
 
 	// if query is a valid number, rewrite as a tel = query
-	if env.RedactionPolicy() != envs.RedactionPolicyURNs {
+	if !env.RedactionPolicy().RedactsURNs() {
 		if number := utils.ParsePhoneNumber(text, string(env.DefaultCountry())); number != "" {
 			text = fmt.Sprintf(`tel = %s`, number)
 		}
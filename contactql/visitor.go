@@ -83,7 +83,7 @@ func (v *visitor) VisitImplicitCondition(ctx *gen.ImplicitConditionContext) inte
 
 	asURN, _ := urns.Parse(value)
 
-	if v.env.RedactionPolicy() == envs.RedactionPolicyURNs {
+	if v.env.RedactionPolicy().RedactsURNs() {
 		num, err := strconv.Atoi(value)
 		if err == nil {
 			return newCondition(AttributeID, PropertyTypeAttribute, nil, OpEqual, strconv.Itoa(num), attributes[AttributeID])
@@ -133,7 +133,7 @@ func (v *visitor) VisitCondition(ctx *gen.ConditionContext) interface{} {
 	if isAttribute {
 		propType = PropertyTypeAttribute
 
-		if propKey == AttributeURN && v.env.RedactionPolicy() == envs.RedactionPolicyURNs && value != "" {
+		if propKey == AttributeURN && v.env.RedactionPolicy().RedactsURNs() && value != "" {
 			v.addError(NewQueryError(ErrRedactedURNs, "cannot query on redacted URNs"))
 		}
 
@@ -142,7 +142,7 @@ func (v *visitor) VisitCondition(ctx *gen.ConditionContext) interface{} {
 		propType = PropertyTypeScheme
 		valueType = assets.FieldTypeText
 
-		if v.env.RedactionPolicy() == envs.RedactionPolicyURNs && value != "" {
+		if v.env.RedactionPolicy().RedactsURNs() && value != "" {
 			v.addError(NewQueryError(ErrRedactedURNs, "cannot query on redacted URNs"))
 		}
 	} else {
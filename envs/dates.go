@@ -61,6 +61,18 @@ const (
 func (df DateFormat) String() string { return string(df) }
 func (tf TimeFormat) String() string { return string(tf) }
 
+// Format formats the given time in the given timezone and locale using this date format
+func (df DateFormat) Format(t time.Time, tz *time.Location, locale Locale) string {
+	formatted, _ := dates.Format(t.In(tz), string(df), locale.ToBCP47(), dates.DateOnlyLayouts)
+	return formatted
+}
+
+// Format formats the given time in the given timezone and locale using this time format
+func (tf TimeFormat) Format(t time.Time, tz *time.Location, locale Locale) string {
+	formatted, _ := dates.Format(t.In(tz), string(tf), locale.ToBCP47(), dates.TimeOnlyLayouts)
+	return formatted
+}
+
 // generic format for parsing any 8601 date
 var iso8601Format = "2006-01-02T15:04:05Z07:00"
 var iso8601NoSecondsFormat = "2006-01-02T15:04Z07:00"
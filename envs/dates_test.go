@@ -6,6 +6,7 @@ import (
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/test"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -87,7 +88,7 @@ func TestDateTimeFromString(t *testing.T) {
 		{envs.DateFormatYearMonthDay, envs.TimeFormatHourMinute, "UTC", false, "2001-02-01 03:15:34.123456", "01-02-2001 03:15:34.123456 +0000 UTC", false},
 	}
 
-	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 9, 13, 13, 36, 30, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-09-13T13:36:30.123456789Z")))
 	defer dates.SetNowSource(dates.DefaultNowSource)
 
 	for _, tc := range testCases {
@@ -206,3 +207,30 @@ func TestTimeFromString(t *testing.T) {
 		}
 	}
 }
+
+func TestDateAndTimeFormats(t *testing.T) {
+	tz, _ := time.LoadLocation("America/Los_Angeles")
+	d := test.MustParseTime("2020-03-05T02:04:06Z") // 6:04:06 pm on the 4th in LA
+
+	assert.Equal(t, "2020-03-05", envs.DateFormatYearMonthDay.Format(d, time.UTC, envs.NilLocale))
+	assert.Equal(t, "03-05-2020", envs.DateFormatMonthDayYear.Format(d, time.UTC, envs.NilLocale))
+	assert.Equal(t, "05-03-2020", envs.DateFormatDayMonthYear.Format(d, time.UTC, envs.NilLocale))
+	assert.Equal(t, "04-03-2020", envs.DateFormatDayMonthYear.Format(d, tz, envs.NilLocale))
+
+	assert.Equal(t, "02:04", envs.TimeFormatHourMinute.Format(d, time.UTC, envs.NilLocale))
+	assert.Equal(t, "2:04 am", envs.TimeFormatHourMinuteAmPm.Format(d, time.UTC, envs.NilLocale))
+	assert.Equal(t, "02:04:06", envs.TimeFormatHourMinuteSecond.Format(d, time.UTC, envs.NilLocale))
+	assert.Equal(t, "2:04:06 am", envs.TimeFormatHourMinuteSecondAmPm.Format(d, time.UTC, envs.NilLocale))
+	assert.Equal(t, "18:04", envs.TimeFormatHourMinute.Format(d, tz, envs.NilLocale))
+	assert.Equal(t, "6:04:06 pm", envs.TimeFormatHourMinuteSecondAmPm.Format(d, tz, envs.NilLocale))
+
+	// am/pm markers are localized
+	qatar := envs.NewLocale("ara", "QA")
+	spanish := envs.NewLocale("spa", "EC")
+
+	assert.Equal(t, "04-03-2020", envs.DateFormatDayMonthYear.Format(d, tz, qatar))
+	assert.Equal(t, "04-03-2020", envs.DateFormatDayMonthYear.Format(d, tz, spanish))
+	assert.Equal(t, "2:04 ص", envs.TimeFormatHourMinuteAmPm.Format(d, time.UTC, qatar))
+	assert.Equal(t, "6:04:06 م", envs.TimeFormatHourMinuteSecondAmPm.Format(d, tz, qatar))
+	assert.Equal(t, "18:04", envs.TimeFormatHourMinute.Format(d, tz, qatar))
+}
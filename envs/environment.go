@@ -14,8 +14,20 @@ type RedactionPolicy string
 const (
 	RedactionPolicyNone RedactionPolicy = "none"
 	RedactionPolicyURNs RedactionPolicy = "urns"
+	RedactionPolicyFull RedactionPolicy = "full"
 )
 
+// RedactsURNs returns whether URNs should be redacted under this policy
+func (p RedactionPolicy) RedactsURNs() bool {
+	return p == RedactionPolicyURNs || p == RedactionPolicyFull
+}
+
+// RedactsContactDetails returns whether contact names, field values and URNs in message text should be redacted
+// under this policy
+func (p RedactionPolicy) RedactsContactDetails() bool {
+	return p == RedactionPolicyFull
+}
+
 // NumberFormat describes how numbers should be parsed and formatted
 type NumberFormat struct {
 	DecimalSymbol       string `json:"decimal_symbol"`
@@ -39,6 +51,7 @@ type Environment interface {
 	MaxValueLength() int
 
 	DefaultLocale() Locale
+	ResolveLanguage(Language) Language
 
 	LocationResolver() LocationResolver
 
@@ -75,6 +88,19 @@ func (e *environment) DefaultLocale() Locale {
 	return NewLocale(e.DefaultLanguage(), e.DefaultCountry())
 }
 
+// ResolveLanguage resolves the language to use for a contact with the given language, which is that language if
+// it is one of the allowed languages, and otherwise the default language
+func (e *environment) ResolveLanguage(contactLang Language) Language {
+	if contactLang != NilLanguage {
+		for _, l := range e.AllowedLanguages() {
+			if l == contactLang {
+				return contactLang
+			}
+		}
+	}
+	return e.DefaultLanguage()
+}
+
 func (e *environment) LocationResolver() LocationResolver { return nil }
 
 // Now gets the current time in the eonvironment's timezone
@@ -99,7 +125,7 @@ type envEnvelope struct {
 	AllowedLanguages []Language      `json:"allowed_languages,omitempty" validate:"omitempty,dive,language"`
 	NumberFormat     *NumberFormat   `json:"number_format,omitempty"`
 	DefaultCountry   Country         `json:"default_country,omitempty" validate:"omitempty,country"`
-	RedactionPolicy  RedactionPolicy `json:"redaction_policy" validate:"omitempty,eq=none|eq=urns"`
+	RedactionPolicy  RedactionPolicy `json:"redaction_policy" validate:"omitempty,eq=none|eq=urns|eq=full"`
 	MaxValuelength   int             `json:"max_value_length"`
 }
 
@@ -119,3 +119,34 @@ func TestEnvironmentBuilder(t *testing.T) {
 	assert.Equal(t, 1024, env.MaxValueLength())
 	assert.Nil(t, env.LocationResolver())
 }
+
+func TestRedactionPolicy(t *testing.T) {
+	assert.False(t, envs.RedactionPolicyNone.RedactsURNs())
+	assert.False(t, envs.RedactionPolicyNone.RedactsContactDetails())
+	assert.True(t, envs.RedactionPolicyURNs.RedactsURNs())
+	assert.False(t, envs.RedactionPolicyURNs.RedactsContactDetails())
+	assert.True(t, envs.RedactionPolicyFull.RedactsURNs())
+	assert.True(t, envs.RedactionPolicyFull.RedactsContactDetails())
+
+	env, err := envs.ReadEnvironment([]byte(`{"date_format": "DD-MM-YYYY", "time_format": "tt:mm", "timezone": "UTC", "redaction_policy": "full"}`))
+	require.NoError(t, err)
+	assert.Equal(t, envs.RedactionPolicyFull, env.RedactionPolicy())
+
+	_, err = envs.ReadEnvironment([]byte(`{"date_format": "DD-MM-YYYY", "time_format": "tt:mm", "timezone": "UTC", "redaction_policy": "some"}`))
+	assert.Error(t, err)
+}
+
+func TestResolveLanguage(t *testing.T) {
+	env := envs.NewBuilder().
+		WithDefaultLanguage(envs.Language("eng")).
+		WithAllowedLanguages([]envs.Language{envs.Language("eng"), envs.Language("fra")}).
+		Build()
+
+	assert.Equal(t, envs.Language("fra"), env.ResolveLanguage(envs.Language("fra"))) // exact match
+	assert.Equal(t, envs.Language("eng"), env.ResolveLanguage(envs.Language("kin"))) // not allowed so fallback
+	assert.Equal(t, envs.Language("eng"), env.ResolveLanguage(envs.NilLanguage))     // no contact language
+
+	// no allowed languages means everything falls back to the default
+	env = envs.NewBuilder().WithDefaultLanguage(envs.Language("spa")).Build()
+	assert.Equal(t, envs.Language("spa"), env.ResolveLanguage(envs.Language("fra")))
+}
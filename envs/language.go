@@ -5,6 +5,7 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
 	"gopkg.in/go-playground/validator.v9"
 )
 
@@ -33,3 +34,37 @@ func ParseLanguage(lang string) (Language, error) {
 
 	return Language(base.ISO3()), nil
 }
+
+// DisplayName returns the English name of this language, e.g. "English" for "eng"
+func (l Language) DisplayName() (string, error) {
+	base, err := language.ParseBase(string(l))
+	if err != nil {
+		return "", errors.Errorf("unrecognized language code: %s", l)
+	}
+
+	name := display.English.Languages().Name(base)
+	if name == "" {
+		return "", errors.Errorf("no display name for language: %s", l)
+	}
+	return name, nil
+}
+
+// DisplayNameIn returns the name of this language in the given language, e.g. "français" for "fra" in "fra". If the
+// name isn't available in that language, the English name is returned.
+func (l Language) DisplayNameIn(target Language) string {
+	base, err := language.ParseBase(string(l))
+	if err != nil {
+		return ""
+	}
+
+	if targetBase, err := language.ParseBase(string(target)); err == nil {
+		if namer := display.Languages(language.Make(targetBase.String())); namer != nil {
+			if name := namer.Name(base); name != "" {
+				return name
+			}
+		}
+	}
+
+	name, _ := l.DisplayName()
+	return name
+}
@@ -18,4 +18,32 @@ func TestLanguage(t *testing.T) {
 
 	_, err = envs.ParseLanguage("xzx")
 	assert.EqualError(t, err, "unrecognized language code: xzx")
+
+	for _, tc := range []struct {
+		lang        envs.Language
+		displayName string
+	}{
+		{"eng", "English"},
+		{"fra", "French"},
+		{"spa", "Spanish"},
+		{"por", "Portuguese"},
+		{"kin", "Kinyarwanda"},
+		{"ara", "Arabic"},
+	} {
+		name, err := tc.lang.DisplayName()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.displayName, name, "display name mismatch for %s", tc.lang)
+	}
+
+	_, err = envs.Language("xzx").DisplayName()
+	assert.EqualError(t, err, "unrecognized language code: xzx")
+
+	_, err = envs.NilLanguage.DisplayName()
+	assert.Error(t, err)
+
+	assert.Equal(t, "français", envs.Language("fra").DisplayNameIn("fra"))
+	assert.Equal(t, "inglés", envs.Language("eng").DisplayNameIn("spa"))
+	assert.Equal(t, "French", envs.Language("fra").DisplayNameIn("eng"))
+	assert.Equal(t, "French", envs.Language("fra").DisplayNameIn("xzx"))
+	assert.Equal(t, "", envs.Language("xzx").DisplayNameIn("eng"))
 }
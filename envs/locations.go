@@ -21,6 +21,13 @@ type LocationResolver interface {
 	LookupLocation(LocationPath) *Location
 }
 
+// SimilarLocationResolver is implemented by location resolvers which can also find locations with similar names
+type SimilarLocationResolver interface {
+	LocationResolver
+
+	FindLocationsSimilar(string, LocationLevel, *Location) []*Location
+}
+
 const (
 	LocationPathSeparator = ">"
 )
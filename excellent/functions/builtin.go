@@ -1677,7 +1677,7 @@ func FormatDate(env envs.Environment, args ...types.XValue) types.XValue {
 		return types.NewXText(formatted)
 	}
 
-	return types.NewXText(date.Format(env))
+	return types.NewXText(env.DateFormat().Format(date.Native().Combine(dates.ZeroTimeOfDay, env.Timezone()), env.Timezone(), env.DefaultLocale()))
 }
 
 // FormatDateTime formats `datetime` as text according to the given `format`.
@@ -1810,7 +1810,7 @@ func FormatTime(env envs.Environment, args ...types.XValue) types.XValue {
 		return types.NewXText(formatted)
 	}
 
-	return types.NewXText(t.Format(env))
+	return types.NewXText(env.TimeFormat().Format(t.Native().Combine(dates.ZeroDate, env.Timezone()), env.Timezone(), env.DefaultLocale()))
 }
 
 // FormatNumber formats `number` to the given number of decimal `places`.
@@ -37,6 +37,12 @@ func TestFunctions(t *testing.T) {
 		WithTimeFormat(envs.TimeFormatHourMinuteAmPm).
 		WithTimezone(la).
 		Build()
+	ara := envs.NewBuilder().
+		WithDateFormat(envs.DateFormatDayMonthYear).
+		WithTimeFormat(envs.TimeFormatHourMinuteAmPm).
+		WithDefaultLanguage("ara").
+		WithDefaultCountry("QA").
+		Build()
 
 	var funcTests = []struct {
 		name     string
@@ -127,28 +133,28 @@ func TestFunctions(t *testing.T) {
 		{"date_from_parts", dmy, []types.XValue{xi(2018), xi(11), ERROR}, ERROR},
 		{"date_from_parts", dmy, []types.XValue{}, ERROR},
 
-		{"datetime", dmy, []types.XValue{xs("01-12-2017")}, xdt(time.Date(2017, 12, 1, 0, 0, 0, 0, time.UTC))},
+		{"datetime", dmy, []types.XValue{xs("01-12-2017")}, xdt(test.MustParseDate("2017-12-01"))},
 		{"datetime", mdy, []types.XValue{xs("12-01-2017")}, xdt(time.Date(2017, 12, 1, 0, 0, 0, 0, la))},
-		{"datetime", dmy, []types.XValue{xs("01-12-2017 10:15pm")}, xdt(time.Date(2017, 12, 1, 22, 15, 0, 0, time.UTC))},
+		{"datetime", dmy, []types.XValue{xs("01-12-2017 10:15pm")}, xdt(test.MustParseTime("2017-12-01T22:15:00Z"))},
 		{"datetime", dmy, []types.XValue{xs("01.15.2017")}, ERROR}, // month out of range
 		{"datetime", dmy, []types.XValue{xs("no date")}, ERROR},    // invalid date
 		{"datetime", dmy, []types.XValue{}, ERROR},
 
-		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("2"), xs("Y")}, xdt(time.Date(2019, 12, 03, 22, 15, 0, 0, time.UTC))},
+		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("2"), xs("Y")}, xdt(test.MustParseTime("2019-12-03T22:15:00Z"))},
 		{"datetime_add", mdy, []types.XValue{xs("12-03-2017 10:15pm"), xs("2"), xs("Y")}, xdt(time.Date(2019, 12, 03, 22, 15, 0, 0, la))},
-		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("-2"), xs("Y")}, xdt(time.Date(2015, 12, 03, 22, 15, 0, 0, time.UTC))},
-		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("2"), xs("M")}, xdt(time.Date(2018, 2, 03, 22, 15, 0, 0, time.UTC))},
-		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("-2"), xs("M")}, xdt(time.Date(2017, 10, 3, 22, 15, 0, 0, time.UTC))},
-		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("2"), xs("W")}, xdt(time.Date(2017, 12, 17, 22, 15, 0, 0, time.UTC))},
-		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("-2"), xs("W")}, xdt(time.Date(2017, 11, 19, 22, 15, 0, 0, time.UTC))},
-		{"datetime_add", dmy, []types.XValue{xs("03-12-2017"), xs("2"), xs("D")}, xdt(time.Date(2017, 12, 5, 0, 0, 0, 0, time.UTC))},
-		{"datetime_add", dmy, []types.XValue{xs("03-12-2017"), xs("-4"), xs("D")}, xdt(time.Date(2017, 11, 29, 0, 0, 0, 0, time.UTC))},
-		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("2"), xs("h")}, xdt(time.Date(2017, 12, 4, 0, 15, 0, 0, time.UTC))},
-		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("-2"), xs("h")}, xdt(time.Date(2017, 12, 3, 20, 15, 0, 0, time.UTC))},
-		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("105"), xs("m")}, xdt(time.Date(2017, 12, 4, 0, 0, 0, 0, time.UTC))},
-		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("-20"), xs("m")}, xdt(time.Date(2017, 12, 3, 21, 55, 0, 0, time.UTC))},
-		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("2"), xs("s")}, xdt(time.Date(2017, 12, 3, 22, 15, 2, 0, time.UTC))},
-		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("-2"), xs("s")}, xdt(time.Date(2017, 12, 3, 22, 14, 58, 0, time.UTC))},
+		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("-2"), xs("Y")}, xdt(test.MustParseTime("2015-12-03T22:15:00Z"))},
+		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("2"), xs("M")}, xdt(test.MustParseTime("2018-02-03T22:15:00Z"))},
+		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("-2"), xs("M")}, xdt(test.MustParseTime("2017-10-03T22:15:00Z"))},
+		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("2"), xs("W")}, xdt(test.MustParseTime("2017-12-17T22:15:00Z"))},
+		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("-2"), xs("W")}, xdt(test.MustParseTime("2017-11-19T22:15:00Z"))},
+		{"datetime_add", dmy, []types.XValue{xs("03-12-2017"), xs("2"), xs("D")}, xdt(test.MustParseDate("2017-12-05"))},
+		{"datetime_add", dmy, []types.XValue{xs("03-12-2017"), xs("-4"), xs("D")}, xdt(test.MustParseDate("2017-11-29"))},
+		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("2"), xs("h")}, xdt(test.MustParseTime("2017-12-04T00:15:00Z"))},
+		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("-2"), xs("h")}, xdt(test.MustParseTime("2017-12-03T20:15:00Z"))},
+		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("105"), xs("m")}, xdt(test.MustParseDate("2017-12-04"))},
+		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("-20"), xs("m")}, xdt(test.MustParseTime("2017-12-03T21:55:00Z"))},
+		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("2"), xs("s")}, xdt(test.MustParseTime("2017-12-03T22:15:02Z"))},
+		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15pm"), xs("-2"), xs("s")}, xdt(test.MustParseTime("2017-12-03T22:14:58Z"))},
 		{"datetime_add", dmy, []types.XValue{xs("xxx"), xs("2"), xs("D")}, ERROR},
 		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15"), xs("xxx"), xs("D")}, ERROR},
 		{"datetime_add", dmy, []types.XValue{xs("03-12-2017 10:15"), xs("2"), xs("xxx")}, ERROR},
@@ -190,7 +196,7 @@ func TestFunctions(t *testing.T) {
 		{"datetime_diff", mdy, []types.XValue{xs("03-10-2019 1:00am"), xs("03-11-2019 1:00am"), xs("h")}, xi(23)},
 		{"datetime_diff", mdy, []types.XValue{xs("03-10-2019 1:00am"), xs("03-11-2019 1:00am"), xs("D")}, xi(1)},
 
-		{"datetime_from_epoch", dmy, []types.XValue{xn("1497286619.000000000")}, xdt(time.Date(2017, 6, 12, 16, 56, 59, 0, time.UTC))},
+		{"datetime_from_epoch", dmy, []types.XValue{xn("1497286619.000000000")}, xdt(test.MustParseTime("2017-06-12T16:56:59Z"))},
 		{"datetime_from_epoch", dmy, []types.XValue{ERROR}, ERROR},
 		{"datetime_from_epoch", dmy, []types.XValue{}, ERROR},
 
@@ -238,7 +244,7 @@ func TestFunctions(t *testing.T) {
 		},
 		{"extract_object", dmy, []types.XValue{}, ERROR},
 
-		{"epoch", dmy, []types.XValue{xdt(time.Date(2017, 6, 12, 16, 56, 59, 0, time.UTC))}, xn("1497286619")},
+		{"epoch", dmy, []types.XValue{xdt(test.MustParseTime("2017-06-12T16:56:59Z"))}, xn("1497286619")},
 		{"epoch", dmy, []types.XValue{ERROR}, ERROR},
 		{"epoch", dmy, []types.XValue{}, ERROR},
 
@@ -274,12 +280,14 @@ func TestFunctions(t *testing.T) {
 
 		{"format", dmy, []types.XValue{xn("1234")}, xs("1,234")},
 		{"format", dmy, []types.XValue{xd(dates.NewDate(2017, 6, 12))}, xs("12-06-2017")},
-		{"format", dmy, []types.XValue{xdt(time.Date(2017, 6, 12, 16, 56, 59, 0, time.UTC))}, xs("12-06-2017 16:56")},
+		{"format", dmy, []types.XValue{xdt(test.MustParseTime("2017-06-12T16:56:59Z"))}, xs("12-06-2017 16:56")},
 		{"format", dmy, []types.XValue{nil}, xs("")},
 
 		{"format_date", dmy, []types.XValue{xs("1977-06-23T15:34:00.000000Z")}, xs("23-06-1977")},
 		{"format_date", mdy, []types.XValue{xs("1977-06-23T15:34:00.000000Z")}, xs("06-23-1977")},
+		{"format_date", ara, []types.XValue{xs("1977-06-23T15:34:00.000000Z")}, xs("23-06-1977")},
 		{"format_date", dmy, []types.XValue{xs("1977-06-23T15:34:00.000000Z"), xs("YYYY-MM-DD")}, xs("1977-06-23")},
+		{"format_date", ara, []types.XValue{xs("1977-06-23T15:34:00.000000Z"), xs("EEEE D MMMM")}, xs("الخميس 23 يونيو")},
 		{"format_date", dmy, []types.XValue{xs("1977-06-23"), xs("YYYY/MM/DD")}, xs("1977/06/23")},
 		{"format_date", dmy, []types.XValue{xs("NOT DATE")}, ERROR},
 		{"format_date", dmy, []types.XValue{ERROR}, ERROR},
@@ -290,6 +298,8 @@ func TestFunctions(t *testing.T) {
 
 		{"format_datetime", dmy, []types.XValue{xs("1977-06-23T15:34:00.000000Z")}, xs("23-06-1977 15:34")},
 		{"format_datetime", mdy, []types.XValue{xs("1977-06-23T15:34:00.000000Z")}, xs("06-23-1977 8:34 am")},
+		{"format_datetime", ara, []types.XValue{xs("1977-06-23T15:34:00.000000Z")}, xs("23-06-1977 3:34 م")},
+		{"format_datetime", ara, []types.XValue{xs("1977-06-23T15:34:00.000000Z"), xs("EEEE h:mm aa")}, xs("الخميس 3:34 م")},
 		{"format_datetime", dmy, []types.XValue{xs("1977-06-23T15:34:00.000000Z"), xs("YYYY-MM-DDTtt:mm:ss.fffZZZ"), xs("America/Los_Angeles")}, xs("1977-06-23T08:34:00.000-07:00")},
 		{"format_datetime", dmy, []types.XValue{xs("1977-06-23T15:34:00.123000Z"), xs("YYYY-MM-DDTtt:mm:ss.fffZ"), xs("America/Los_Angeles")}, xs("1977-06-23T08:34:00.123-07:00")},
 		{"format_datetime", dmy, []types.XValue{xs("1977-06-23T15:34:00.000000Z"), xs("YYYY-MM-DDTtt:mm:ss.ffffffZ"), xs("America/Los_Angeles")}, xs("1977-06-23T08:34:00.000000-07:00")},
@@ -308,6 +318,8 @@ func TestFunctions(t *testing.T) {
 
 		{"format_time", dmy, []types.XValue{xs("15:34:00.000000")}, xs("15:34")},
 		{"format_time", mdy, []types.XValue{xs("15:34:00.000000")}, xs("3:34 pm")},
+		{"format_time", ara, []types.XValue{xs("15:34:00.000000")}, xs("3:34 م")},
+		{"format_time", ara, []types.XValue{xs("09:34:00.000000"), xs("hh:mm aa")}, xs("09:34 ص")},
 		{"format_time", dmy, []types.XValue{xs("15:34:00.000000"), xs("tt")}, xs("15")},
 		{"format_time", dmy, []types.XValue{xs("15:34:00.000000"), xs("YY")}, ERROR},
 		{"format_time", dmy, []types.XValue{xs("15:34:00.000000"), ERROR}, ERROR},
@@ -365,8 +377,8 @@ func TestFunctions(t *testing.T) {
 		{"json", dmy, []types.XValue{nil}, xs(`null`)},
 		{"json", dmy, []types.XValue{ERROR}, ERROR},
 
-		{"legacy_add", dmy, []types.XValue{xs("01-12-2017"), xi(2)}, xdt(time.Date(2017, 12, 3, 0, 0, 0, 0, time.UTC))},
-		{"legacy_add", dmy, []types.XValue{xs("2"), xs("01-12-2017 10:15:33pm")}, xdt(time.Date(2017, 12, 3, 22, 15, 33, 0, time.UTC))},
+		{"legacy_add", dmy, []types.XValue{xs("01-12-2017"), xi(2)}, xdt(test.MustParseDate("2017-12-03"))},
+		{"legacy_add", dmy, []types.XValue{xs("2"), xs("01-12-2017 10:15:33pm")}, xdt(test.MustParseTime("2017-12-03T22:15:33Z"))},
 		{"legacy_add", dmy, []types.XValue{xs("2"), xs("3.5")}, xn("5.5")},
 		{"legacy_add", dmy, []types.XValue{xs("01-12-2017 10:15:33pm"), xs("01-12-2017")}, ERROR},
 		{"legacy_add", dmy, []types.XValue{types.NewXNumberFromInt64(int64(math.MaxInt32 + 1)), xs("01-12-2017 10:15:33pm")}, ERROR},
@@ -409,7 +421,7 @@ func TestFunctions(t *testing.T) {
 		{"mod", dmy, []types.XValue{xs("9"), xs("not_num")}, ERROR},
 		{"mod", dmy, []types.XValue{}, ERROR},
 
-		{"now", dmy, []types.XValue{}, xdt(time.Date(2018, 4, 11, 13, 24, 30, 123456000, time.UTC))},
+		{"now", dmy, []types.XValue{}, xdt(test.MustParseTime("2018-04-11T13:24:30.123456Z"))},
 		{"now", dmy, []types.XValue{ERROR}, ERROR},
 
 		{"number", dmy, []types.XValue{xn("10")}, xn("10")},
@@ -712,7 +724,7 @@ func TestFunctions(t *testing.T) {
 	defer dates.SetNowSource(dates.DefaultNowSource)
 
 	random.SetGenerator(random.NewSeededGenerator(123456))
-	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 4, 11, 13, 24, 30, 123456000, time.UTC)))
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-04-11T13:24:30.123456Z")))
 
 	for _, tc := range funcTests {
 		testID := fmt.Sprintf("%s(%#v)", tc.name, tc.args)
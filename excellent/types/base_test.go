@@ -8,6 +8,7 @@ import (
 	"github.com/nyaruka/gocommon/jsonx"
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent/types"
+	"github.com/nyaruka/goflow/test"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -18,7 +19,7 @@ func TestXValue(t *testing.T) {
 	chi, err := time.LoadLocation("America/Chicago")
 	require.NoError(t, err)
 
-	date1 := time.Date(2017, 6, 23, 15, 30, 0, 0, time.UTC)
+	date1 := test.MustParseTime("2017-06-23T15:30:00Z")
 	date2 := time.Date(2017, 7, 18, 15, 30, 0, 0, chi)
 	object1 := types.NewXObject(map[string]types.XValue{
 		"foo": types.NewXText("Hello"),
@@ -202,8 +203,8 @@ func TestEquals(t *testing.T) {
 		{types.NewXDate(dates.NewDate(2018, 4, 9)), types.NewXDate(dates.NewDate(2018, 4, 9)), true},
 		{types.NewXDate(dates.NewDate(2019, 4, 9)), types.NewXDate(dates.NewDate(2018, 4, 10)), false},
 
-		{types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC)), types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC)), true},
-		{types.NewXDateTime(time.Date(2019, 4, 9, 17, 1, 30, 0, time.UTC)), types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC)), false},
+		{types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z")), types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z")), true},
+		{types.NewXDateTime(test.MustParseTime("2019-04-09T17:01:30Z")), types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z")), false},
 
 		{
 			types.NewXObject(map[string]types.XValue{"foo": types.XBooleanFalse, "bar": types.NewXText("bob")}),
@@ -2,11 +2,11 @@ package types_test
 
 import (
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent/types"
+	"github.com/nyaruka/goflow/test"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -59,7 +59,7 @@ func TestToXDate(t *testing.T) {
 		{types.NewXNumberFromInt(123), types.XDateZero, true},
 		{types.NewXText("2018-01-20"), types.NewXDate(dates.NewDate(2018, 1, 20)), false},
 		{types.NewXDate(dates.NewDate(2018, 4, 19)), types.NewXDate(dates.NewDate(2018, 4, 19)), false},
-		{types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC)), types.NewXDate(dates.NewDate(2018, 4, 9)), false},
+		{types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z")), types.NewXDate(dates.NewDate(2018, 4, 9)), false},
 		{types.NewXObject(map[string]types.XValue{
 			"__default__": types.NewXText("2018-01-20"), // should use default
 			"foo":         types.NewXNumberFromInt(234),
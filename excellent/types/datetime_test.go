@@ -8,6 +8,7 @@ import (
 	"github.com/nyaruka/gocommon/jsonx"
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent/types"
+	"github.com/nyaruka/goflow/test"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -17,24 +18,24 @@ func TestXDateTime(t *testing.T) {
 	env := envs.NewBuilder().WithDateFormat(envs.DateFormatDayMonthYear).Build()
 	env2 := envs.NewBuilder().WithDateFormat(envs.DateFormatYearMonthDay).WithDefaultLanguage("spa").Build()
 
-	assert.True(t, types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 123456789, time.UTC)).Truthy())
+	assert.True(t, types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30.123456789Z")).Truthy())
 
 	// test stringing
-	assert.Equal(t, `2018-04-09T17:01:30.123456Z`, types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 123456789, time.UTC)).Render())
-	assert.Equal(t, `09-04-2018 17:01`, types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 123456789, time.UTC)).Format(env))
-	assert.Equal(t, `XDateTime(2018, 4, 9, 17, 1, 30, 123456789, UTC)`, types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 123456789, time.UTC)).String())
+	assert.Equal(t, `2018-04-09T17:01:30.123456Z`, types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30.123456789Z")).Render())
+	assert.Equal(t, `09-04-2018 17:01`, types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30.123456789Z")).Format(env))
+	assert.Equal(t, `XDateTime(2018, 4, 9, 17, 1, 30, 123456789, UTC)`, types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30.123456789Z")).String())
 
-	asJSON, _ := types.ToXJSON(types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 123456789, time.UTC)))
+	asJSON, _ := types.ToXJSON(types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30.123456789Z")))
 	assert.Equal(t, types.NewXText(`"2018-04-09T17:01:30.123456Z"`), asJSON)
 
 	// test equality
-	assert.True(t, types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC)).Equals(types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC))))
-	assert.False(t, types.NewXDateTime(time.Date(2019, 4, 9, 17, 1, 30, 0, time.UTC)).Equals(types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC))))
+	assert.True(t, types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z")).Equals(types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z"))))
+	assert.False(t, types.NewXDateTime(test.MustParseTime("2019-04-09T17:01:30Z")).Equals(types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z"))))
 
 	// test comparisons
-	assert.Equal(t, 0, types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC)).Compare(types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC))))
-	assert.Equal(t, 1, types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 31, 0, time.UTC)).Compare(types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC))))
-	assert.Equal(t, -1, types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 29, 0, time.UTC)).Compare(types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC))))
+	assert.Equal(t, 0, types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z")).Compare(types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z"))))
+	assert.Equal(t, 1, types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:31Z")).Compare(types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z"))))
+	assert.Equal(t, -1, types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:29Z")).Compare(types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z"))))
 
 	la, _ := time.LoadLocation("America/Los_Angeles")
 
@@ -66,10 +67,10 @@ func TestXDateTime(t *testing.T) {
 	var date types.XDateTime
 	err = jsonx.Unmarshal([]byte(`"2018-04-09T17:01:30Z"`), &date)
 	assert.NoError(t, err)
-	assert.Equal(t, types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC)), date)
+	assert.Equal(t, types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z")), date)
 
 	// test marshaling
-	data, err := jsonx.Marshal(types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC)))
+	data, err := jsonx.Marshal(types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z")))
 	assert.NoError(t, err)
 	assert.Equal(t, `"2018-04-09T17:01:30.000000Z"`, string(data))
 }
@@ -83,14 +84,14 @@ func TestToXDateTime(t *testing.T) {
 		{nil, types.XDateTimeZero, true},
 		{types.NewXError(errors.Errorf("Error")), types.XDateTimeZero, true},
 		{types.NewXNumberFromInt(123), types.XDateTimeZero, true},
-		{types.NewXText("2018-06-05"), types.NewXDateTime(time.Date(2018, 6, 5, 0, 0, 0, 0, time.UTC)), false},
+		{types.NewXText("2018-06-05"), types.NewXDateTime(test.MustParseDate("2018-06-05")), false},
 		{types.NewXText("wha?"), types.XDateTimeZero, true},
-		{types.NewXDate(dates.NewDate(2018, 4, 9)), types.NewXDateTime(time.Date(2018, 4, 9, 0, 0, 0, 0, time.UTC)), false},
-		{types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC)), types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC)), false},
+		{types.NewXDate(dates.NewDate(2018, 4, 9)), types.NewXDateTime(test.MustParseDate("2018-04-09")), false},
+		{types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z")), types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z")), false},
 		{types.NewXObject(map[string]types.XValue{
 			"__default__": types.NewXText("2018-06-05"), // should use default
 			"foo":         types.NewXNumberFromInt(234),
-		}), types.NewXDateTime(time.Date(2018, 6, 5, 0, 0, 0, 0, time.UTC)), false},
+		}), types.NewXDateTime(test.MustParseDate("2018-06-05")), false},
 	}
 
 	env := envs.NewBuilder().Build()
@@ -108,11 +109,11 @@ func TestToXDateTime(t *testing.T) {
 }
 
 func TestToXDateTimeWithTimeFill(t *testing.T) {
-	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 9, 13, 13, 36, 30, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-09-13T13:36:30.123456789Z")))
 	defer dates.SetNowSource(dates.DefaultNowSource)
 
 	env := envs.NewBuilder().Build()
 	result, err := types.ToXDateTimeWithTimeFill(env, types.NewXText("2018/12/20"))
 	assert.NoError(t, err)
-	assert.Equal(t, types.NewXDateTime(time.Date(2018, 12, 20, 13, 36, 30, 123456789, time.UTC)), result)
+	assert.Equal(t, types.NewXDateTime(test.MustParseTime("2018-12-20T13:36:30.123456789Z")), result)
 }
@@ -2,12 +2,12 @@ package types_test
 
 import (
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/jsonx"
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent/types"
+	"github.com/nyaruka/goflow/test"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -61,7 +61,7 @@ func TestToXTime(t *testing.T) {
 		{types.NewXText("10 PM"), types.NewXTime(dates.NewTimeOfDay(22, 0, 0, 0)), false},
 		{types.NewXText("wha?"), types.XTimeZero, true},
 		{types.NewXTime(dates.NewTimeOfDay(17, 1, 30, 0)), types.NewXTime(dates.NewTimeOfDay(17, 1, 30, 0)), false},
-		{types.NewXDateTime(time.Date(2018, 4, 9, 17, 1, 30, 0, time.UTC)), types.NewXTime(dates.NewTimeOfDay(17, 1, 30, 0)), false},
+		{types.NewXDateTime(test.MustParseTime("2018-04-09T17:01:30Z")), types.NewXTime(dates.NewTimeOfDay(17, 1, 30, 0)), false},
 		{types.NewXObject(map[string]types.XValue{
 			"__default__": types.NewXText("10:30"), // should use default
 			"foo":         types.NewXNumberFromInt(234),
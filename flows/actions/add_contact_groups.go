@@ -43,10 +43,10 @@ func NewAddContactGroups(uuid flows.ActionUUID, groups []*assets.GroupReference)
 }
 
 // Execute adds our contact to the specified groups
-func (a *AddContactGroupsAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *AddContactGroupsAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	contact := run.Contact()
 	if contact == nil {
-		logEvent(events.NewErrorf("can't execute action in session without a contact"))
+		logEvent.Add(events.NewErrorf("can't execute action in session without a contact"))
 		return nil
 	}
 
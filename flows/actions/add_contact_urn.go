@@ -46,11 +46,11 @@ func NewAddContactURN(uuid flows.ActionUUID, scheme string, path string) *AddCon
 }
 
 // Execute runs the labeling action
-func (a *AddContactURNAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *AddContactURNAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	// only generate event if run has a contact
 	contact := run.Contact()
 	if contact == nil {
-		logEvent(events.NewErrorf("can't execute action in session without a contact"))
+		logEvent.Add(events.NewErrorf("can't execute action in session without a contact"))
 		return nil
 	}
 
@@ -58,12 +58,12 @@ func (a *AddContactURNAction) Execute(run flows.FlowRun, step flows.Step, logMod
 
 	// if we received an error, log it although it might just be a non-expression like foo@bar.com
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 	}
 
 	evaluatedPath = strings.TrimSpace(evaluatedPath)
 	if evaluatedPath == "" {
-		logEvent(events.NewErrorf("can't add URN with empty path"))
+		logEvent.Add(events.NewErrorf("can't add URN with empty path"))
 		return nil
 	}
 
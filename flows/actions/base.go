@@ -1,8 +1,10 @@
 package actions
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
@@ -33,6 +35,9 @@ const (
 	CategoryFailure = "Failure"
 )
 
+// the value of results saved by actions which skip what they would have done, e.g. calling a classifier or resthook
+const skippedResultValue = "0"
+
 var webhookCategories = []string{CategorySuccess, CategoryFailure}
 var webhookStatusCategories = map[flows.CallStatus]string{
 	flows.CallStatusSuccess:         CategorySuccess,
@@ -78,13 +83,14 @@ func (a *baseAction) Validate() error { return nil }
 // LocalizationUUID gets the UUID which identifies this object for localization
 func (a *baseAction) LocalizationUUID() uuids.UUID { return uuids.UUID(a.UUID_) }
 
-// helper function for actions that send a message (text + attachments) that must be localized and evalulated
-func (a *baseAction) evaluateMessage(run flows.FlowRun, languages []envs.Language, actionText string, actionAttachments []string, actionQuickReplies []string, logEvent flows.EventCallback) (string, []utils.Attachment, []string) {
+// helper function for actions that send a message (text + attachments) that must be localized and evalulated. Errors
+// evaluating the text are logged and also returned so that callers can record them on the message.
+func (a *baseAction) evaluateMessage(run flows.FlowRun, languages []envs.Language, actionText string, actionAttachments []string, actionQuickReplies []string, logEvent flows.EventSink) (string, []utils.Attachment, []string, error) {
 	// localize and evaluate the message text
 	localizedText := run.GetTranslatedTextArray(uuids.UUID(a.UUID()), "text", []string{actionText}, languages)[0]
-	evaluatedText, err := run.EvaluateTemplate(localizedText)
-	if err != nil {
-		logEvent(events.NewError(err))
+	evaluatedText, textErr := run.EvaluateTemplate(localizedText)
+	if textErr != nil {
+		logEvent.Add(events.NewError(textErr))
 	}
 
 	// localize and evaluate the message attachments
@@ -93,14 +99,14 @@ func (a *baseAction) evaluateMessage(run flows.FlowRun, languages []envs.Languag
 	for _, a := range translatedAttachments {
 		evaluatedAttachment, err := run.EvaluateTemplate(a)
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent.Add(events.NewError(err))
 		}
 		if evaluatedAttachment == "" {
-			logEvent(events.NewErrorf("attachment text evaluated to empty string, skipping"))
+			logEvent.Add(events.NewErrorf("attachment text evaluated to empty string, skipping"))
 			continue
 		}
 		if len(evaluatedAttachment) > maxAttachmentLength {
-			logEvent(events.NewErrorf("evaluated attachment is longer than %d limit, skipping", maxAttachmentLength))
+			logEvent.Add(events.NewErrorf("evaluated attachment is longer than %d limit, skipping", maxAttachmentLength))
 			continue
 		}
 		evaluatedAttachments = append(evaluatedAttachments, utils.Attachment(evaluatedAttachment))
@@ -112,27 +118,27 @@ func (a *baseAction) evaluateMessage(run flows.FlowRun, languages []envs.Languag
 	for _, qr := range translatedQuickReplies {
 		evaluatedQuickReply, err := run.EvaluateTemplate(qr)
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent.Add(events.NewError(err))
 		}
 		if evaluatedQuickReply == "" {
-			logEvent(events.NewErrorf("quick reply text evaluated to empty string, skipping"))
+			logEvent.Add(events.NewErrorf("quick reply text evaluated to empty string, skipping"))
 			continue
 		}
 		evaluatedQuickReplies = append(evaluatedQuickReplies, evaluatedQuickReply)
 	}
 
-	return evaluatedText, evaluatedAttachments, evaluatedQuickReplies
+	return evaluatedText, evaluatedAttachments, evaluatedQuickReplies, textErr
 }
 
 // helper to save a run result and log it as an event
-func (a *baseAction) saveResult(run flows.FlowRun, step flows.Step, name, value, category, categoryLocalized string, input string, extra json.RawMessage, logEvent flows.EventCallback) {
+func (a *baseAction) saveResult(run flows.FlowRun, step flows.Step, name, value, category, categoryLocalized string, input string, extra json.RawMessage, logEvent flows.EventSink) {
 	result := flows.NewResult(name, value, category, categoryLocalized, step.NodeUUID(), input, extra, dates.Now())
 	run.SaveResult(result)
-	logEvent(events.NewRunResultChanged(result))
+	logEvent.Add(events.NewRunResultChanged(result))
 }
 
 // helper to save a run result based on a webhook call and log it as an event
-func (a *baseAction) saveWebhookResult(run flows.FlowRun, step flows.Step, name string, call *flows.WebhookCall, status flows.CallStatus, logEvent flows.EventCallback) {
+func (a *baseAction) saveWebhookResult(run flows.FlowRun, step flows.Step, name string, call *flows.WebhookCall, status flows.CallStatus, logEvent flows.EventSink) {
 	input := fmt.Sprintf("%s %s", call.Request.Method, call.Request.URL.String())
 	value := "0"
 	category := webhookStatusCategories[status]
@@ -160,16 +166,27 @@ func (a *baseAction) updateWebhook(run flows.FlowRun, call *flows.WebhookCall) {
 	}
 }
 
+// applies any HTTP request timeout configured on the engine for this type of action to the given request
+func (a *baseAction) withHTTPTimeout(run flows.FlowRun, req *http.Request) (*http.Request, context.CancelFunc) {
+	timeout := run.Session().Engine().HTTPRequestTimeout(a.Type())
+	if timeout <= 0 {
+		return req, func() {}
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	return req.WithContext(ctx), cancel
+}
+
 // helper to apply a contact modifier
-func (a *baseAction) applyModifier(run flows.FlowRun, mod flows.Modifier, logModifier flows.ModifierCallback, logEvent flows.EventCallback) {
+func (a *baseAction) applyModifier(run flows.FlowRun, mod flows.Modifier, logModifier flows.ModifierCallback, logEvent flows.EventSink) {
 	mod.Apply(run.Environment(), run.Session().Assets(), run.Contact(), logEvent)
 	logModifier(mod)
 }
 
 // helper to log a failure
-func (a *baseAction) fail(run flows.FlowRun, err error, logEvent flows.EventCallback) {
+func (a *baseAction) fail(run flows.FlowRun, err error, logEvent flows.EventSink) {
 	run.Exit(flows.RunStatusFailed)
-	logEvent(events.NewFailure(err))
+	logEvent.Add(events.NewFailure(err))
 }
 
 // utility struct which sets the allowed flow types to any
@@ -213,7 +230,7 @@ type otherContactsAction struct {
 	LegacyVars   []string                  `json:"legacy_vars,omitempty" engine:"evaluated"`
 }
 
-func (a *otherContactsAction) resolveRecipients(run flows.FlowRun, logEvent flows.EventCallback) ([]*assets.GroupReference, []*flows.ContactReference, string, []urns.URN, error) {
+func (a *otherContactsAction) resolveRecipients(run flows.FlowRun, logEvent flows.EventSink) ([]*assets.GroupReference, []*flows.ContactReference, string, []urns.URN, error) {
 	groupSet := run.Session().Assets().Groups()
 
 	// copy URNs
@@ -242,7 +259,7 @@ func (a *otherContactsAction) resolveRecipients(run flows.FlowRun, logEvent flow
 	for _, legacyVar := range a.LegacyVars {
 		evaluatedLegacyVar, err := run.EvaluateTemplate(legacyVar)
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent.Add(events.NewError(err))
 		}
 
 		evaluatedLegacyVar = strings.TrimSpace(evaluatedLegacyVar)
@@ -264,7 +281,7 @@ func (a *otherContactsAction) resolveRecipients(run flows.FlowRun, logEvent flow
 				// if that fails, assume this is a phone number, and let the caller worry about validation
 				urn, err := urns.NewURNFromParts(urns.TelScheme, evaluatedLegacyVar, "", "")
 				if err != nil {
-					logEvent(events.NewError(err))
+					logEvent.Add(events.NewError(err))
 				} else {
 					urn = urn.Normalize(string(run.Environment().DefaultCountry()))
 					urnList = append(urnList, urn)
@@ -288,7 +305,7 @@ type createMsgAction struct {
 }
 
 // helper function for actions that have a set of group references that must be resolved to actual groups
-func resolveGroups(run flows.FlowRun, references []*assets.GroupReference, logEvent flows.EventCallback) ([]*flows.Group, error) {
+func resolveGroups(run flows.FlowRun, references []*assets.GroupReference, logEvent flows.EventSink) ([]*flows.Group, error) {
 	groupSet := run.Session().Assets().Groups()
 	groups := make([]*flows.Group, 0, len(references))
 
@@ -299,18 +316,18 @@ func resolveGroups(run flows.FlowRun, references []*assets.GroupReference, logEv
 			// group is a fixed group with a UUID
 			group = groupSet.Get(ref.UUID)
 			if group == nil {
-				logEvent(events.NewDependencyError(ref))
+				logEvent.Add(events.NewDependencyError(ref))
 			}
 		} else {
 			// group is an expression that evaluates to an existing group's name
 			evaluatedGroupName, err := run.EvaluateTemplate(ref.NameMatch)
 			if err != nil {
-				logEvent(events.NewError(err))
+				logEvent.Add(events.NewError(err))
 			} else {
 				// look up the set of all groups to see if such a group exists
 				group = groupSet.FindByName(evaluatedGroupName)
 				if group == nil {
-					logEvent(events.NewErrorf("no such group with name '%s'", evaluatedGroupName))
+					logEvent.Add(events.NewErrorf("no such group with name '%s'", evaluatedGroupName))
 				}
 			}
 		}
@@ -324,7 +341,7 @@ func resolveGroups(run flows.FlowRun, references []*assets.GroupReference, logEv
 }
 
 // helper function for actions that have a set of label references that must be resolved to actual labels
-func resolveLabels(run flows.FlowRun, references []*assets.LabelReference, logEvent flows.EventCallback) ([]*flows.Label, error) {
+func resolveLabels(run flows.FlowRun, references []*assets.LabelReference, logEvent flows.EventSink) ([]*flows.Label, error) {
 	labelSet := run.Session().Assets().Labels()
 	labels := make([]*flows.Label, 0, len(references))
 
@@ -335,18 +352,18 @@ func resolveLabels(run flows.FlowRun, references []*assets.LabelReference, logEv
 			// label is a fixed label with a UUID
 			label = labelSet.Get(ref.UUID)
 			if label == nil {
-				logEvent(events.NewDependencyError(ref))
+				logEvent.Add(events.NewDependencyError(ref))
 			}
 		} else {
 			// label is an expression that evaluates to an existing label's name
 			evaluatedLabelName, err := run.EvaluateTemplate(ref.NameMatch)
 			if err != nil {
-				logEvent(events.NewError(err))
+				logEvent.Add(events.NewError(err))
 			} else {
 				// look up the set of all labels to see if such a label exists
 				label = labelSet.FindByName(evaluatedLabelName)
 				if label == nil {
-					logEvent(events.NewErrorf("no such label with name '%s'", evaluatedLabelName))
+					logEvent.Add(events.NewErrorf("no such label with name '%s'", evaluatedLabelName))
 				}
 			}
 		}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"sort"
 	"testing"
 	"time"
@@ -108,7 +109,7 @@ func testActionType(t *testing.T, assetsJSON json.RawMessage, typeName string) {
 	defer smtpx.SetSender(smtpx.DefaultSender)
 
 	for i, tc := range tests {
-		dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 10, 18, 14, 20, 30, 123456, time.UTC)))
+		dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-10-18T14:20:30.000123456Z")))
 		uuids.SetGenerator(uuids.NewSeededGenerator(12345))
 
 		var clonedMocks *httpx.MockRequestor
@@ -736,7 +737,7 @@ func TestReadAction(t *testing.T) {
 
 func TestResthookPayload(t *testing.T) {
 	uuids.SetGenerator(uuids.NewSeededGenerator(123456))
-	dates.SetNowSource(dates.NewSequentialNowSource(time.Date(2018, 7, 6, 12, 30, 0, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewSequentialNowSource(test.MustParseTime("2018-07-06T12:30:00.123456789Z")))
 	defer uuids.SetGenerator(uuids.DefaultGenerator)
 	defer dates.SetNowSource(dates.DefaultNowSource)
 
@@ -968,3 +969,78 @@ func TestStartSessionLoopProtectionWithInput(t *testing.T) {
 
 	assert.Equal(t, 10, len(sessions))
 }
+
+func TestHTTPRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	sa, err := test.CreateSessionAssets([]byte(fmt.Sprintf(`{
+		"flows": [
+			{
+				"uuid": "bead76f5-dac4-4c9d-996c-c62b326e8c0a",
+				"name": "Webhook",
+				"spec_version": "13.0",
+				"language": "eng",
+				"type": "messaging",
+				"nodes": [
+					{
+						"uuid": "72a1f5df-49f9-45df-94c9-d86f7ea064e5",
+						"actions": [
+							{
+								"uuid": "ad154980-7bf7-4ab8-8728-545fd6378912",
+								"type": "call_webhook",
+								"method": "GET",
+								"url": "%s",
+								"result_name": "Call"
+							}
+						],
+						"exits": [{"uuid": "d7a36118-0a38-4b35-a7e4-ae89042f0d3c"}]
+					}
+				]
+			}
+		]
+	}`, server.URL)), "")
+	require.NoError(t, err)
+
+	flow, err := sa.Flows().Get("bead76f5-dac4-4c9d-996c-c62b326e8c0a")
+	require.NoError(t, err)
+
+	trigger := triggers.NewBuilder(envs.NewBuilder().Build(), flow.Reference(), nil).Manual().Build()
+
+	callWebhook := func(eng flows.Engine) *events.WebhookCalledEvent {
+		_, sprint, err := eng.NewSession(sa, trigger)
+		require.NoError(t, err)
+
+		for _, e := range sprint.Events() {
+			if e.Type() == events.TypeWebhookCalled {
+				return e.(*events.WebhookCalledEvent)
+			}
+		}
+		require.Fail(t, "no webhook_called event")
+		return nil
+	}
+
+	// by default requests are only limited by the HTTP client
+	eng := engine.NewBuilder().
+		WithWebhookServiceFactory(webhooks.NewServiceFactory(http.DefaultClient, nil, nil, nil, 10000)).
+		Build()
+
+	assert.Equal(t, time.Duration(0), eng.HTTPRequestTimeout("call_webhook"))
+	assert.Equal(t, flows.CallStatusSuccess, callWebhook(eng).Status)
+
+	// but a tight timeout for webhook actions means the request fails
+	eng = engine.NewBuilder().
+		WithWebhookServiceFactory(webhooks.NewServiceFactory(http.DefaultClient, nil, nil, nil, 10000)).
+		WithHTTPRequestTimeout("call_webhook", 50*time.Millisecond).
+		Build()
+
+	assert.Equal(t, 50*time.Millisecond, eng.HTTPRequestTimeout("call_webhook"))
+	assert.Equal(t, time.Duration(0), eng.HTTPRequestTimeout("call_resthook"))
+
+	event := callWebhook(eng)
+	assert.Equal(t, flows.CallStatusConnectionError, event.Status)
+	assert.True(t, event.ElapsedMS < 500, "expected request to time out but took %dms", event.ElapsedMS)
+}
@@ -1,10 +1,14 @@
 package actions
 
 import (
+	"fmt"
+
 	"github.com/nyaruka/gocommon/jsonx"
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/events"
+
+	"github.com/shopspring/decimal"
 )
 
 func init() {
@@ -18,7 +22,11 @@ const TypeCallClassifier string = "call_classifier"
 
 // CallClassifierAction can be used to classify the intent and entities from a given input using an NLU classifier. It always
 // saves a result indicating whether the classification was successful, skipped or failed, and what the extracted intents
-// and entities were.
+// and entities were. The value of a successful result is the name and confidence of the top intent, e.g. `book_flight:0.90`,
+// and the confidence is also available as `top_confidence` in the result's extra. The value of a skipped result is `0`.
+//
+// Flows which previously compared the result value to an intent name should instead use the `has_top_intent` test,
+// which matches on the intent name and a minimum confidence.
 //
 //   {
 //     "uuid": "8eebd020-1af5-431c-b943-aa670fc74da9",
@@ -52,14 +60,19 @@ func NewCallClassifier(uuid flows.ActionUUID, classifier *assets.ClassifierRefer
 }
 
 // Execute runs this action
-func (a *CallClassifierAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *CallClassifierAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	classifiers := run.Session().Assets().Classifiers()
 	classifier := classifiers.Get(a.Classifier.UUID)
 
+	// older flows may reference a classifier whose UUID has since changed, so fallback to looking it up by name
+	if classifier == nil && a.Classifier.Name != "" {
+		classifier = classifiers.GetByName(a.Classifier.Name)
+	}
+
 	// substitute any variables in our input
 	input, err := run.EvaluateTemplate(a.Input)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 	}
 
 	classification, skipped := a.classify(run, step, input, classifier, logEvent)
@@ -74,54 +87,63 @@ func (a *CallClassifierAction) Execute(run flows.FlowRun, step flows.Step, logMo
 	return nil
 }
 
-func (a *CallClassifierAction) classify(run flows.FlowRun, step flows.Step, input string, classifier *flows.Classifier, logEvent flows.EventCallback) (*flows.Classification, bool) {
+func (a *CallClassifierAction) classify(run flows.FlowRun, step flows.Step, input string, classifier *flows.Classifier, logEvent flows.EventSink) (*flows.Classification, bool) {
 	if input == "" {
-		logEvent(events.NewErrorf("can't classify empty input, skipping classification"))
+		logEvent.Add(events.NewErrorf("can't classify empty input, skipping classification"))
 		return nil, true
 	}
 	if classifier == nil {
-		logEvent(events.NewDependencyError(a.Classifier))
+		logEvent.Add(events.NewDependencyError(a.Classifier))
 		return nil, false
 	}
 
 	svc, err := run.Session().Engine().Services().Classification(run.Session(), classifier)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 		return nil, false
 	}
 
-	httpLogger := &flows.HTTPLogger{}
+	httpLogger := flows.NewHTTPLogger(run.Session().Engine().MaxBodyBytes())
 
 	classification, err := svc.Classify(run.Session(), input, httpLogger.Log)
 
 	if len(httpLogger.Logs) > 0 {
-		logEvent(events.NewClassifierCalled(classifier.Reference(), httpLogger.Logs))
+		logEvent.Add(events.NewClassifierCalled(classifier.Reference(), httpLogger.Logs))
 	}
 
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 		return nil, false
 	}
 
 	return classification, false
 }
 
-func (a *CallClassifierAction) saveSuccess(run flows.FlowRun, step flows.Step, input string, classification *flows.Classification, logEvent flows.EventCallback) {
-	// result value is name of top ranked intent if there is one
+func (a *CallClassifierAction) saveSuccess(run flows.FlowRun, step flows.Step, input string, classification *flows.Classification, logEvent flows.EventSink) {
+	// result value is name and confidence of top ranked intent if there is one, and extra also includes its confidence
 	value := ""
-	if len(classification.Intents) > 0 {
-		value = classification.Intents[0].Name
+	var topConfidence *decimal.Decimal
+	if top, found := classification.TopIntent(); found {
+		value = fmt.Sprintf("%s:%s", top.Name, top.Confidence.StringFixed(2))
+		topConfidence = &top.Confidence
 	}
-	extra, _ := jsonx.Marshal(classification)
+	extra, _ := jsonx.Marshal(&classificationExtra{Classification: classification, TopConfidence: topConfidence})
 
 	a.saveResult(run, step, a.ResultName, value, CategorySuccess, "", input, extra, logEvent)
 }
 
-func (a *CallClassifierAction) saveSkipped(run flows.FlowRun, step flows.Step, input string, logEvent flows.EventCallback) {
-	a.saveResult(run, step, a.ResultName, "0", CategorySkipped, "", input, nil, logEvent)
+// the extra saved with a successful classification result
+type classificationExtra struct {
+	*flows.Classification
+
+	TopConfidence *decimal.Decimal `json:"top_confidence,omitempty"`
+}
+
+func (a *CallClassifierAction) saveSkipped(run flows.FlowRun, step flows.Step, input string, logEvent flows.EventSink) {
+	a.saveResult(run, step, a.ResultName, skippedResultValue, CategorySkipped, "", input, nil, logEvent)
 }
 
-func (a *CallClassifierAction) saveFailure(run flows.FlowRun, step flows.Step, input string, logEvent flows.EventCallback) {
+func (a *CallClassifierAction) saveFailure(run flows.FlowRun, step flows.Step, input string, logEvent flows.EventSink) {
 	a.saveResult(run, step, a.ResultName, "0", CategoryFailure, "", input, nil, logEvent)
 }
 
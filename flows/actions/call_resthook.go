@@ -11,6 +11,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// resthook calls which can be skipped may also save a skipped result
+var skippableResthookCategories = []string{CategorySuccess, CategorySkipped, CategoryFailure}
+
 // ResthookPayload is the POST payload used by resthooks
 const ResthookPayload = `@(json(object(
   "contact", object("uuid", contact.uuid, "name", contact.name, "urn", contact.urn, "language", contact.language),
@@ -54,7 +57,9 @@ const TypeCallResthook string = "call_resthook"
 // A [event:webhook_called] event will be created for each subscriber of the resthook with the results
 // of the HTTP call. If the action has `result_name` set, a result will
 // be created with that name, and if the resthook returns valid JSON, that will be accessible
-// through `extra` on the result.
+// through `extra` on the result. If `skip_if_no_subscribers` is set and the resthook has no
+// subscribers, then no events are created besides a result with the category `Skipped` and the value `0`,
+// as for skipped classifier calls.
 //
 //   {
 //     "uuid": "8eebd020-1af5-431c-b943-aa670fc74da9",
@@ -67,8 +72,9 @@ type CallResthookAction struct {
 	baseAction
 	onlineAction
 
-	Resthook   string `json:"resthook" validate:"required"`
-	ResultName string `json:"result_name,omitempty"`
+	Resthook            string `json:"resthook" validate:"required"`
+	ResultName          string `json:"result_name,omitempty"`
+	SkipIfNoSubscribers bool   `json:"skip_if_no_subscribers,omitempty"`
 }
 
 // NewCallResthook creates a new call resthook action
@@ -81,13 +87,21 @@ func NewCallResthook(uuid flows.ActionUUID, resthook string, resultName string)
 }
 
 // Execute runs this action
-func (a *CallResthookAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *CallResthookAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	// NOOP if resthook doesn't exist
 	resthook := run.Session().Assets().Resthooks().FindBySlug(a.Resthook)
 	if resthook == nil {
 		return nil
 	}
 
+	// if there's no one to call and we've been asked to skip, don't bother building the payload
+	if a.SkipIfNoSubscribers && resthook.SubscriberCount() == 0 {
+		if a.ResultName != "" {
+			a.saveResult(run, step, a.ResultName, skippedResultValue, CategorySkipped, "", "", nil, logEvent)
+		}
+		return nil
+	}
+
 	// build our payload (not truncated)
 	payload, err := run.EvaluateTemplateText(ResthookPayload, nil, false)
 	if err != nil {
@@ -101,7 +115,7 @@ func (a *CallResthookAction) Execute(run flows.FlowRun, step flows.Step, logModi
 	}
 
 	// regardless of what subscriber calls we make, we need to record the payload that would be sent
-	logEvent(events.NewResthookCalled(a.Resthook, json.RawMessage(payload)))
+	logEvent.Add(events.NewResthookCalled(a.Resthook, json.RawMessage(payload)))
 
 	// make a call to each subscriber URL
 	calls := make([]*flows.WebhookCall, 0, len(resthook.Subscribers()))
@@ -109,7 +123,7 @@ func (a *CallResthookAction) Execute(run flows.FlowRun, step flows.Step, logModi
 	for _, url := range resthook.Subscribers() {
 		req, err := http.NewRequest("POST", url, strings.NewReader(payload))
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent.Add(events.NewError(err))
 			return nil
 		}
 
@@ -117,18 +131,20 @@ func (a *CallResthookAction) Execute(run flows.FlowRun, step flows.Step, logModi
 
 		svc, err := run.Session().Engine().Services().Webhook(run.Session())
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent.Add(events.NewError(err))
 			return nil
 		}
 
+		req, cancel := a.withHTTPTimeout(run, req)
 		call, err := svc.Call(run.Session(), req)
+		cancel()
 
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent.Add(events.NewError(err))
 		}
 		if call != nil {
 			calls = append(calls, call)
-			logEvent(events.NewWebhookCalled(call, callStatus(call, nil, true), a.Resthook))
+			logEvent.Add(events.NewWebhookCalled(call, callStatus(call, nil, true), a.Resthook, run.Session().Engine().IncludeFullWebhookBody()))
 		}
 	}
 
@@ -178,6 +194,10 @@ func (a *CallResthookAction) pickResultCall(calls []*flows.WebhookCall) *flows.W
 // Results enumerates any results generated by this flow object
 func (a *CallResthookAction) Results(include func(*flows.ResultInfo)) {
 	if a.ResultName != "" {
-		include(flows.NewResultInfo(a.ResultName, webhookCategories))
+		if a.SkipIfNoSubscribers {
+			include(flows.NewResultInfo(a.ResultName, skippableResthookCategories))
+		} else {
+			include(flows.NewResultInfo(a.ResultName, webhookCategories))
+		}
 	}
 }
@@ -79,19 +79,19 @@ func (a *CallWebhookAction) Validate() error {
 }
 
 // Execute runs this action
-func (a *CallWebhookAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *CallWebhookAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 
 	// substitute any variables in our url
 	url, err := run.EvaluateTemplate(a.URL)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 	}
 	if url == "" {
-		logEvent(events.NewErrorf("webhook URL evaluated to empty string"))
+		logEvent.Add(events.NewErrorf("webhook URL evaluated to empty string"))
 		return nil
 	}
 	if !isValidURL(url) {
-		logEvent(events.NewErrorf("webhook URL evaluated to an invalid URL: '%s'", url))
+		logEvent.Add(events.NewErrorf("webhook URL evaluated to an invalid URL: '%s'", url))
 		return nil
 	}
 
@@ -103,7 +103,7 @@ func (a *CallWebhookAction) Execute(run flows.FlowRun, step flows.Step, logModif
 		// webhook bodies aren't truncated like other templates
 		body, err = run.EvaluateTemplateText(body, nil, false)
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent.Add(events.NewError(err))
 		}
 	}
 
@@ -111,7 +111,7 @@ func (a *CallWebhookAction) Execute(run flows.FlowRun, step flows.Step, logModif
 }
 
 // Execute runs this action
-func (a *CallWebhookAction) call(run flows.FlowRun, step flows.Step, url, method, body string, logEvent flows.EventCallback) error {
+func (a *CallWebhookAction) call(run flows.FlowRun, step flows.Step, url, method, body string, logEvent flows.EventSink) error {
 	// build our request
 	req, err := http.NewRequest(method, url, strings.NewReader(body))
 	if err != nil {
@@ -122,7 +122,7 @@ func (a *CallWebhookAction) call(run flows.FlowRun, step flows.Step, url, method
 	for key, value := range a.Headers {
 		headerValue, err := run.EvaluateTemplate(value)
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent.Add(events.NewError(err))
 		}
 
 		req.Header.Add(key, headerValue)
@@ -151,22 +151,24 @@ func (a *CallWebhookAction) call(run flows.FlowRun, step flows.Step, url, method
 
 	svc, err := run.Session().Engine().Services().Webhook(run.Session())
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 		return nil
 	}
 
+	req, cancel := a.withHTTPTimeout(run, req)
+	defer cancel()
 
 	call, err := svc.Call(run.Session(), req)
 
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 	}
 	if call != nil {
 		a.updateWebhook(run, call)
 
 		status := callStatus(call, err, false)
 
-		logEvent(events.NewWebhookCalled(call, status, ""))
+		logEvent.Add(events.NewWebhookCalled(call, status, "", run.Session().Engine().IncludeFullWebhookBody()))
 
 		if a.ResultName != "" {
 			a.saveWebhookResult(run, step, a.ResultName, call, status, logEvent)
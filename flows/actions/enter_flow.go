@@ -45,7 +45,7 @@ func NewEnterFlow(uuid flows.ActionUUID, flow *assets.FlowReference, terminal bo
 }
 
 // Execute runs our action
-func (a *EnterFlowAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *EnterFlowAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	flow, err := run.Session().Assets().Flows().Get(a.Flow.UUID)
 
 	// we ignore other missing asset types but a missing flow means we don't know how to route so we can't continue
@@ -60,6 +60,6 @@ func (a *EnterFlowAction) Execute(run flows.FlowRun, step flows.Step, logModifie
 	}
 
 	run.Session().PushFlow(flow, run, a.Terminal)
-	logEvent(events.NewFlowEntered(a.Flow, run.UUID(), a.Terminal))
+	logEvent.Add(events.NewFlowEntered(a.Flow, run.UUID(), a.Terminal))
 	return nil
 }
@@ -50,17 +50,17 @@ func NewOpenTicket(uuid flows.ActionUUID, ticketer *assets.TicketerReference, su
 }
 
 // Execute runs this action
-func (a *OpenTicketAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *OpenTicketAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	ticketers := run.Session().Assets().Ticketers()
 	ticketer := ticketers.Get(a.Ticketer.UUID)
 
 	evaluatedSubject, err := run.EvaluateTemplate(a.Subject)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 	}
 	evaluatedBody, err := run.EvaluateTemplate(a.Body)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 	}
 
 	ticket := a.open(run, step, ticketer, evaluatedSubject, evaluatedBody, logEvent)
@@ -73,34 +73,34 @@ func (a *OpenTicketAction) Execute(run flows.FlowRun, step flows.Step, logModifi
 	return nil
 }
 
-func (a *OpenTicketAction) open(run flows.FlowRun, step flows.Step, ticketer *flows.Ticketer, subject, body string, logEvent flows.EventCallback) *flows.Ticket {
+func (a *OpenTicketAction) open(run flows.FlowRun, step flows.Step, ticketer *flows.Ticketer, subject, body string, logEvent flows.EventSink) *flows.Ticket {
 	if run.Session().BatchStart() {
-		logEvent(events.NewErrorf("can't open tickets during batch starts"))
+		logEvent.Add(events.NewErrorf("can't open tickets during batch starts"))
 		return nil
 	}
 
 	if ticketer == nil {
-		logEvent(events.NewDependencyError(a.Ticketer))
+		logEvent.Add(events.NewDependencyError(a.Ticketer))
 		return nil
 	}
 
 	svc, err := run.Session().Engine().Services().Ticket(run.Session(), ticketer)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 		return nil
 	}
 
-	httpLogger := &flows.HTTPLogger{}
+	httpLogger := flows.NewHTTPLogger(run.Session().Engine().MaxBodyBytes())
 
 	ticket, err := svc.Open(run.Session(), subject, body, httpLogger.Log)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 	}
 	if len(httpLogger.Logs) > 0 {
-		logEvent(events.NewTicketerCalled(ticketer.Reference(), httpLogger.Logs))
+		logEvent.Add(events.NewTicketerCalled(ticketer.Reference(), httpLogger.Logs))
 	}
 	if ticket != nil {
-		logEvent(events.NewTicketOpened(ticket))
+		logEvent.Add(events.NewTicketOpened(ticket))
 	}
 
 	return ticket
@@ -43,18 +43,18 @@ func NewPlayAudio(uuid flows.ActionUUID, audioURL string) *PlayAudioAction {
 }
 
 // Execute runs this action
-func (a *PlayAudioAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *PlayAudioAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	// localize and evaluate audio URL
 	localizedAudioURL := run.GetText(uuids.UUID(a.UUID()), "audio_url", a.AudioURL)
 	evaluatedAudioURL, err := run.EvaluateTemplate(localizedAudioURL)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 		return nil
 	}
 
 	evaluatedAudioURL = strings.TrimSpace(evaluatedAudioURL)
 	if evaluatedAudioURL == "" {
-		logEvent(events.NewErrorf("audio URL evaluated to empty, skipping"))
+		logEvent.Add(events.NewErrorf("audio URL evaluated to empty, skipping"))
 		return nil
 	}
 
@@ -63,7 +63,7 @@ func (a *PlayAudioAction) Execute(run flows.FlowRun, step flows.Step, logModifie
 
 	// if we have an audio URL, turn it into a message
 	msg := flows.NewIVRMsgOut(connection.URN(), connection.Channel(), "", envs.NilLanguage, evaluatedAudioURL)
-	logEvent(events.NewIVRCreated(msg))
+	logEvent.Add(events.NewIVRCreated(msg))
 
 	return nil
 }
@@ -47,12 +47,12 @@ func NewSayMsg(uuid flows.ActionUUID, text string, audioURL string) *SayMsgActio
 }
 
 // Execute runs this action
-func (a *SayMsgAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *SayMsgAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	// localize and evaluate the message text
 	localizedTexts, textLanguage := run.GetTextArray(uuids.UUID(a.UUID()), "text", []string{a.Text})
 	evaluatedText, err := run.EvaluateTemplate(localizedTexts[0])
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 	}
 	evaluatedText = strings.TrimSpace(evaluatedText)
 
@@ -61,7 +61,7 @@ func (a *SayMsgAction) Execute(run flows.FlowRun, step flows.Step, logModifier f
 
 	// if we have neither an audio URL or backdown text, skip
 	if evaluatedText == "" && localizedAudioURL == "" {
-		logEvent(events.NewErrorf("need either audio URL or backdown text, skipping"))
+		logEvent.Add(events.NewErrorf("need either audio URL or backdown text, skipping"))
 		return nil
 	}
 
@@ -69,7 +69,7 @@ func (a *SayMsgAction) Execute(run flows.FlowRun, step flows.Step, logModifier f
 	connection := run.Session().Trigger().Connection()
 
 	msg := flows.NewIVRMsgOut(connection.URN(), connection.Channel(), evaluatedText, textLanguage, localizedAudioURL)
-	logEvent(events.NewIVRCreated(msg))
+	logEvent.Add(events.NewIVRCreated(msg))
 
 	return nil
 }
@@ -55,7 +55,7 @@ func NewSendBroadcast(uuid flows.ActionUUID, text string, attachments []string,
 }
 
 // Execute runs this action
-func (a *SendBroadcastAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *SendBroadcastAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	groupRefs, contactRefs, _, urnList, err := a.resolveRecipients(run, logEvent)
 	if err != nil {
 		return err
@@ -63,7 +63,7 @@ func (a *SendBroadcastAction) Execute(run flows.FlowRun, step flows.Step, logMod
 
 	// footgun prevention
 	if run.Session().BatchStart() && len(groupRefs) > 0 {
-		logEvent(events.NewErrorf("can't send broadcasts to groups during batch starts"))
+		logEvent.Add(events.NewErrorf("can't send broadcasts to groups during batch starts"))
 		return nil
 	}
 
@@ -74,7 +74,7 @@ func (a *SendBroadcastAction) Execute(run flows.FlowRun, step flows.Step, logMod
 	for _, language := range languages {
 		languages := []envs.Language{language, run.Flow().Language()}
 
-		evaluatedText, evaluatedAttachments, evaluatedQuickReplies := a.evaluateMessage(run, languages, a.Text, a.Attachments, a.QuickReplies, logEvent)
+		evaluatedText, evaluatedAttachments, evaluatedQuickReplies, _ := a.evaluateMessage(run, languages, a.Text, a.Attachments, a.QuickReplies, logEvent)
 		translations[language] = &events.BroadcastTranslation{
 			Text:         evaluatedText,
 			Attachments:  evaluatedAttachments,
@@ -84,7 +84,7 @@ func (a *SendBroadcastAction) Execute(run flows.FlowRun, step flows.Step, logMod
 
 	// if we have any recipients, log an event
 	if len(urnList) > 0 || len(contactRefs) > 0 || len(groupRefs) > 0 {
-		logEvent(events.NewBroadcastCreated(translations, run.Flow().Language(), groupRefs, contactRefs, urnList))
+		logEvent.Add(events.NewBroadcastCreated(translations, run.Flow().Language(), groupRefs, contactRefs, urnList))
 	}
 
 	return nil
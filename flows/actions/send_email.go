@@ -51,11 +51,11 @@ func NewSendEmail(uuid flows.ActionUUID, addresses []string, subject string, bod
 }
 
 // Execute creates the email events
-func (a *SendEmailAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *SendEmailAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	localizedSubject := run.GetText(uuids.UUID(a.UUID()), "subject", a.Subject)
 	evaluatedSubject, err := run.EvaluateTemplate(localizedSubject)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 	}
 
 	// make sure the subject is single line - replace '\t\n\r\f\v' to ' '
@@ -63,17 +63,17 @@ func (a *SendEmailAction) Execute(run flows.FlowRun, step flows.Step, logModifie
 	evaluatedSubject = strings.TrimSpace(evaluatedSubject)
 
 	if evaluatedSubject == "" {
-		logEvent(events.NewErrorf("email subject evaluated to empty string, skipping"))
+		logEvent.Add(events.NewErrorf("email subject evaluated to empty string, skipping"))
 		return nil
 	}
 
 	localizedBody := run.GetText(uuids.UUID(a.UUID()), "body", a.Body)
 	evaluatedBody, err := run.EvaluateTemplate(localizedBody)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 	}
 	if evaluatedBody == "" {
-		logEvent(events.NewErrorf("email body evaluated to empty string, skipping"))
+		logEvent.Add(events.NewErrorf("email body evaluated to empty string, skipping"))
 		return nil
 	}
 
@@ -82,10 +82,10 @@ func (a *SendEmailAction) Execute(run flows.FlowRun, step flows.Step, logModifie
 	for _, address := range a.Addresses {
 		evaluatedAddress, err := run.EvaluateTemplate(address)
 		if err != nil {
-			logEvent(events.NewError(err))
+			logEvent.Add(events.NewError(err))
 		}
 		if evaluatedAddress == "" {
-			logEvent(events.NewErrorf("email address evaluated to empty string, skipping"))
+			logEvent.Add(events.NewErrorf("email address evaluated to empty string, skipping"))
 			continue
 		}
 
@@ -104,15 +104,15 @@ func (a *SendEmailAction) Execute(run flows.FlowRun, step flows.Step, logModifie
 
 	svc, err := run.Session().Engine().Services().Email(run.Session())
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 		return nil
 	}
 
 	err = svc.Send(run.Session(), evaluatedAddresses, evaluatedSubject, evaluatedBody)
 	if err != nil {
-		logEvent(events.NewError(errors.Wrap(err, "unable to send email")))
+		logEvent.Add(events.NewError(errors.Wrap(err, "unable to send email")))
 	} else {
-		logEvent(events.NewEmailSent(evaluatedAddresses, evaluatedSubject, evaluatedBody))
+		logEvent.Add(events.NewEmailSent(evaluatedAddresses, evaluatedSubject, evaluatedBody))
 	}
 
 	return nil
@@ -74,13 +74,19 @@ func NewSendMsg(uuid flows.ActionUUID, text string, attachments []string, quickR
 }
 
 // Execute runs this action
-func (a *SendMsgAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *SendMsgAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	if run.Contact() == nil {
-		logEvent(events.NewErrorf("can't execute action in session without a contact"))
+		logEvent.Add(events.NewErrorf("can't execute action in session without a contact"))
 		return nil
 	}
 
-	evaluatedText, evaluatedAttachments, evaluatedQuickReplies := a.evaluateMessage(run, nil, a.Text, a.Attachments, a.QuickReplies, logEvent)
+	evaluatedText, evaluatedAttachments, evaluatedQuickReplies, err := a.evaluateMessage(run, nil, a.Text, a.Attachments, a.QuickReplies, logEvent)
+
+	// if the text couldn't be fully evaluated, record the error on the message events as well
+	var evaluationErrors []string
+	if err != nil {
+		evaluationErrors = []string{err.Error()}
+	}
 
 	destinations := run.Contact().ResolveDestinations(a.AllURNs)
 
@@ -112,7 +118,7 @@ func (a *SendMsgAction) Execute(run flows.FlowRun, step flows.Step, logModifier
 				for i, variable := range localizedVariables {
 					sub, err := run.EvaluateTemplate(variable)
 					if err != nil {
-						logEvent(events.NewError(err))
+						logEvent.Add(events.NewError(err))
 					}
 					evaluatedVariables[i] = sub
 				}
@@ -123,14 +129,14 @@ func (a *SendMsgAction) Execute(run flows.FlowRun, step flows.Step, logModifier
 		}
 
 		msg := flows.NewMsgOut(dest.URN.URN(), channelRef, evaluatedText, evaluatedAttachments, evaluatedQuickReplies, templating, a.Topic)
-		logEvent(events.NewMsgCreated(msg))
+		logEvent.Add(events.NewMsgCreatedWithErrors(msg, evaluationErrors))
 	}
 
 	// if we couldn't find a destination, create a msg without a URN or channel and it's up to the caller
 	// to handle that as they want
 	if len(destinations) == 0 {
 		msg := flows.NewMsgOut(urns.NilURN, nil, evaluatedText, evaluatedAttachments, evaluatedQuickReplies, nil, flows.NilMsgTopic)
-		logEvent(events.NewMsgCreated(msg))
+		logEvent.Add(events.NewMsgCreatedWithErrors(msg, evaluationErrors))
 	}
 
 	return nil
@@ -42,10 +42,10 @@ func NewSetContactChannel(uuid flows.ActionUUID, channel *assets.ChannelReferenc
 }
 
 // Execute runs our action
-func (a *SetContactChannelAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *SetContactChannelAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	contact := run.Contact()
 	if contact == nil {
-		logEvent(events.NewErrorf("can't execute action in session without a contact"))
+		logEvent.Add(events.NewErrorf("can't execute action in session without a contact"))
 		return nil
 	}
 
@@ -53,7 +53,7 @@ func (a *SetContactChannelAction) Execute(run flows.FlowRun, step flows.Step, lo
 	if a.Channel != nil {
 		channel = run.Session().Assets().Channels().Get(a.Channel.UUID)
 		if channel == nil {
-			logEvent(events.NewDependencyError(a.Channel))
+			logEvent.Add(events.NewDependencyError(a.Channel))
 			return nil
 		}
 	}
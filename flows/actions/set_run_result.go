@@ -49,13 +49,13 @@ func NewSetRunResult(uuid flows.ActionUUID, name string, value string, category
 }
 
 // Execute runs this action
-func (a *SetRunResultAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *SetRunResultAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	// get our evaluated value
 	value, err := run.EvaluateTemplate(a.Value)
 
 	// log any error received
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 		return nil
 	}
 
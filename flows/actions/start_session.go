@@ -56,7 +56,7 @@ func NewStartSession(uuid flows.ActionUUID, flow *assets.FlowReference, urns []u
 }
 
 // Execute runs our action
-func (a *StartSessionAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *StartSessionAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	groupRefs, contactRefs, contactQuery, urnList, err := a.resolveRecipients(run, logEvent)
 	if err != nil {
 		return err
@@ -64,14 +64,14 @@ func (a *StartSessionAction) Execute(run flows.FlowRun, step flows.Step, logModi
 
 	// batch footgun prevention
 	if run.Session().BatchStart() && (len(groupRefs) > 0 || contactQuery != "") {
-		logEvent(events.NewErrorf("can't start new sessions for groups or queries during batch starts"))
+		logEvent.Add(events.NewErrorf("can't start new sessions for groups or queries during batch starts"))
 		return nil
 	}
 
 	// loop footgun prevention
 	ref := run.Session().History()
 	if ref.AncestorsSinceInput >= maxAncestorsSinceInput {
-		logEvent(events.NewErrorf("too many sessions have been spawned since the last time input was received"))
+		logEvent.Add(events.NewErrorf("too many sessions have been spawned since the last time input was received"))
 		return nil
 	}
 
@@ -87,6 +87,6 @@ func (a *StartSessionAction) Execute(run flows.FlowRun, step flows.Step, logModi
 
 	history := flows.NewChildHistory(run.Session())
 
-	logEvent(events.NewSessionTriggered(a.Flow, groupRefs, contactRefs, contactQuery, a.CreateContact, urnList, runSnapshot, history))
+	logEvent.Add(events.NewSessionTriggered(a.Flow, groupRefs, contactRefs, contactQuery, a.CreateContact, urnList, runSnapshot, history))
 	return nil
 }
@@ -48,10 +48,10 @@ func NewTransferAirtime(uuid flows.ActionUUID, amounts map[string]decimal.Decima
 }
 
 // Execute executes the transfer action
-func (a *TransferAirtimeAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
+func (a *TransferAirtimeAction) Execute(run flows.FlowRun, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventSink) error {
 	transfer, err := a.transfer(run, step, logEvent)
 	if err != nil {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 
 		a.saveFailure(run, step, logEvent)
 	} else {
@@ -61,7 +61,7 @@ func (a *TransferAirtimeAction) Execute(run flows.FlowRun, step flows.Step, logM
 	return nil
 }
 
-func (a *TransferAirtimeAction) transfer(run flows.FlowRun, step flows.Step, logEvent flows.EventCallback) (*flows.AirtimeTransfer, error) {
+func (a *TransferAirtimeAction) transfer(run flows.FlowRun, step flows.Step, logEvent flows.EventSink) (*flows.AirtimeTransfer, error) {
 	// fail if we don't have a contact
 	contact := run.Contact()
 	if contact == nil {
@@ -86,25 +86,25 @@ func (a *TransferAirtimeAction) transfer(run flows.FlowRun, step flows.Step, log
 		return nil, err
 	}
 
-	httpLogger := &flows.HTTPLogger{}
+	httpLogger := flows.NewHTTPLogger(run.Session().Engine().MaxBodyBytes())
 
 	transfer, err := svc.Transfer(run.Session(), sender, telURNs[0].URN(), a.Amounts, httpLogger.Log)
 	if transfer != nil {
-		logEvent(events.NewAirtimeTransferred(transfer, httpLogger.Logs))
+		logEvent.Add(events.NewAirtimeTransferred(transfer, httpLogger.Logs))
 	}
 
 	return transfer, err
 }
 
-func (a *TransferAirtimeAction) saveSuccess(run flows.FlowRun, step flows.Step, transfer *flows.AirtimeTransfer, logEvent flows.EventCallback) {
+func (a *TransferAirtimeAction) saveSuccess(run flows.FlowRun, step flows.Step, transfer *flows.AirtimeTransfer, logEvent flows.EventSink) {
 	a.saveResult(run, step, a.ResultName, transfer.ActualAmount.String(), CategorySuccess, "", "", nil, logEvent)
 }
 
-func (a *TransferAirtimeAction) saveSkipped(run flows.FlowRun, step flows.Step, logEvent flows.EventCallback) {
+func (a *TransferAirtimeAction) saveSkipped(run flows.FlowRun, step flows.Step, logEvent flows.EventSink) {
 	a.saveResult(run, step, a.ResultName, "0", CategorySkipped, "", "", nil, logEvent)
 }
 
-func (a *TransferAirtimeAction) saveFailure(run flows.FlowRun, step flows.Step, logEvent flows.EventCallback) {
+func (a *TransferAirtimeAction) saveFailure(run flows.FlowRun, step flows.Step, logEvent flows.EventSink) {
 	a.saveResult(run, step, a.ResultName, "0", CategoryFailure, "", "", nil, logEvent)
 }
 
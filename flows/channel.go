@@ -80,20 +80,26 @@ func (c *Channel) String() string {
 
 // ChannelAssets provides access to all channel assets
 type ChannelAssets struct {
-	all    []*Channel
-	byUUID map[assets.ChannelUUID]*Channel
+	all      []*Channel
+	byUUID   map[assets.ChannelUUID]*Channel
+	byScheme map[string][]*Channel
 }
 
 // NewChannelAssets creates a new set of channel assets
 func NewChannelAssets(channels []assets.Channel) *ChannelAssets {
 	s := &ChannelAssets{
-		all:    make([]*Channel, len(channels)),
-		byUUID: make(map[assets.ChannelUUID]*Channel, len(channels)),
+		all:      make([]*Channel, len(channels)),
+		byUUID:   make(map[assets.ChannelUUID]*Channel, len(channels)),
+		byScheme: make(map[string][]*Channel),
 	}
 	for i, asset := range channels {
 		channel := NewChannel(asset)
 		s.all[i] = channel
 		s.byUUID[channel.UUID()] = channel
+
+		for _, scheme := range channel.Schemes() {
+			s.byScheme[scheme] = append(s.byScheme[scheme], channel)
+		}
 	}
 	return s
 }
@@ -103,6 +109,11 @@ func (s *ChannelAssets) Get(uuid assets.ChannelUUID) *Channel {
 	return s.byUUID[uuid]
 }
 
+// GetByScheme returns all the channels which support the given URN scheme
+func (s *ChannelAssets) GetByScheme(scheme string) []*Channel {
+	return s.byScheme[scheme]
+}
+
 // GetForURN returns the best channel for the given URN
 func (s *ChannelAssets) GetForURN(urn *ContactURN, role assets.ChannelRole) *Channel {
 	// if caller has told us which channel to use for this URN, use that
@@ -115,9 +126,9 @@ func (s *ChannelAssets) GetForURN(urn *ContactURN, role assets.ChannelRole) *Cha
 		countryCode := envs.DeriveCountryFromTel(urn.URN().Path())
 		candidates := make([]*Channel, 0)
 
-		for _, ch := range s.all {
-			// skip if not tel and not sendable
-			if !ch.SupportsScheme(urns.TelScheme) || !ch.HasRole(role) {
+		for _, ch := range s.byScheme[urns.TelScheme] {
+			// skip if doesn't have the requested role
+			if !ch.HasRole(role) {
 				continue
 			}
 			// skip if international and channel doesn't allow that
@@ -164,8 +175,8 @@ func (s *ChannelAssets) GetForURN(urn *ContactURN, role assets.ChannelRole) *Cha
 }
 
 func (s *ChannelAssets) getForSchemeAndRole(scheme string, role assets.ChannelRole) *Channel {
-	for _, ch := range s.all {
-		if ch.HasRole(role) && ch.SupportsScheme(scheme) {
+	for _, ch := range s.byScheme[scheme] {
+		if ch.HasRole(role) {
 			return s.getDelegate(ch, role)
 		}
 	}
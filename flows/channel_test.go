@@ -115,3 +115,34 @@ func TestChannelSetGetForURN(t *testing.T) {
 	assert.Equal(t, short1, all.GetForURN(flows.NewContactURN(urns.URN("tel:+250771234567"), nil), assets.ChannelRoleSend))
 	assert.Equal(t, short2, all.GetForURN(flows.NewContactURN(urns.URN("tel:+250721234567"), nil), assets.ChannelRoleSend))
 }
+
+func TestChannelSetGetByScheme(t *testing.T) {
+	rolesReceive := []assets.ChannelRole{assets.ChannelRoleReceive}
+	rolesDefault := []assets.ChannelRole{assets.ChannelRoleSend, assets.ChannelRoleReceive}
+
+	mtn := test.NewTelChannel("MTN", "+250782222222", rolesDefault, nil, "RW", nil, false)
+	receiver := test.NewTelChannel("Receiver", "+250724444444", rolesReceive, nil, "RW", nil, false)
+	twitter := test.NewChannel("Twitter", "nyaruka", []string{"twitter", "twitterid"}, rolesDefault, nil)
+	facebook := test.NewChannel("Facebook", "12345", []string{"facebook"}, rolesDefault, nil)
+
+	all := flows.NewChannelAssets([]assets.Channel{mtn.Asset(), receiver.Asset(), twitter.Asset(), facebook.Asset()})
+
+	// channels are indexed by each scheme they support, in the order they were provided
+	assert.Equal(t, []*flows.Channel{mtn, receiver}, all.GetByScheme("tel"))
+	assert.Equal(t, []*flows.Channel{twitter}, all.GetByScheme("twitter"))
+	assert.Equal(t, []*flows.Channel{twitter}, all.GetByScheme("twitterid"))
+	assert.Equal(t, []*flows.Channel{facebook}, all.GetByScheme("facebook"))
+
+	// empty for schemes no channel supports
+	assert.Equal(t, 0, len(all.GetByScheme("mailto")))
+	assert.Equal(t, 0, len(flows.NewChannelAssets(nil).GetByScheme("tel")))
+
+	// selecting a channel for a URN still filters the indexed channels by role
+	assert.Equal(t, mtn, all.GetForURN(flows.NewContactURN(urns.URN("tel:+250721234567"), nil), assets.ChannelRoleSend))
+	assert.Equal(t, receiver, all.GetForURN(flows.NewContactURN(urns.URN("tel:+250724444440"), nil), assets.ChannelRoleReceive))
+	assert.Equal(t, twitter, all.GetForURN(flows.NewContactURN(urns.URN("twitterid:12345"), nil), assets.ChannelRoleSend))
+
+	receiverOnly := flows.NewChannelAssets([]assets.Channel{receiver.Asset()})
+	assert.Equal(t, []*flows.Channel{receiver}, receiverOnly.GetByScheme("tel"))
+	assert.Nil(t, receiverOnly.GetForURN(flows.NewContactURN(urns.URN("tel:+250721234567"), nil), assets.ChannelRoleSend))
+}
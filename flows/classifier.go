@@ -1,6 +1,10 @@
 package flows
 
-import "github.com/nyaruka/goflow/assets"
+import (
+	"strings"
+
+	"github.com/nyaruka/goflow/assets"
+)
 
 // Classifier represents an NLU classifier.
 type Classifier struct {
@@ -22,16 +26,20 @@ func (c *Classifier) Reference() *assets.ClassifierReference {
 
 // ClassifierAssets provides access to all classifier assets
 type ClassifierAssets struct {
+	all    []*Classifier
 	byUUID map[assets.ClassifierUUID]*Classifier
 }
 
 // NewClassifierAssets creates a new set of classifier assets
 func NewClassifierAssets(classifiers []assets.Classifier) *ClassifierAssets {
 	s := &ClassifierAssets{
+		all:    make([]*Classifier, 0, len(classifiers)),
 		byUUID: make(map[assets.ClassifierUUID]*Classifier, len(classifiers)),
 	}
 	for _, asset := range classifiers {
-		s.byUUID[asset.UUID()] = NewClassifier(asset)
+		classifier := NewClassifier(asset)
+		s.all = append(s.all, classifier)
+		s.byUUID[asset.UUID()] = classifier
 	}
 	return s
 }
@@ -40,3 +48,14 @@ func NewClassifierAssets(classifiers []assets.Classifier) *ClassifierAssets {
 func (s *ClassifierAssets) Get(uuid assets.ClassifierUUID) *Classifier {
 	return s.byUUID[uuid]
 }
+
+// GetByName returns the classifier with the given name (case-insensitive), or nil if there isn't one
+func (s *ClassifierAssets) GetByName(name string) *Classifier {
+	name = strings.ToLower(name)
+	for _, classifier := range s.all {
+		if strings.ToLower(classifier.Name()) == name {
+			return classifier
+		}
+	}
+	return nil
+}
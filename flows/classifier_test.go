@@ -0,0 +1,29 @@
+package flows_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/assets"
+	atypes "github.com/nyaruka/goflow/assets/static/types"
+	"github.com/nyaruka/goflow/flows"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifiers(t *testing.T) {
+	ca1 := atypes.NewClassifier("37657cf7-5eab-4286-9cb0-bbf270587bad", "Booking", "wit", []string{"book_flight", "book_hotel"})
+	ca2 := atypes.NewClassifier("4b937f49-7fb7-43a5-8e57-14e2f028a471", "Support", "luis", []string{"complaint"})
+
+	ca := flows.NewClassifierAssets([]assets.Classifier{ca1, ca2})
+
+	assert.Equal(t, ca1, ca.Get("37657cf7-5eab-4286-9cb0-bbf270587bad").Asset())
+	assert.Nil(t, ca.Get("f5a3a3a4-8e9c-4b42-b1b4-0a7d3ad1f7b9"))
+
+	assert.Equal(t, ca1, ca.GetByName("Booking").Asset())
+	assert.Equal(t, ca1, ca.GetByName("BOOKING").Asset())
+	assert.Equal(t, ca2, ca.GetByName("support").Asset())
+	assert.Nil(t, ca.GetByName("Bookings"))
+
+	c1 := ca.GetByName("booking")
+	assert.Equal(t, assets.NewClassifierReference("37657cf7-5eab-4286-9cb0-bbf270587bad", "Booking"), c1.Reference())
+}
@@ -1,6 +1,8 @@
 package flows
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -43,6 +45,9 @@ const (
 	ContactStatusArchived ContactStatus = "archived"
 )
 
+// AnonymousName is the name used in place of a contact's name when it is redacted or the contact is anonymized
+const AnonymousName = "Anonymous"
+
 // Contact represents a person who is interacting with the flow
 type Contact struct {
 	uuid       ContactUUID
@@ -124,6 +129,12 @@ func (c *Contact) Clone() *Contact {
 		return nil
 	}
 
+	var lastSeenOn *time.Time
+	if c.lastSeenOn != nil {
+		t := *c.lastSeenOn
+		lastSeenOn = &t
+	}
+
 	return &Contact{
 		uuid:       c.uuid,
 		id:         c.id,
@@ -132,14 +143,37 @@ func (c *Contact) Clone() *Contact {
 		status:     c.status,
 		timezone:   c.timezone,
 		createdOn:  c.createdOn,
-		lastSeenOn: c.lastSeenOn,
+		lastSeenOn: lastSeenOn,
 		urns:       c.urns.clone(),
 		groups:     c.groups.clone(),
-		fields:     c.fields.clone(),
+		fields:     c.fields.Snapshot(),
 		assets:     c.assets,
 	}
 }
 
+// Anonymize returns a copy of this contact with its personally identifying information removed, e.g. when the contact
+// has requested erasure. The copy keeps the same UUID, language, status, timezone, dates and groups so that flow
+// analytics are preserved, but its name is replaced with AnonymousName, its field values are cleared, and its URNs
+// are replaced by a single ext URN whose path is a SHA-256 hash of the contact UUID. The ext scheme is used because
+// URNs must have a valid scheme for the contact to be read back. The original is unchanged.
+func (c *Contact) Anonymize() *Contact {
+	if c == nil {
+		return nil
+	}
+
+	anon := c.Clone()
+	anon.name = AnonymousName
+
+	hash := sha256.Sum256([]byte(c.uuid))
+	anon.urns = URNList{NewContactURN(urns.URN(urns.ExternalScheme+":"+hex.EncodeToString(hash[:])), nil)}
+
+	for key := range anon.fields {
+		anon.fields[key] = nil
+	}
+
+	return anon
+}
+
 // Equal returns true if this instance is equal to the given instance
 func (c *Contact) Equal(other *Contact) bool {
 	asJSON1, _ := jsonx.Marshal(c)
@@ -199,6 +233,37 @@ func (c *Contact) CreatedOn() time.Time { return c.createdOn }
 // LastSeenOn returns the last seen on time of this contact
 func (c *Contact) LastSeenOn() *time.Time { return c.lastSeenOn }
 
+// Age returns the age in full years of this contact at the given time based on their birthday field, or -1 if that
+// isn't set or isn't a valid date
+func (c *Contact) Age(now time.Time) int {
+	value := c.fields["birthday"]
+	if value == nil {
+		return -1
+	}
+
+	var birthday time.Time
+	if value.Datetime != nil {
+		birthday = value.Datetime.Native()
+	} else {
+		var err error
+		if birthday, err = time.Parse("2006-01-02", value.Text.Native()); err != nil {
+			return -1
+		}
+	}
+
+	age := now.Year() - birthday.Year()
+	if now.Month() < birthday.Month() || (now.Month() == birthday.Month() && now.Day() < birthday.Day()) {
+		age--
+	}
+	if age < 0 {
+		return -1
+	}
+	return age
+}
+
+// SetCreatedOn sets the created on time of this contact
+func (c *Contact) SetCreatedOn(t time.Time) { c.createdOn = t }
+
 // SetLastSeenOn sets the last seen on time of this contact
 func (c *Contact) SetLastSeenOn(t time.Time) { c.lastSeenOn = &t }
 
@@ -245,6 +310,23 @@ func (c *Contact) RemoveURN(urn urns.URN) bool {
 	return true
 }
 
+// SetURNChannel sets the preferred channel of the given URN on this contact, or clears it if channel is nil. Returns
+// whether the contact has that URN and its channel was changed.
+func (c *Contact) SetURNChannel(urn urns.URN, channel *Channel) bool {
+	urn = urn.Normalize("")
+
+	for _, u := range c.urns {
+		if u.URN().Identity() == urn.Identity() {
+			if u.Channel() == channel {
+				return false
+			}
+			u.SetChannel(channel)
+			return true
+		}
+	}
+	return false
+}
+
 // HasURN checks whether the contact has the given URN
 func (c *Contact) HasURN(urn urns.URN) bool {
 	urn = urn.Normalize("")
@@ -260,9 +342,42 @@ func (c *Contact) HasURN(urn urns.URN) bool {
 // Fields returns this contact's field values
 func (c *Contact) Fields() FieldValues { return c.fields }
 
+// SetField sets the value of the field with the given key, or clears it if value is nil or has no text. The error
+// returned wraps ErrFieldTypeMismatch if the value doesn't have a value of the field's type, e.g. a text value without a
+// number for a number field.
+func (c *Contact) SetField(key string, value *Value) error {
+	field := c.assets.Fields().Get(key)
+	if field == nil {
+		return errors.Errorf("no such field with key '%s'", key)
+	}
+
+	if value != nil && !value.Text.Empty() && !value.hasType(field.Type()) {
+		return errors.Wrapf(ErrFieldTypeMismatch, "can't set %s field '%s' to '%s'", field.Type(), key, value.Text.Native())
+	}
+
+	c.fields.Set(field, value)
+	return nil
+}
+
 // Groups returns the groups that this contact belongs to
 func (c *Contact) Groups() *GroupList { return c.groups }
 
+// HasGroup returns whether this contact belongs to the group with the given UUID
+func (c *Contact) HasGroup(uuid assets.GroupUUID) bool {
+	return c.groups.FindByUUID(uuid) != nil
+}
+
+// HasGroupByName returns whether this contact belongs to a group with the given name (case-insensitive)
+func (c *Contact) HasGroupByName(name string) bool {
+	name = normalizeAssetName(name)
+	for _, group := range c.groups.All() {
+		if normalizeAssetName(group.Name()) == name {
+			return true
+		}
+	}
+	return false
+}
+
 // Reference returns a reference to this contact
 func (c *Contact) Reference() *ContactReference {
 	if c == nil {
@@ -275,11 +390,14 @@ func (c *Contact) Reference() *ContactReference {
 func (c *Contact) Format(env envs.Environment) string {
 	// if contact has a name set, use that
 	if c.name != "" {
+		if env.RedactionPolicy().RedactsContactDetails() {
+			return AnonymousName
+		}
 		return c.name
 	}
 
 	// otherwise use either id or the highest priority URN depending on the env
-	if env.RedactionPolicy() == envs.RedactionPolicyURNs {
+	if env.RedactionPolicy().RedactsURNs() {
 		return strconv.Itoa(int(c.id))
 	}
 	if len(c.urns) > 0 {
@@ -296,9 +414,11 @@ func (c *Contact) Format(env envs.Environment) string {
 //   id:text -> the numeric ID of the contact
 //   first_name:text -> the first name of the contact
 //   name:text -> the name of the contact
-//   language:text -> the language of the contact as 3-letter ISO code
+//   language:text -> the language of the contact as 3-letter ISO code, or the default language if it isn't allowed
+//   language_name:any -> the English name of the language of the contact
 //   created_on:datetime -> the creation date of the contact
 //   last_seen_on:any -> the last seen date of the contact
+//   age:any -> the age in years of the contact based on their birthday field
 //   urns:[]text -> the URNs belonging to the contact
 //   urn:text -> the preferred URN of the contact
 //   groups:[]group -> the groups the contact belongs to
@@ -307,7 +427,7 @@ func (c *Contact) Format(env envs.Environment) string {
 //
 // @context contact
 func (c *Contact) Context(env envs.Environment) map[string]types.XValue {
-	var firstName, urn, timezone, lastSeenOn types.XValue
+	var firstName, languageName, urn, timezone, lastSeenOn, age types.XValue
 
 	if c.timezone != nil {
 		timezone = types.NewXText(c.timezone.String())
@@ -318,30 +438,53 @@ func (c *Contact) Context(env envs.Environment) map[string]types.XValue {
 		urn = preferredURN.ToXValue(env)
 	}
 
-	names := utils.TokenizeString(c.name)
+	name := c.name
+	if name != "" && env.RedactionPolicy().RedactsContactDetails() {
+		name = AnonymousName
+	}
+
+	names := utils.TokenizeString(name)
 	if len(names) >= 1 {
 		firstName = types.NewXText(names[0])
 	}
 
+	// only fall back to the default language if the environment restricts languages and this isn't one of them
+	language := c.language
+	if len(env.AllowedLanguages()) > 0 {
+		language = env.ResolveLanguage(c.language)
+	}
+
+	if name, err := language.DisplayName(); err == nil {
+		languageName = types.NewXText(name)
+	}
+
 	if c.lastSeenOn != nil {
 		lastSeenOn = types.NewXDateTime(*c.lastSeenOn)
 	}
 
+	if c.fields["birthday"] != nil {
+		if years := c.Age(dates.Now().In(env.Timezone())); years >= 0 {
+			age = types.NewXNumberFromInt(years)
+		}
+	}
+
 	return map[string]types.XValue{
-		"__default__":  types.NewXText(c.Format(env)),
-		"uuid":         types.NewXText(string(c.uuid)),
-		"id":           types.NewXText(strconv.Itoa(int(c.id))),
-		"name":         types.NewXText(c.name),
-		"first_name":   firstName,
-		"language":     types.NewXText(string(c.language)),
-		"timezone":     timezone,
-		"created_on":   types.NewXDateTime(c.createdOn),
-		"last_seen_on": lastSeenOn,
-		"urns":         c.urns.ToXValue(env),
-		"urn":          urn,
-		"groups":       c.groups.ToXValue(env),
-		"fields":       Context(env, c.Fields()),
-		"channel":      Context(env, c.PreferredChannel()),
+		"__default__":   types.NewXText(c.Format(env)),
+		"uuid":          types.NewXText(string(c.uuid)),
+		"id":            types.NewXText(strconv.Itoa(int(c.id))),
+		"name":          types.NewXText(name),
+		"first_name":    firstName,
+		"language":      types.NewXText(string(language)),
+		"language_name": languageName,
+		"timezone":      timezone,
+		"created_on":    types.NewXDateTime(c.createdOn),
+		"last_seen_on":  lastSeenOn,
+		"age":           age,
+		"urns":          c.urns.ToXValue(env),
+		"urn":           urn,
+		"groups":        c.groups.ToXValue(env),
+		"fields":        Context(env, c.Fields()),
+		"channel":       Context(env, c.PreferredChannel()),
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/jsonx"
 	"github.com/nyaruka/gocommon/urns"
 	"github.com/nyaruka/gocommon/uuids"
@@ -13,12 +14,14 @@ import (
 	"github.com/nyaruka/goflow/assets/static"
 	"github.com/nyaruka/goflow/contactql"
 	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent"
 	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/engine"
 	"github.com/nyaruka/goflow/flows/triggers"
 	"github.com/nyaruka/goflow/test"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -58,7 +61,7 @@ func TestContact(t *testing.T) {
 		envs.Language("eng"),
 		flows.ContactStatusActive,
 		tz,
-		time.Date(2017, 12, 15, 10, 0, 0, 0, time.UTC),
+		test.MustParseTime("2017-12-15T10:00:00Z"),
 		nil,
 		nil,
 		nil,
@@ -72,8 +75,8 @@ func TestContact(t *testing.T) {
 	assert.Nil(t, contact.LastSeenOn())
 	assert.Nil(t, contact.PreferredChannel())
 
-	contact.SetLastSeenOn(time.Date(2018, 12, 15, 10, 0, 0, 0, time.UTC))
-	assert.Equal(t, time.Date(2018, 12, 15, 10, 0, 0, 0, time.UTC), *contact.LastSeenOn())
+	contact.SetLastSeenOn(test.MustParseTime("2018-12-15T10:00:00Z"))
+	assert.Equal(t, test.MustParseTime("2018-12-15T10:00:00Z"), *contact.LastSeenOn())
 
 	contact.AddURN(urns.URN("tel:+12024561111?channel=294a14d4-c998-41e5-a314-5941b97b89d7"), nil)
 	contact.AddURN(urns.URN("twitter:joey"), nil)
@@ -132,25 +135,38 @@ func TestContact(t *testing.T) {
 	assert.Equal(t, envs.Language("eng"), clone.Language())
 	assert.Equal(t, android, contact.PreferredChannel())
 
+	// created on can be changed and is reflected in expressions and JSON
+	contact.SetCreatedOn(test.MustParseTime("2019-03-04T05:06:07Z"))
+	assert.Equal(t, test.MustParseTime("2019-03-04T05:06:07Z"), contact.CreatedOn())
+	test.AssertXEqual(t, types.NewXDateTime(test.MustParseTime("2019-03-04T05:06:07Z")), contact.Context(env)["created_on"])
+
+	contactJSON, err := jsonx.Marshal(contact)
+	require.NoError(t, err)
+	roundTripped, err := flows.ReadContact(sa, contactJSON, assets.PanicOnMissing)
+	require.NoError(t, err)
+	assert.Equal(t, test.MustParseTime("2019-03-04T05:06:07Z"), roundTripped.CreatedOn())
+
 	// can also clone a null contact!
 	mrNil := (*flows.Contact)(nil)
 	assert.Nil(t, mrNil.Clone())
 
 	test.AssertXEqual(t, types.NewXObject(map[string]types.XValue{
-		"__default__":  types.NewXText("Joe Bloggs"),
-		"channel":      flows.Context(env, android),
-		"created_on":   types.NewXDateTime(contact.CreatedOn()),
-		"last_seen_on": types.NewXDateTime(*contact.LastSeenOn()),
-		"fields":       flows.Context(env, contact.Fields()),
-		"first_name":   types.NewXText("Joe"),
-		"groups":       contact.Groups().ToXValue(env),
-		"id":           types.NewXText("12345"),
-		"language":     types.NewXText("eng"),
-		"name":         types.NewXText("Joe Bloggs"),
-		"timezone":     types.NewXText("America/Bogota"),
-		"urn":          contact.URNs()[0].ToXValue(env),
-		"urns":         contact.URNs().ToXValue(env),
-		"uuid":         types.NewXText(string(contact.UUID())),
+		"__default__":   types.NewXText("Joe Bloggs"),
+		"channel":       flows.Context(env, android),
+		"created_on":    types.NewXDateTime(contact.CreatedOn()),
+		"last_seen_on":  types.NewXDateTime(*contact.LastSeenOn()),
+		"age":           nil,
+		"fields":        flows.Context(env, contact.Fields()),
+		"first_name":    types.NewXText("Joe"),
+		"groups":        contact.Groups().ToXValue(env),
+		"id":            types.NewXText("12345"),
+		"language":      types.NewXText("eng"),
+		"language_name": types.NewXText("English"),
+		"name":          types.NewXText("Joe Bloggs"),
+		"timezone":      types.NewXText("America/Bogota"),
+		"urn":           contact.URNs()[0].ToXValue(env),
+		"urns":          contact.URNs().ToXValue(env),
+		"uuid":          types.NewXText(string(contact.UUID())),
 	}), flows.Context(env, contact))
 
 	assert.True(t, contact.ClearURNs()) // did have URNs
@@ -158,6 +174,195 @@ func TestContact(t *testing.T) {
 	assert.Equal(t, flows.URNList{}, contact.URNs())
 }
 
+func TestContactClone(t *testing.T) {
+	session, _, err := test.CreateTestSession("http://localhost", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	sa := session.Assets()
+	original := session.Contact()
+	original.SetLastSeenOn(test.MustParseTime("2020-08-01T12:00:00Z"))
+
+	tcs := []struct {
+		description string
+		mutate      func(*flows.Contact)
+	}{
+		{"name changed", func(c *flows.Contact) { c.SetName("Bob") }},
+		{"language changed", func(c *flows.Contact) { c.SetLanguage(envs.Language("fra")) }},
+		{"status changed", func(c *flows.Contact) { c.SetStatus(flows.ContactStatusBlocked) }},
+		{"timezone cleared", func(c *flows.Contact) { c.SetTimezone(nil) }},
+		{"last seen on changed in place", func(c *flows.Contact) { *c.LastSeenOn() = test.MustParseTime("2021-01-01T00:00:00Z") }},
+		{"URN added", func(c *flows.Contact) { c.AddURN(urns.URN("tel:+593979000000"), nil) }},
+		{"URNs cleared", func(c *flows.Contact) { c.ClearURNs() }},
+		{"URN channel changed", func(c *flows.Contact) { c.URNs()[0].SetChannel(nil) }},
+		{"group added", func(c *flows.Contact) { c.Groups().Add(sa.Groups().FindByName("Customers")) }},
+		{"group removed", func(c *flows.Contact) { c.Groups().Remove(sa.Groups().FindByName("Testers")) }},
+		{"field set", func(c *flows.Contact) {
+			c.Fields().Set(sa.Fields().Get("gender"), flows.NewValue(types.NewXText("Female"), nil, nil, "", "", ""))
+		}},
+		{"field cleared", func(c *flows.Contact) { c.Fields().Set(sa.Fields().Get("gender"), nil) }},
+		{"field value changed in place", func(c *flows.Contact) {
+			value := c.Fields().Get(sa.Fields().Get("join_date"))
+			value.Text = types.NewXText("2020-01-01")
+			*value.Datetime = types.NewXDateTime(test.MustParseTime("2020-01-01T00:00:00Z"))
+		}},
+	}
+
+	originalJSON, err := jsonx.Marshal(original)
+	require.NoError(t, err)
+
+	for _, tc := range tcs {
+		clone := original.Clone()
+		assert.True(t, original.Equal(clone), "clone not equal to original before mutating in '%s'", tc.description)
+
+		tc.mutate(clone)
+
+		cloneJSON, err := jsonx.Marshal(clone)
+		require.NoError(t, err)
+		afterJSON, err := jsonx.Marshal(original)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, string(originalJSON), string(cloneJSON), "clone unchanged in '%s'", tc.description)
+		assert.Equal(t, string(originalJSON), string(afterJSON), "original changed in '%s'", tc.description)
+	}
+}
+
+func TestContactAnonymize(t *testing.T) {
+	session, _, err := test.CreateTestSession("http://localhost", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	original := session.Contact()
+	originalJSON, err := jsonx.Marshal(original)
+	require.NoError(t, err)
+
+	anon := original.Anonymize()
+
+	// identifying information is removed
+	assert.Equal(t, flows.AnonymousName, anon.Name())
+	assert.Equal(t, 1, len(anon.URNs()))
+	assert.Equal(t, urns.URN("ext:401b3e7d550db0e793573f946f03df2078c6ba7d303f0988f4e43cb3ab9eb5ef"), anon.URNs()[0].URN())
+	assert.Nil(t, anon.PreferredChannel())
+	for key, value := range anon.Fields() {
+		assert.Nil(t, value, "field %s not cleared", key)
+	}
+	assert.Equal(t, len(original.Fields()), len(anon.Fields()))
+
+	// but everything else is preserved
+	assert.Equal(t, original.UUID(), anon.UUID())
+	assert.Equal(t, original.ID(), anon.ID())
+	assert.Equal(t, original.Language(), anon.Language())
+	assert.Equal(t, original.Status(), anon.Status())
+	assert.Equal(t, original.Timezone(), anon.Timezone())
+	assert.Equal(t, original.CreatedOn(), anon.CreatedOn())
+	assert.Equal(t, original.Groups().All(), anon.Groups().All())
+
+	// and the original is unchanged
+	afterJSON, err := jsonx.Marshal(original)
+	require.NoError(t, err)
+	assert.Equal(t, string(originalJSON), string(afterJSON))
+
+	// anonymized contacts are still valid contacts, which they wouldn't be with an anonymous scheme URN
+	assert.EqualError(t, urns.URN("anonymous:401b3e7d550db0e793573f946f03df2078c6ba7d303f0988f4e43cb3ab9eb5ef").Validate(), "invalid scheme: 'anonymous'")
+
+	anonJSON, err := jsonx.Marshal(anon)
+	require.NoError(t, err)
+	readBack, err := flows.ReadContact(session.Assets(), anonJSON, assets.PanicOnMissing)
+	require.NoError(t, err)
+	assert.True(t, anon.Equal(readBack))
+
+	// anonymizing is deterministic
+	assert.Equal(t, anon.URNs()[0].URN(), original.Anonymize().URNs()[0].URN())
+
+	assert.Nil(t, (*flows.Contact)(nil).Anonymize())
+}
+
+func TestContactHasGroup(t *testing.T) {
+	session, _, err := test.CreateTestSession("http://localhost", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	contact := session.Contact()
+
+	assert.True(t, contact.HasGroup("b7cf0d83-f1c9-411c-96fd-c511a4cfa86d"))
+	assert.True(t, contact.HasGroup("4f1f98fc-27a7-4a69-bbdb-24744ba739a9"))
+	assert.False(t, contact.HasGroup("1e1ce1e1-9288-4504-869e-022d1003c72a")) // Customers
+	assert.False(t, contact.HasGroup(""))
+
+	assert.True(t, contact.HasGroupByName("Testers"))
+	assert.True(t, contact.HasGroupByName("MALES"))
+	assert.True(t, contact.HasGroupByName(" testers "))
+	assert.False(t, contact.HasGroupByName("Customers"))
+	assert.False(t, contact.HasGroupByName("Test"))
+
+	contact.Groups().Remove(session.Assets().Groups().Get("b7cf0d83-f1c9-411c-96fd-c511a4cfa86d"))
+
+	assert.False(t, contact.HasGroup("b7cf0d83-f1c9-411c-96fd-c511a4cfa86d"))
+	assert.False(t, contact.HasGroupByName("Testers"))
+}
+
+func TestContactSetField(t *testing.T) {
+	source, err := static.NewSource([]byte(`{
+		"fields": [
+			{"uuid": "d66a7823-eada-40e5-9a3a-57239d4690bf", "key": "gender", "name": "Gender", "type": "text"},
+			{"uuid": "f1b5aea6-6586-41c7-9020-1a6326cc6565", "key": "age", "name": "Age", "type": "number"},
+			{"uuid": "6c86d5ab-3fd9-4a5c-a5b6-48168b016747", "key": "join_date", "name": "Join Date", "type": "datetime"},
+			{"uuid": "a8ca2e8b-1c5d-4b5e-9a3f-3e6f0d2c1b7a", "key": "state", "name": "State", "type": "state"},
+			{"uuid": "b3f1c6d2-7e4a-4f8b-9c2d-5a6e7f8a9b0c", "key": "district", "name": "District", "type": "district"},
+			{"uuid": "c4e2d7f3-8a5b-4c9d-8e3f-6b7c8d9e0f1a", "key": "ward", "name": "Ward", "type": "ward"}
+		]
+	}`))
+	require.NoError(t, err)
+
+	sa, err := engine.NewSessionAssets(envs.NewBuilder().Build(), source, nil)
+	require.NoError(t, err)
+
+	num := types.RequireXNumberFromString("23")
+	date := types.NewXDateTime(test.MustParseTime("2017-12-15T10:00:00Z"))
+
+	textValue := flows.NewValue(types.NewXText("hello"), nil, nil, "", "", "")
+	numberValue := flows.NewValue(types.NewXText("23"), nil, &num, "", "", "")
+	datetimeValue := flows.NewValue(types.NewXText("2017-12-15"), &date, nil, "", "", "")
+	stateValue := flows.NewValue(types.NewXText("Kigali"), nil, nil, "Rwanda > Kigali City", "", "")
+	districtValue := flows.NewValue(types.NewXText("Gasabo"), nil, nil, "Rwanda > Kigali City", "Rwanda > Kigali City > Gasabo", "")
+	wardValue := flows.NewValue(types.NewXText("Gisozi"), nil, nil, "Rwanda > Kigali City", "Rwanda > Kigali City > Gasabo", "Rwanda > Kigali City > Gasabo > Gisozi")
+
+	tcs := []struct {
+		key     string
+		valid   *flows.Value
+		invalid *flows.Value
+		err     string
+	}{
+		{"gender", numberValue, nil, ""}, // any value is valid for a text field
+		{"age", numberValue, textValue, "can't set number field 'age' to 'hello': value doesn't match field type"},
+		{"join_date", datetimeValue, numberValue, "can't set datetime field 'join_date' to '23': value doesn't match field type"},
+		{"state", stateValue, textValue, "can't set state field 'state' to 'hello': value doesn't match field type"},
+		{"district", districtValue, stateValue, "can't set district field 'district' to 'Kigali': value doesn't match field type"},
+		{"ward", wardValue, districtValue, "can't set ward field 'ward' to 'Gasabo': value doesn't match field type"},
+	}
+
+	for _, tc := range tcs {
+		contact := flows.NewEmptyContact(sa, "Bob", envs.NilLanguage, nil)
+		field := sa.Fields().Get(tc.key)
+
+		assert.NoError(t, contact.SetField(tc.key, tc.valid), "unexpected error for field %s", tc.key)
+		assert.Equal(t, tc.valid, contact.Fields().Get(field), "value mismatch for field %s", tc.key)
+
+		if tc.invalid != nil {
+			err := contact.SetField(tc.key, tc.invalid)
+			assert.EqualError(t, err, tc.err)
+			assert.True(t, errors.Is(err, flows.ErrFieldTypeMismatch))
+
+			// and value is unchanged
+			assert.Equal(t, tc.valid, contact.Fields().Get(field), "value mismatch for field %s", tc.key)
+		}
+
+		// values can always be cleared
+		assert.NoError(t, contact.SetField(tc.key, nil))
+		assert.Nil(t, contact.Fields().Get(field))
+	}
+
+	contact := flows.NewEmptyContact(sa, "Bob", envs.NilLanguage, nil)
+	assert.EqualError(t, contact.SetField("xxx", textValue), "no such field with key 'xxx'")
+}
+
 func TestReadContact(t *testing.T) {
 	source, err := static.NewSource([]byte(`{}`))
 	require.NoError(t, err)
@@ -178,6 +383,86 @@ func TestReadContact(t *testing.T) {
 	assert.EqualError(t, err, "unable to read contact: field 'status' is not a valid contact status")
 }
 
+func TestContactAge(t *testing.T) {
+	source, err := static.NewSource([]byte(`{
+		"fields": [
+			{"uuid": "d66a7823-eada-40e5-9a3a-57239d4690bf", "key": "birthday", "name": "Birthday", "type": "datetime"}
+		]
+	}`))
+	require.NoError(t, err)
+
+	env := envs.NewBuilder().Build()
+
+	sa, err := engine.NewSessionAssets(env, source, nil)
+	require.NoError(t, err)
+
+	readContact := func(fieldsJSON string) *flows.Contact {
+		contact, err := flows.ReadContact(sa, []byte(`{"uuid": "a20f7948-e497-4a4a-be3c-b17f79f7ab7d", "created_on": "2020-07-22T13:50:30.123456789Z", "fields": `+fieldsJSON+`}`), assets.PanicOnMissing)
+		require.NoError(t, err)
+		return contact
+	}
+
+	contact := readContact(`{"birthday": {"text": "1990-05-10", "datetime": "1990-05-10T00:00:00.000000Z"}}`)
+	assert.Equal(t, 30, contact.Age(test.MustParseTime("2020-05-10T12:00:00Z")))
+	assert.Equal(t, 29, contact.Age(test.MustParseTime("2020-05-09T12:00:00Z")))
+	assert.Equal(t, -1, contact.Age(test.MustParseTime("1989-05-09T12:00:00Z")))
+
+	defer dates.SetNowSource(dates.DefaultNowSource)
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2020-08-01T12:00:00Z")))
+
+	test.AssertXEqual(t, types.NewXNumberFromInt(30), contact.Context(env)["age"])
+
+	// born on a leap day, so birthday is considered to be March 1st in other years
+	contact = readContact(`{"birthday": {"text": "2000-02-29", "datetime": "2000-02-29T00:00:00.000000Z"}}`)
+	assert.Equal(t, 20, contact.Age(test.MustParseTime("2021-02-28T12:00:00Z")))
+	assert.Equal(t, 21, contact.Age(test.MustParseTime("2021-03-01T12:00:00Z")))
+	assert.Equal(t, 24, contact.Age(test.MustParseTime("2024-02-29T12:00:00Z")))
+
+	// birthday which isn't a date
+	contact = readContact(`{"birthday": {"text": "sometime in May"}}`)
+	assert.Equal(t, -1, contact.Age(test.MustParseTime("2020-05-10T12:00:00Z")))
+
+	// no birthday at all
+	contact = readContact(`{}`)
+	assert.Equal(t, -1, contact.Age(test.MustParseTime("2020-05-10T12:00:00Z")))
+	assert.Nil(t, contact.Context(env)["age"])
+}
+
+func TestContactLanguageResolution(t *testing.T) {
+	source, err := static.NewSource([]byte(`{}`))
+	require.NoError(t, err)
+
+	env := envs.NewBuilder().
+		WithDefaultLanguage("eng").
+		WithAllowedLanguages([]envs.Language{"eng", "fra"}).
+		Build()
+
+	sa, err := engine.NewSessionAssets(env, source, nil)
+	require.NoError(t, err)
+
+	evaluate := func(contact *flows.Contact, template string) string {
+		context := types.NewXObject(map[string]types.XValue{"contact": flows.Context(env, contact)})
+		out, err := excellent.EvaluateTemplate(env, context, template, nil)
+		require.NoError(t, err)
+		return out
+	}
+
+	// contact with an allowed language
+	contact := flows.NewEmptyContact(sa, "Bob", envs.Language("fra"), nil)
+	assert.Equal(t, envs.Language("fra"), contact.Language())
+	assert.Equal(t, "fra French", evaluate(contact, "@contact.language @contact.language_name"))
+
+	// contact with a language that isn't allowed gets the default language in expressions
+	contact = flows.NewEmptyContact(sa, "Bob", envs.Language("kin"), nil)
+	assert.Equal(t, envs.Language("kin"), contact.Language())
+	assert.Equal(t, "eng English", evaluate(contact, "@contact.language @contact.language_name"))
+
+	// as does a contact with no language
+	contact = flows.NewEmptyContact(sa, "Bob", envs.NilLanguage, nil)
+	assert.Equal(t, envs.NilLanguage, contact.Language())
+	assert.Equal(t, "eng", evaluate(contact, "@contact.language"))
+}
+
 func TestContactFormat(t *testing.T) {
 	env := envs.NewBuilder().Build()
 	sa, _ := engine.NewSessionAssets(env, static.NewEmptySource(), nil)
@@ -1,16 +1,20 @@
 package definition
 
 import (
+	"strings"
 	"sync"
 
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/definition/migrations"
+
+	"github.com/pkg/errors"
 )
 
 // implemention of FlowAssets which provides lazy loading and validation of flows
 type flowAssets struct {
 	byUUID map[assets.FlowUUID]flows.Flow
+	byName map[string]assets.FlowUUID // built on first lookup by name
 
 	mutex  sync.Mutex
 	source assets.Source
@@ -50,3 +54,56 @@ func (a *flowAssets) Get(uuid assets.FlowUUID) (flows.Flow, error) {
 	a.byUUID[flow.UUID()] = flow
 	return flow, nil
 }
+
+// GetByName returns the flow with the given name (case-insensitive). If the source has more than one flow with that
+// name, the first one is returned. If there is no such flow, the returned error wraps flows.ErrFlowNotFound.
+func (a *flowAssets) GetByName(name string) (flows.Flow, error) {
+	uuid, err := a.uuidForName(name)
+	if err != nil {
+		return nil, err
+	}
+	return a.Get(uuid)
+}
+
+func (a *flowAssets) uuidForName(name string) (assets.FlowUUID, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.byName == nil {
+		enumerator, canEnumerate := a.source.(assets.FlowEnumerator)
+		if !canEnumerate {
+			return "", errors.New("can't look up flows by name as source can't enumerate its flows")
+		}
+
+		byName := make(map[string]assets.FlowUUID)
+		for _, uuid := range enumerator.FlowUUIDs() {
+			asset, err := a.source.Flow(uuid)
+			if err != nil {
+				return "", err
+			}
+
+			key := strings.ToLower(asset.Name())
+			if _, exists := byName[key]; !exists {
+				byName[key] = uuid
+			}
+		}
+		a.byName = byName
+	}
+
+	uuid, found := a.byName[strings.ToLower(name)]
+	if !found || name == "" {
+		return "", errors.Wrapf(flows.ErrFlowNotFound, "name '%s'", name)
+	}
+	return uuid, nil
+}
+
+// AllUUIDs returns the UUIDs of all flows in the underlying source, or nil if the source can't enumerate its flows
+func (a *flowAssets) AllUUIDs() []assets.FlowUUID {
+	enumerator, canEnumerate := a.source.(assets.FlowEnumerator)
+	if !canEnumerate {
+		return nil
+	}
+	return enumerator.FlowUUIDs()
+}
+
+var _ flows.NamedFlowAssets = (*flowAssets)(nil)
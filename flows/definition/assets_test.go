@@ -0,0 +1,69 @@
+package definition_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/definition"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlowAssets(t *testing.T) {
+	source, err := static.NewSource([]byte(`{
+		"flows": [
+			{"uuid": "76f0a02f-3b75-4b86-9064-e9195e1b3a02", "name": "Flow 1", "spec_version": "13.1.0", "language": "eng", "type": "messaging", "nodes": []},
+			{"uuid": "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", "name": "Flow 2", "spec_version": "13.1.0", "language": "eng", "type": "messaging", "nodes": []},
+			{"uuid": "0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f", "name": "Flow 3", "spec_version": "13.1.0", "language": "eng", "type": "voice", "nodes": []},
+			{"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507", "name": "FLOW 2", "spec_version": "13.1.0", "language": "eng", "type": "messaging", "nodes": []}
+		]
+	}`))
+	require.NoError(t, err)
+
+	fa := definition.NewFlowAssets(source, nil)
+
+	assert.Equal(t, []assets.FlowUUID{
+		"76f0a02f-3b75-4b86-9064-e9195e1b3a02",
+		"b7cf0d83-f1c9-411c-96fd-c511a4cfa86d",
+		"0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f",
+		"a58be63b-907d-4a1a-856b-0bb5579d7507",
+	}, fa.AllUUIDs())
+
+	flow, err := fa.Get("b7cf0d83-f1c9-411c-96fd-c511a4cfa86d")
+	assert.NoError(t, err)
+	assert.Equal(t, "Flow 2", flow.Name())
+
+	_, err = fa.Get("ddba5842-252f-4a20-b901-08696fc773e2")
+	assert.EqualError(t, err, "no such flow with UUID 'ddba5842-252f-4a20-b901-08696fc773e2'")
+
+	// flow assets can also look up flows by name
+	named, isNamed := fa.(flows.NamedFlowAssets)
+	require.True(t, isNamed)
+
+	// exact name match
+	flow, err = named.GetByName("Flow 3")
+	assert.NoError(t, err)
+	assert.Equal(t, assets.FlowUUID("0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f"), flow.UUID())
+
+	// case-insensitive match
+	flow, err = named.GetByName("flow 1")
+	assert.NoError(t, err)
+	assert.Equal(t, assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"), flow.UUID())
+
+	// first match wins if names are duplicated
+	flow, err = named.GetByName("Flow 2")
+	assert.NoError(t, err)
+	assert.Equal(t, assets.FlowUUID("b7cf0d83-f1c9-411c-96fd-c511a4cfa86d"), flow.UUID())
+
+	// no match
+	_, err = named.GetByName("Flow 4")
+	assert.EqualError(t, err, "name 'Flow 4': flow not found")
+	assert.Equal(t, flows.ErrFlowNotFound, errors.Cause(err))
+
+	_, err = named.GetByName("")
+	assert.Equal(t, flows.ErrFlowNotFound, errors.Cause(err))
+}
@@ -2,6 +2,7 @@ package definition
 
 import (
 	"encoding/json"
+	"reflect"
 
 	"github.com/nyaruka/gocommon/jsonx"
 	"github.com/nyaruka/gocommon/uuids"
@@ -145,7 +146,7 @@ func (f *flow) Reference() *assets.FlowReference {
 // ExtractTemplates extracts all non-empty templates
 func (f *flow) ExtractTemplates() []string {
 	templates := make([]string, 0)
-	include := func(a flows.Action, r flows.Router, l envs.Language, t string) {
+	include := func(a flows.Action, r flows.Router, f string, l envs.Language, t string) {
 		if t != "" {
 			templates = append(templates, t)
 		}
@@ -246,8 +247,8 @@ func (f *flow) extract() ([]flows.ExtractedTemplate, []flows.ExtractedReference,
 	}
 
 	for _, n := range f.nodes {
-		n.EnumerateTemplates(f.Localization(), func(a flows.Action, r flows.Router, l envs.Language, t string) {
-			templates = append(templates, flows.NewExtractedTemplate(n, a, r, l, t))
+		n.EnumerateTemplates(f.Localization(), func(a flows.Action, r flows.Router, fp string, l envs.Language, t string) {
+			templates = append(templates, flows.NewExtractedTemplate(n, a, r, fp, l, t))
 			ars, prs := inspect.ExtractFromTemplate(t)
 			for _, ref := range ars {
 				recordAssetRef(n, a, r, l, ref)
@@ -310,6 +311,15 @@ type flowEnvelope struct {
 	UI                 json.RawMessage `json:"_ui,omitempty"`
 }
 
+// EnvelopeTypes returns the types which flows, nodes and exits are read from, e.g. for generating a schema
+func EnvelopeTypes() map[string]reflect.Type {
+	return map[string]reflect.Type{
+		"flow": reflect.TypeOf(flowEnvelope{}),
+		"node": reflect.TypeOf(nodeEnvelope{}),
+		"exit": reflect.TypeOf(exitEnvelope{}),
+	}
+}
+
 // ReadFlow a flow definition from the passed in byte array, migrating it to the spec version of the engine if necessary
 func ReadFlow(data json.RawMessage, migrationConfig *migrations.Config) (flows.Flow, error) {
 	var err error
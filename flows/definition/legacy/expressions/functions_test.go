@@ -2,7 +2,6 @@ package expressions_test
 
 import (
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/goflow/envs"
@@ -147,7 +146,7 @@ func TestMigrateFunctionCall(t *testing.T) {
 	require.NoError(t, err)
 
 	defer dates.SetNowSource(dates.DefaultNowSource)
-	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 4, 11, 13, 24, 30, 123456000, time.UTC)))
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-04-11T13:24:30.123456Z")))
 
 	for _, tc := range tests {
 		migratedTemplate, err := expressions.MigrateTemplate(tc.old, nil)
@@ -82,16 +82,16 @@ func (n *node) Validate(flow flows.Flow, seenUUIDs map[uuids.UUID]bool) error {
 }
 
 // EnumerateTemplates enumerates all expressions on this object
-func (n *node) EnumerateTemplates(localization flows.Localization, include func(flows.Action, flows.Router, envs.Language, string)) {
+func (n *node) EnumerateTemplates(localization flows.Localization, include func(flows.Action, flows.Router, string, envs.Language, string)) {
 	for _, action := range n.actions {
-		inspect.Templates(action, localization, func(l envs.Language, t string) {
-			include(action, nil, l, t)
+		inspect.Templates(action, localization, func(f string, l envs.Language, t string) {
+			include(action, nil, f, l, t)
 		})
 	}
 
 	if n.router != nil {
-		n.router.EnumerateTemplates(localization, func(l envs.Language, t string) {
-			include(nil, n.router, l, t)
+		n.router.EnumerateTemplates(localization, func(f string, l envs.Language, t string) {
+			include(nil, n.router, f, l, t)
 		})
 	}
 }
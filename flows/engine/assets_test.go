@@ -15,6 +15,14 @@ import (
 )
 
 var assetsJSON = `{
+	"classifiers": [
+		{
+			"uuid": "1c06c884-39dd-4ce4-ad9f-9a01cbe6c000",
+			"name": "Booking",
+			"type": "wit",
+			"intents": ["book_flight", "book_hotel"]
+		}
+	],
 	"groups": [
 		{
 			"uuid": "2aad21f6-30b7-42c5-bd7f-1b720c154817",
@@ -48,6 +56,15 @@ func TestSessionAssets(t *testing.T) {
 
 	assert.Equal(t, source, sa.Source())
 
+	classifier := sa.Classifiers().Get(assets.ClassifierUUID("1c06c884-39dd-4ce4-ad9f-9a01cbe6c000"))
+	assert.Equal(t, assets.ClassifierUUID("1c06c884-39dd-4ce4-ad9f-9a01cbe6c000"), classifier.UUID())
+	assert.Equal(t, "Booking", classifier.Name())
+	assert.Equal(t, []string{"book_flight", "book_hotel"}, classifier.Intents())
+	assert.Equal(t, classifier, sa.Classifiers().GetByName("booking"))
+
+	assert.Nil(t, sa.Classifiers().Get(assets.ClassifierUUID("xyz")))
+	assert.Nil(t, sa.Classifiers().GetByName("xyz"))
+
 	label := sa.Labels().Get(assets.LabelUUID("18644b27-fb7f-40e1-b8f4-4ea8999129ef"))
 	assert.Equal(t, assets.LabelUUID("18644b27-fb7f-40e1-b8f4-4ea8999129ef"), label.UUID())
 	assert.Equal(t, "Spam", label.Name())
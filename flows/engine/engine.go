@@ -1,18 +1,36 @@
 package engine
 
 import (
+	"bytes"
 	"encoding/json"
+	"time"
 
+	"github.com/nyaruka/gocommon/jsonx"
 	"github.com/nyaruka/gocommon/uuids"
 	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/triggers"
+
+	"github.com/pkg/errors"
 )
 
+// EventPostProcessor is a function which can replace an event in a sprint, or suppress it by returning nil. Runs always
+// log the original event so processors should return a new event rather than modifying the one they are given. Note
+// that this means it isn't a redaction mechanism - the original event and the state it describes remain in the
+// session JSON.
+type EventPostProcessor func(flows.Event) flows.Event
+
 // an instance of the engine
 type engine struct {
-	services          *services
-	maxStepsPerSprint int
-	maxTemplateChars  int
+	services           *services
+	eventPostProcessor EventPostProcessor
+	maxStepsPerSprint  int
+	maxTemplateChars   int
+	maxBodyBytes       int
+	fullWebhookBodies  bool
+	exprCacheSize      int
+	httpTimeouts       map[string]time.Duration
 }
 
 // NewSession creates a new session
@@ -25,6 +43,8 @@ func (e *engine) NewSession(sa flows.SessionAssets, trigger flows.Trigger) (flow
 		status:     flows.SessionStatusActive,
 		batchStart: trigger.Batch(),
 		runsByUUID: make(map[flows.RunUUID]flows.FlowRun),
+		services:   make(map[string]interface{}),
+		exprCache:  flows.NewExpressionCache(e.exprCacheSize),
 	}
 
 	sprint, err := s.start(trigger)
@@ -32,14 +52,53 @@ func (e *engine) NewSession(sa flows.SessionAssets, trigger flows.Trigger) (flow
 	return s, sprint, err
 }
 
+// NewSessionFromContact is a convenience function which starts a new session for the given contact in the given
+// flow using a manual trigger
+func NewSessionFromContact(sa flows.SessionAssets, contact *flows.Contact, flowRef *assets.FlowReference, env envs.Environment, e flows.Engine) (flows.Session, flows.Sprint, error) {
+	trigger := triggers.NewBuilder(env, flowRef, contact).Manual().Build()
+
+	return e.NewSession(sa, trigger)
+}
+
 // ReadSession reads an existing session
 func (e *engine) ReadSession(sa flows.SessionAssets, data json.RawMessage, missing assets.MissingCallback) (flows.Session, error) {
 	return readSession(e, sa, data, missing)
 }
 
-func (e *engine) Services() flows.Services { return e.services }
-func (e *engine) MaxStepsPerSprint() int   { return e.maxStepsPerSprint }
-func (e *engine) MaxTemplateChars() int    { return e.maxTemplateChars }
+// CloneSession creates a copy of the given session with new session and run UUIDs which can be resumed and stored
+// independently of the original
+func (e *engine) CloneSession(s flows.Session) (flows.Session, error) {
+	data, err := jsonx.Marshal(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to marshal session")
+	}
+
+	// replace every reference to each run's UUID, e.g. parent run UUIDs, with a new UUID
+	for _, run := range s.Runs() {
+		data = bytes.ReplaceAll(data, []byte(run.UUID()), []byte(uuids.New()))
+	}
+
+	clone, err := readSession(e, s.Assets(), data, assets.IgnoreMissing)
+	if err != nil {
+		return nil, err
+	}
+
+	clone.(*session).uuid = flows.SessionUUID(uuids.New())
+	return clone, nil
+}
+
+func (e *engine) Services() flows.Services     { return e.services }
+func (e *engine) MaxStepsPerSprint() int       { return e.maxStepsPerSprint }
+func (e *engine) MaxTemplateChars() int        { return e.maxTemplateChars }
+func (e *engine) MaxBodyBytes() int            { return e.maxBodyBytes }
+func (e *engine) IncludeFullWebhookBody() bool { return e.fullWebhookBodies }
+func (e *engine) ExpressionCacheSize() int     { return e.exprCacheSize }
+
+// HTTPRequestTimeout returns the timeout for HTTP requests made by call_webhook and call_resthook actions of the given
+// type, or zero if requests are only limited by the timeout of the webhook service's HTTP client
+func (e *engine) HTTPRequestTimeout(actionType string) time.Duration {
+	return e.httpTimeouts[actionType]
+}
 
 var _ flows.Engine = (*engine)(nil)
 
@@ -59,20 +118,20 @@ func NewBuilder() *Builder {
 			services:          newEmptyServices(),
 			maxStepsPerSprint: 100,
 			maxTemplateChars:  10000,
+			maxBodyBytes:      10000,
+			httpTimeouts:      make(map[string]time.Duration),
 		},
 	}
 }
 
 // WithEmailServiceFactory sets the email service factory
 func (b *Builder) WithEmailServiceFactory(f EmailServiceFactory) *Builder {
-	b.eng.services.email = f
-	return b
+	return b.WithUncachedServiceFactory(flows.ServiceTypeEmail, func(s flows.Session) (interface{}, error) { return f(s) })
 }
 
 // WithWebhookServiceFactory sets the webhook service factory
 func (b *Builder) WithWebhookServiceFactory(f WebhookServiceFactory) *Builder {
-	b.eng.services.webhook = f
-	return b
+	return b.WithUncachedServiceFactory(flows.ServiceTypeWebhook, func(s flows.Session) (interface{}, error) { return f(s) })
 }
 
 // WithClassificationServiceFactory sets the NLU service factory
@@ -89,7 +148,29 @@ func (b *Builder) WithTicketServiceFactory(f TicketServiceFactory) *Builder {
 
 // WithAirtimeServiceFactory sets the airtime service factory
 func (b *Builder) WithAirtimeServiceFactory(f AirtimeServiceFactory) *Builder {
-	b.eng.services.airtime = f
+	return b.WithUncachedServiceFactory(flows.ServiceTypeAirtime, func(s flows.Session) (interface{}, error) { return f(s) })
+}
+
+// WithServiceFactory sets the factory for the given service type. The service is constructed on first use
+// within a session and then reused for the rest of that session.
+func (b *Builder) WithServiceFactory(serviceType string, f ServiceFactory) *Builder {
+	b.eng.services.byType[serviceType] = f
+	b.eng.services.cached[serviceType] = true
+	return b
+}
+
+// WithUncachedServiceFactory sets the factory for the given service type. The factory is called each time the
+// service is used.
+func (b *Builder) WithUncachedServiceFactory(serviceType string, f ServiceFactory) *Builder {
+	b.eng.services.byType[serviceType] = f
+	delete(b.eng.services.cached, serviceType)
+	return b
+}
+
+// WithEventPostProcessor sets a function which will be passed each event before it is added to the sprint. It doesn't
+// change the events logged to runs, so can't be used to remove data from sessions.
+func (b *Builder) WithEventPostProcessor(p EventPostProcessor) *Builder {
+	b.eng.eventPostProcessor = p
 	return b
 }
 
@@ -105,5 +186,33 @@ func (b *Builder) WithMaxTemplateChars(max int) *Builder {
 	return b
 }
 
+// WithMaxBodyBytes sets the maximum number of bytes of request and response bodies recorded in HTTP logs. Zero means
+// bodies aren't truncated.
+func (b *Builder) WithMaxBodyBytes(max int) *Builder {
+	b.eng.maxBodyBytes = max
+	return b
+}
+
+// WithIncludeFullWebhookBody sets whether webhook events should include full response bodies rather than previews
+func (b *Builder) WithIncludeFullWebhookBody(include bool) *Builder {
+	b.eng.fullWebhookBodies = include
+	return b
+}
+
+// WithExpressionCacheSize sets the maximum number of evaluated templates cached within each step of a session. The
+// default of zero disables caching.
+func (b *Builder) WithExpressionCacheSize(size int) *Builder {
+	b.eng.exprCacheSize = size
+	return b
+}
+
+// WithHTTPRequestTimeout sets the timeout for HTTP requests made by actions of the given type, overriding the timeout
+// of the webhook service's HTTP client if that is longer. Only call_webhook and call_resthook actions make their own
+// requests, so classifier and airtime timeouts should be set on the clients given to those service factories.
+func (b *Builder) WithHTTPRequestTimeout(actionType string, timeout time.Duration) *Builder {
+	b.eng.httpTimeouts[actionType] = timeout
+	return b
+}
+
 // Build returns the final engine
 func (b *Builder) Build() flows.Engine { return b.eng }
@@ -1,14 +1,30 @@
 package engine_test
 
 import (
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/nyaruka/gocommon/dates"
+	"github.com/nyaruka/gocommon/random"
+	"github.com/nyaruka/gocommon/urns"
+	"github.com/nyaruka/gocommon/uuids"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/engine"
+	"github.com/nyaruka/goflow/flows/events"
+	"github.com/nyaruka/goflow/flows/resumes"
+	"github.com/nyaruka/goflow/flows/triggers"
 	"github.com/nyaruka/goflow/services/webhooks"
+	"github.com/nyaruka/goflow/test"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBuilder(t *testing.T) {
@@ -16,6 +32,8 @@ func TestBuilder(t *testing.T) {
 	eng := engine.NewBuilder().WithMaxStepsPerSprint(123).Build()
 
 	assert.Equal(t, 123, eng.MaxStepsPerSprint())
+	assert.Equal(t, 10000, eng.MaxBodyBytes())
+	assert.Equal(t, 512, engine.NewBuilder().WithMaxBodyBytes(512).Build().MaxBodyBytes())
 
 	_, err := eng.Services().Email(nil)
 	assert.EqualError(t, err, "no email service factory configured")
@@ -39,3 +57,361 @@ func TestBuilder(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, webhookSvc, svc)
 }
+
+func TestEventPostProcessor(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	source, err := static.NewSource([]byte(`{
+		"flows": [
+			{
+				"uuid": "5472a1c3-63e1-484f-8485-cc8ecb16a058",
+				"name": "Support",
+				"spec_version": "13.1",
+				"language": "eng",
+				"type": "messaging",
+				"nodes": [
+					{
+						"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507",
+						"actions": [
+							{
+								"uuid": "f01d693b-2af2-49fb-9e38-146eb00937e9",
+								"type": "set_contact_name",
+								"name": "Robert"
+							},
+							{
+								"uuid": "2f0e1e7b-5c55-4b3c-bd3a-1ff7e3b2d1a4",
+								"type": "send_msg",
+								"text": "Call us on +593979123456 or 0979123456"
+							}
+						],
+						"exits": [{"uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6"}]
+					}
+				]
+			}
+		]
+	}`))
+	require.NoError(t, err)
+
+	sa, err := engine.NewSessionAssets(env, source, nil)
+	require.NoError(t, err)
+
+	phoneRegex := regexp.MustCompile(`\+?\d{7,}`)
+
+	eng := engine.NewBuilder().
+		WithEventPostProcessor(func(e flows.Event) flows.Event {
+			switch typed := e.(type) {
+			case *events.MsgCreatedEvent:
+				msg := typed.Msg
+				redacted := phoneRegex.ReplaceAllString(msg.Text(), "********")
+				return events.NewMsgCreated(flows.NewMsgOut(msg.URN(), msg.Channel(), redacted, msg.Attachments(), msg.QuickReplies(), msg.Templating(), msg.Topic()))
+			case *events.ContactNameChangedEvent:
+				return nil
+			}
+			return e
+		}).
+		Build()
+
+	flow := assets.NewFlowReference("5472a1c3-63e1-484f-8485-cc8ecb16a058", "Support")
+	contact := flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil)
+
+	session, sprint, err := eng.NewSession(sa, triggers.NewBuilder(env, flow, contact).Manual().Build())
+	require.NoError(t, err)
+
+	// contact name was still changed but the event was suppressed
+	assert.Equal(t, "Robert", session.Contact().Name())
+	require.Equal(t, 1, len(sprint.Events()))
+
+	// the replacement event still gets the step UUID
+	event := sprint.Events()[0].(*events.MsgCreatedEvent)
+	assert.Equal(t, "Call us on ******** or ********", event.Msg.Text())
+	assert.Equal(t, session.Runs()[0].Path()[0].UUID(), event.StepUUID())
+
+	// but the run's own history has the original events
+	runEvents := session.Runs()[0].Events()
+	require.Equal(t, 2, len(runEvents))
+	assert.Equal(t, events.TypeContactNameChanged, runEvents[0].Type())
+	assert.Equal(t, "Call us on +593979123456 or 0979123456", runEvents[1].(*events.MsgCreatedEvent).Msg.Text())
+}
+
+func TestEventPostProcessorSuppressingInput(t *testing.T) {
+	assetsJSON, err := ioutil.ReadFile("../../test/testdata/runner/two_questions.json")
+	require.NoError(t, err)
+
+	sa, err := test.CreateSessionAssets(assetsJSON, "")
+	require.NoError(t, err)
+
+	eng := engine.NewBuilder().
+		WithEventPostProcessor(func(e flows.Event) flows.Event {
+			if e.Type() == events.TypeMsgReceived {
+				return nil
+			}
+			return e
+		}).
+		Build()
+
+	env := envs.NewBuilder().Build()
+	flow := assets.NewFlowReference("615b8a0f-588c-4d20-a05f-363b0b4ce6f4", "Two Questions")
+	contact := flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil)
+
+	session, _, err := eng.NewSession(sa, triggers.NewBuilder(env, flow, contact).Manual().Build())
+	require.NoError(t, err)
+	require.Equal(t, flows.SessionStatusWaiting, session.Status())
+
+	msg := flows.NewMsgIn(flows.MsgUUID(uuids.New()), urns.NilURN, nil, "Red", nil)
+	sprint, err := session.Resume(resumes.NewMsg(env, session.Contact(), msg))
+	require.NoError(t, err)
+
+	// the msg_received event is suppressed from the sprint but the run still knows that it received input
+	for _, e := range sprint.Events() {
+		assert.NotEqual(t, events.TypeMsgReceived, e.Type())
+	}
+	assert.True(t, session.Runs()[0].ReceivedInput())
+}
+
+func TestEventStepUUIDs(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	source, err := static.NewSource([]byte(`{
+		"flows": [
+			{
+				"uuid": "5472a1c3-63e1-484f-8485-cc8ecb16a058",
+				"name": "Registration",
+				"spec_version": "13.1",
+				"language": "eng",
+				"type": "messaging",
+				"nodes": [
+					{
+						"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507",
+						"actions": [
+							{"uuid": "f01d693b-2af2-49fb-9e38-146eb00937e9", "type": "set_contact_name", "name": "Robert"},
+							{"uuid": "2f0e1e7b-5c55-4b3c-bd3a-1ff7e3b2d1a4", "type": "send_msg", "text": "Hi @contact.name"},
+							{"uuid": "9a43d8f5-a4d0-4d8e-9b5c-4ff0fd5be7ca", "type": "set_run_result", "name": "Registered", "value": "yes"}
+						],
+						"exits": [{"uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6", "destination_uuid": "0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f"}]
+					},
+					{
+						"uuid": "0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f",
+						"actions": [
+							{"uuid": "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", "type": "send_msg", "text": "Bye @(1 / 0)"}
+						],
+						"exits": [{"uuid": "5f4b6d8c-1e2a-4b3c-8d9e-0f1a2b3c4d5e"}]
+					}
+				]
+			}
+		]
+	}`))
+	require.NoError(t, err)
+
+	sa, err := engine.NewSessionAssets(env, source, nil)
+	require.NoError(t, err)
+
+	// record the step UUIDs that the post processor sees
+	processed := make([]flows.StepUUID, 0)
+
+	eng := engine.NewBuilder().
+		WithEventPostProcessor(func(e flows.Event) flows.Event {
+			processed = append(processed, e.StepUUID())
+			return e
+		}).
+		Build()
+
+	flow := assets.NewFlowReference("5472a1c3-63e1-484f-8485-cc8ecb16a058", "Registration")
+	contact := flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil)
+
+	session, sprint, err := eng.NewSession(sa, triggers.NewBuilder(env, flow, contact).Manual().Build())
+	require.NoError(t, err)
+
+	path := session.Runs()[0].Path()
+	require.Equal(t, 2, len(path))
+
+	// includes an error event from the failed expression in the second node
+	assert.Equal(t, []string{"contact_name_changed", "msg_created", "run_result_changed", "error", "msg_created"}, eventTypes(sprint.Events()))
+
+	for i, e := range sprint.Events() {
+		assert.NotEqual(t, flows.StepUUID(""), e.StepUUID(), "missing step UUID on event %d", i)
+		assert.Equal(t, e.StepUUID(), processed[i])
+	}
+	assert.Equal(t, path[0].UUID(), sprint.Events()[0].StepUUID())
+	assert.Equal(t, path[1].UUID(), sprint.Events()[4].StepUUID())
+}
+
+func eventTypes(es []flows.Event) []string {
+	types := make([]string, len(es))
+	for i, e := range es {
+		types[i] = e.Type()
+	}
+	return types
+}
+
+func TestNewSessionFromContact(t *testing.T) {
+	env := envs.NewBuilder().Build()
+
+	sa, err := test.LoadSessionAssets(env, "../../test/testdata/runner/two_questions.json")
+	require.NoError(t, err)
+
+	contact := flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil)
+	flow := assets.NewFlowReference("615b8a0f-588c-4d20-a05f-363b0b4ce6f4", "Two Questions")
+
+	session, sprint, err := engine.NewSessionFromContact(sa, contact, flow, env, engine.NewBuilder().Build())
+	require.NoError(t, err)
+
+	assert.Equal(t, flows.SessionStatusWaiting, session.Status())
+	assert.Equal(t, contact, session.Contact())
+	assert.Equal(t, triggers.TypeManual, session.Trigger().Type())
+	assert.True(t, len(sprint.Events()) > 0)
+	assert.Equal(t, events.TypeMsgWait, sprint.Events()[len(sprint.Events())-1].Type())
+}
+
+func TestExpressionCache(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	source, err := static.NewSource([]byte(`{
+		"flows": [
+			{
+				"uuid": "5472a1c3-63e1-484f-8485-cc8ecb16a058",
+				"name": "Registration",
+				"spec_version": "13.1",
+				"language": "eng",
+				"type": "messaging",
+				"nodes": [
+					{
+						"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507",
+						"actions": [
+							{"uuid": "2f0e1e7b-5c55-4b3c-bd3a-1ff7e3b2d1a4", "type": "send_msg", "text": "Hi @contact.name", "quick_replies": ["@contact.name", "@contact.name", "@contact.name"]},
+							{"uuid": "f01d693b-2af2-49fb-9e38-146eb00937e9", "type": "set_contact_name", "name": "Robert"},
+							{"uuid": "9a43d8f5-a4d0-4d8e-9b5c-4ff0fd5be7ca", "type": "send_msg", "text": "Hi @contact.name"}
+						],
+						"exits": [{"uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6", "destination_uuid": "0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f"}]
+					},
+					{
+						"uuid": "0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f",
+						"actions": [
+							{"uuid": "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", "type": "send_msg", "text": "Your node is @node.uuid"}
+						],
+						"exits": [{"uuid": "5f4b6d8c-1e2a-4b3c-8d9e-0f1a2b3c4d5e"}]
+					}
+				]
+			}
+		]
+	}`))
+	require.NoError(t, err)
+
+	sa, err := engine.NewSessionAssets(env, source, nil)
+	require.NoError(t, err)
+
+	flow := assets.NewFlowReference("5472a1c3-63e1-484f-8485-cc8ecb16a058", "Registration")
+
+	msgTexts := func(es []flows.Event) []string {
+		texts := make([]string, 0)
+		for _, e := range es {
+			if typed, isMsg := e.(*events.MsgCreatedEvent); isMsg {
+				texts = append(texts, typed.Msg.Text())
+			}
+		}
+		return texts
+	}
+
+	// by default there is no cache
+	contact := flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil)
+	session, sprint, err := engine.NewBuilder().Build().NewSession(sa, triggers.NewBuilder(env, flow, contact).Manual().Build())
+	require.NoError(t, err)
+	assert.Nil(t, session.ExpressionCache())
+
+	uncachedTexts := msgTexts(sprint.Events())
+
+	contact = flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil)
+	eng := engine.NewBuilder().WithExpressionCacheSize(10).Build()
+	assert.Equal(t, 10, eng.ExpressionCacheSize())
+
+	session, sprint, err = eng.NewSession(sa, triggers.NewBuilder(env, flow, contact).Manual().Build())
+	require.NoError(t, err)
+
+	// evaluations after the name change and on the second node aren't stale
+	cachedTexts := msgTexts(sprint.Events())
+	assert.Equal(t, []string{"Hi Bob", "Hi Robert", "Your node is 0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f"}, cachedTexts)
+	assert.Equal(t, uncachedTexts, cachedTexts)
+
+	// two of the quick replies were served from the cache, and everything else (including the new contact name) was
+	// evaluated
+	msg := sprint.Events()[0].(*events.MsgCreatedEvent).Msg
+	assert.Equal(t, []string{"Bob", "Bob", "Bob"}, msg.QuickReplies())
+
+	cache := session.ExpressionCache()
+	assert.Equal(t, 2, cache.Hits())
+	assert.Equal(t, 5, cache.Misses())
+
+	// templates which call non-deterministic functions aren't cached
+	defer random.SetGenerator(random.DefaultGenerator)
+	defer dates.SetNowSource(dates.DefaultNowSource)
+
+	random.SetGenerator(random.NewSeededGenerator(123456))
+	dates.SetNowSource(dates.NewSequentialNowSource(test.MustParseTime("2018-10-18T14:20:30.000123456Z")))
+
+	run := session.Runs()[0]
+	for _, tpl := range []string{"@(rand())", "@(rand_between(1, 1000000))", "@(now())", "@(NOW ())"} {
+		eval1, err := run.EvaluateTemplate(tpl)
+		require.NoError(t, err)
+		eval2, err := run.EvaluateTemplate(tpl)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, eval1, eval2, "expected different values for %s", tpl)
+	}
+	assert.Equal(t, 2, cache.Hits())
+	assert.Equal(t, 5, cache.Misses())
+}
+
+func BenchmarkExpressionCache(b *testing.B) {
+	quickReplies := make([]string, 50)
+	for i := range quickReplies {
+		quickReplies[i] = `"@contact.name"`
+	}
+
+	env := envs.NewBuilder().Build()
+	source, err := static.NewSource([]byte(`{
+		"flows": [
+			{
+				"uuid": "5472a1c3-63e1-484f-8485-cc8ecb16a058",
+				"name": "Repetitive",
+				"spec_version": "13.1",
+				"language": "eng",
+				"type": "messaging",
+				"nodes": [
+					{
+						"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507",
+						"actions": [
+							{"uuid": "2f0e1e7b-5c55-4b3c-bd3a-1ff7e3b2d1a4", "type": "send_msg", "text": "Hi", "quick_replies": [` + strings.Join(quickReplies, ",") + `]}
+						],
+						"exits": [{"uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6"}]
+					}
+				]
+			}
+		]
+	}`))
+	require.NoError(b, err)
+
+	sa, err := engine.NewSessionAssets(env, source, nil)
+	require.NoError(b, err)
+
+	flow := assets.NewFlowReference("5472a1c3-63e1-484f-8485-cc8ecb16a058", "Repetitive")
+
+	for _, size := range []int{0, 100} {
+		eng := engine.NewBuilder().WithExpressionCacheSize(size).Build()
+
+		b.Run(fmt.Sprintf("cache_size_%d", size), func(b *testing.B) {
+			lookups, evaluations := 0, 0
+
+			for n := 0; n < b.N; n++ {
+				contact := flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil)
+				session, _, _ := eng.NewSession(sa, triggers.NewBuilder(env, flow, contact).Manual().Build())
+
+				if cache := session.ExpressionCache(); cache != nil {
+					lookups += cache.Hits() + cache.Misses()
+					evaluations += cache.Misses()
+				} else {
+					lookups += len(quickReplies) + 1
+					evaluations += len(quickReplies) + 1
+				}
+			}
+
+			b.ReportMetric(float64(evaluations)/float64(b.N), "evals/op")
+			b.ReportMetric(float64(lookups-evaluations)/float64(b.N), "cached/op")
+		})
+	}
+}
@@ -21,40 +21,77 @@ type TicketServiceFactory func(flows.Session, *flows.Ticketer) (flows.TicketServ
 // AirtimeServiceFactory resolves a session to an airtime service
 type AirtimeServiceFactory func(flows.Session) (flows.AirtimeService, error)
 
+// ServiceFactory resolves a session to a service of the given type
+type ServiceFactory func(flows.Session) (interface{}, error)
+
+// classification and ticket services are resolved per classifier and ticketer rather than just per session, so they
+// keep their own factories rather than being resolved by type
 type services struct {
-	email          EmailServiceFactory
-	webhook        WebhookServiceFactory
+	byType         map[string]ServiceFactory
+	cached         map[string]bool
 	classification ClassificationServiceFactory
 	ticket         TicketServiceFactory
-	airtime        AirtimeServiceFactory
 }
 
 func newEmptyServices() *services {
 	return &services{
-		email: func(flows.Session) (flows.EmailService, error) {
-			return nil, errors.New("no email service factory configured")
-		},
-		webhook: func(flows.Session) (flows.WebhookService, error) {
-			return nil, errors.New("no webhook service factory configured")
-		},
+		byType: make(map[string]ServiceFactory),
+		cached: make(map[string]bool),
 		classification: func(flows.Session, *flows.Classifier) (flows.ClassificationService, error) {
 			return nil, errors.New("no classification service factory configured")
 		},
 		ticket: func(flows.Session, *flows.Ticketer) (flows.TicketService, error) {
 			return nil, errors.New("no ticket service factory configured")
 		},
-		airtime: func(flows.Session) (flows.AirtimeService, error) {
-			return nil, errors.New("no airtime service factory configured")
-		},
 	}
 }
 
+func (s *services) Service(sess flows.Session, serviceType string) (interface{}, error) {
+	factory := s.byType[serviceType]
+	if factory == nil {
+		return nil, errors.Errorf("no %s service factory configured", serviceType)
+	}
+
+	// cached services are constructed on first use and then reused for the rest of the session
+	engineSession, isEngineSession := sess.(*session)
+	if !s.cached[serviceType] || !isEngineSession {
+		return factory(sess)
+	}
+	if svc, cached := engineSession.services[serviceType]; cached {
+		return svc, nil
+	}
+
+	svc, err := factory(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	engineSession.services[serviceType] = svc
+	return svc, nil
+}
+
 func (s *services) Email(session flows.Session) (flows.EmailService, error) {
-	return s.email(session)
+	svc, err := s.Service(session, flows.ServiceTypeEmail)
+	if err != nil {
+		return nil, err
+	}
+	email, isEmail := svc.(flows.EmailService)
+	if !isEmail {
+		return nil, errors.Errorf("%s service factory returned an invalid service", flows.ServiceTypeEmail)
+	}
+	return email, nil
 }
 
 func (s *services) Webhook(session flows.Session) (flows.WebhookService, error) {
-	return s.webhook(session)
+	svc, err := s.Service(session, flows.ServiceTypeWebhook)
+	if err != nil {
+		return nil, err
+	}
+	webhook, isWebhook := svc.(flows.WebhookService)
+	if !isWebhook {
+		return nil, errors.Errorf("%s service factory returned an invalid service", flows.ServiceTypeWebhook)
+	}
+	return webhook, nil
 }
 
 func (s *services) Classification(session flows.Session, classifier *flows.Classifier) (flows.ClassificationService, error) {
@@ -66,5 +103,13 @@ func (s *services) Ticket(session flows.Session, ticketer *flows.Ticketer) (flow
 }
 
 func (s *services) Airtime(session flows.Session) (flows.AirtimeService, error) {
-	return s.airtime(session)
+	svc, err := s.Service(session, flows.ServiceTypeAirtime)
+	if err != nil {
+		return nil, err
+	}
+	airtime, isAirtime := svc.(flows.AirtimeService)
+	if !isAirtime {
+		return nil, errors.Errorf("%s service factory returned an invalid service", flows.ServiceTypeAirtime)
+	}
+	return airtime, nil
 }
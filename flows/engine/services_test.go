@@ -1,10 +1,20 @@
 package engine_test
 
 import (
+	"net/http"
 	"testing"
 
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/engine"
+	"github.com/nyaruka/goflow/flows/triggers"
+	"github.com/nyaruka/goflow/services/webhooks"
+	"github.com/nyaruka/goflow/test"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEmptyServices(t *testing.T) {
@@ -22,4 +32,159 @@ func TestEmptyServices(t *testing.T) {
 	airtimeSvc, err := eng.Services().Airtime(nil)
 	assert.EqualError(t, err, "no airtime service factory configured")
 	assert.Nil(t, airtimeSvc)
+
+	customSvc, err := eng.Services().Service(nil, "llm")
+	assert.EqualError(t, err, "no llm service factory configured")
+	assert.Nil(t, customSvc)
+}
+
+type llmService struct {
+	id int
+}
+
+func TestCustomServices(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	source, err := static.NewSource([]byte(`{
+		"flows": [
+			{
+				"uuid": "5472a1c3-63e1-484f-8485-cc8ecb16a058",
+				"name": "Empty",
+				"spec_version": "13.1",
+				"language": "eng",
+				"type": "messaging",
+				"nodes": []
+			}
+		]
+	}`))
+	require.NoError(t, err)
+
+	sa, err := engine.NewSessionAssets(env, source, nil)
+	require.NoError(t, err)
+
+	factoryCalls := 0
+	translationCalls := 0
+	eng := engine.NewBuilder().
+		WithServiceFactory("llm", func(flows.Session) (interface{}, error) {
+			factoryCalls++
+			return &llmService{id: factoryCalls}, nil
+		}).
+		WithUncachedServiceFactory("translation", func(flows.Session) (interface{}, error) {
+			translationCalls++
+			return &llmService{id: translationCalls}, nil
+		}).
+		Build()
+
+	flow := assets.NewFlowReference("5472a1c3-63e1-484f-8485-cc8ecb16a058", "Empty")
+	contact := flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil)
+
+	session1, _, err := eng.NewSession(sa, triggers.NewBuilder(env, flow, contact).Manual().Build())
+	require.NoError(t, err)
+
+	// factory isn't called until the service is used
+	assert.Equal(t, 0, factoryCalls)
+
+	svc1, err := eng.Services().Service(session1, "llm")
+	require.NoError(t, err)
+	assert.Equal(t, 1, svc1.(*llmService).id)
+
+	// subsequent uses in the same session reuse the same service
+	svc2, err := eng.Services().Service(session1, "llm")
+	require.NoError(t, err)
+	assert.Same(t, svc1, svc2)
+	assert.Equal(t, 1, factoryCalls)
+
+	// but another session gets its own service
+	session2, _, err := eng.NewSession(sa, triggers.NewBuilder(env, flow, contact).Manual().Build())
+	require.NoError(t, err)
+
+	svc3, err := eng.Services().Service(session2, "llm")
+	require.NoError(t, err)
+	assert.Equal(t, 2, svc3.(*llmService).id)
+	assert.Equal(t, 2, factoryCalls)
+
+	// services which aren't cached are constructed on every use
+	svc4, err := eng.Services().Service(session1, "translation")
+	require.NoError(t, err)
+	svc5, err := eng.Services().Service(session1, "translation")
+	require.NoError(t, err)
+	assert.NotSame(t, svc4, svc5)
+	assert.Equal(t, 2, translationCalls)
+
+	_, err = eng.Services().Service(session1, "summary")
+	assert.EqualError(t, err, "no summary service factory configured")
+}
+
+func TestInvalidServices(t *testing.T) {
+	// factories which return nothing or the wrong type of service are errors rather than panics
+	eng := engine.NewBuilder().
+		WithServiceFactory(flows.ServiceTypeEmail, func(flows.Session) (interface{}, error) { return nil, nil }).
+		WithServiceFactory(flows.ServiceTypeWebhook, func(flows.Session) (interface{}, error) { return &llmService{}, nil }).
+		WithServiceFactory(flows.ServiceTypeAirtime, func(flows.Session) (interface{}, error) { return &emailService{}, nil }).
+		Build()
+
+	_, err := eng.Services().Email(nil)
+	assert.EqualError(t, err, "email service factory returned an invalid service")
+
+	_, err = eng.Services().Webhook(nil)
+	assert.EqualError(t, err, "webhook service factory returned an invalid service")
+
+	_, err = eng.Services().Airtime(nil)
+	assert.EqualError(t, err, "airtime service factory returned an invalid service")
+}
+
+type emailService struct{}
+
+func (s *emailService) Send(session flows.Session, addresses []string, subject, body string) error {
+	return nil
+}
+
+func TestServices(t *testing.T) {
+	emailSvc := &emailService{}
+	webhookSvc := webhooks.NewService(&http.Client{}, nil, nil, map[string]string{"User-Agent": "goflow"}, 1000)
+	classificationSvc := test.NewEngine().Services()
+	ticketSvc := test.NewTicketService(nil)
+
+	eng := engine.NewBuilder().
+		WithEmailServiceFactory(func(flows.Session) (flows.EmailService, error) { return emailSvc, nil }).
+		WithWebhookServiceFactory(func(flows.Session) (flows.WebhookService, error) { return webhookSvc, nil }).
+		WithClassificationServiceFactory(func(s flows.Session, c *flows.Classifier) (flows.ClassificationService, error) {
+			return classificationSvc.Classification(s, c)
+		}).
+		WithTicketServiceFactory(func(flows.Session, *flows.Ticketer) (flows.TicketService, error) { return ticketSvc, nil }).
+		Build()
+
+	// email service can be resolved by its typed method or by type
+	email, err := eng.Services().Email(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, emailSvc, email)
+
+	svc, err := eng.Services().Service(nil, flows.ServiceTypeEmail)
+	assert.NoError(t, err)
+	assert.Equal(t, emailSvc, svc)
+
+	// as can the webhook service
+	webhook, err := eng.Services().Webhook(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, webhookSvc, webhook)
+
+	svc, err = eng.Services().Service(nil, flows.ServiceTypeWebhook)
+	assert.NoError(t, err)
+	assert.Equal(t, webhookSvc, svc)
+
+	// classification and ticket services are resolved per classifier and ticketer
+	classifier := test.NewClassifier("Booking", "wit", []string{"book_flight"})
+	classification, err := eng.Services().Classification(nil, classifier)
+	assert.NoError(t, err)
+	assert.NotNil(t, classification)
+
+	ticket, err := eng.Services().Ticket(nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, ticketSvc, ticket)
+
+	// airtime service isn't configured
+	_, err = eng.Services().Airtime(nil)
+	assert.EqualError(t, err, "no airtime service factory configured")
+
+	_, err = eng.Services().Service(nil, flows.ServiceTypeAirtime)
+	assert.EqualError(t, err, "no airtime service factory configured")
 }
@@ -46,6 +46,8 @@ type session struct {
 	runsByUUID map[flows.RunUUID]flows.FlowRun
 	pushedFlow *pushedFlow
 	parentRun  flows.RunSummary
+	services   map[string]interface{}
+	exprCache  *flows.ExpressionCache
 
 	engine flows.Engine
 }
@@ -59,15 +61,22 @@ func (s *session) UUID() flows.SessionUUID { return s.uuid }
 func (s *session) Type() flows.FlowType         { return s.type_ }
 func (s *session) SetType(type_ flows.FlowType) { s.type_ = type_ }
 
-func (s *session) Environment() envs.Environment       { return s.env }
-func (s *session) SetEnvironment(env envs.Environment) { s.env = env }
+func (s *session) Environment() envs.Environment { return s.env }
+func (s *session) SetEnvironment(env envs.Environment) {
+	s.env = env
+	s.exprCache.Clear()
+}
 
-func (s *session) Contact() *flows.Contact           { return s.contact }
-func (s *session) SetContact(contact *flows.Contact) { s.contact = contact }
+func (s *session) Contact() *flows.Contact { return s.contact }
+func (s *session) SetContact(contact *flows.Contact) {
+	s.contact = contact
+	s.exprCache.Clear()
+}
 
 func (s *session) Input() flows.Input { return s.input }
 func (s *session) SetInput(input flows.Input) {
 	s.input = input
+	s.exprCache.Clear()
 
 	// if we have a contact, update their last seen date
 	if input != nil && s.contact != nil {
@@ -77,6 +86,8 @@ func (s *session) SetInput(input flows.Input) {
 
 func (s *session) BatchStart() bool { return s.batchStart }
 
+func (s *session) ExpressionCache() *flows.ExpressionCache { return s.exprCache }
+
 func (s *session) PushFlow(flow flows.Flow, parentRun flows.FlowRun, terminal bool) {
 	s.pushedFlow = &pushedFlow{flow: flow, parentRun: parentRun, terminal: terminal}
 }
@@ -114,15 +125,27 @@ func (s *session) Status() flows.SessionStatus { return s.status }
 func (s *session) Wait() flows.ActivatedWait   { return s.wait }
 
 func (s *session) CurrentContext() *types.XObject {
-	run := s.currentRun()
+	run := s.lastModifiedRun()
 	if run == nil {
 		return nil
 	}
 	return types.NewXObject(run.RootContext(s.env))
 }
 
+// CurrentRun gets the topmost run on the run stack, i.e. the active or waiting run of the deepest flow, or nil if
+// the session has ended
+func (s *session) CurrentRun() flows.FlowRun {
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		status := s.runs[i].Status()
+		if status == flows.RunStatusActive || status == flows.RunStatusWaiting {
+			return s.runs[i]
+		}
+	}
+	return nil
+}
+
 // looks through this session's run for the one that was last modified
-func (s *session) currentRun() flows.FlowRun {
+func (s *session) lastModifiedRun() flows.FlowRun {
 	var lastRun flows.FlowRun
 	for _, run := range s.runs {
 		if lastRun == nil || run.ModifiedOn().After(lastRun.ModifiedOn()) {
@@ -150,6 +173,11 @@ func (s *session) History() *flows.SessionHistory {
 	return flows.EmptyHistory
 }
 
+// FlowHistory gets the ordered history of flows entered during this session, including those which have been exited
+func (s *session) FlowHistory() []*flows.FlowHistoryEntry {
+	return flows.NewFlowHistory(s.runs)
+}
+
 func (s *session) Engine() flows.Engine { return s.engine }
 
 //------------------------------------------------------------------------------------------
@@ -158,18 +186,19 @@ func (s *session) Engine() flows.Engine { return s.engine }
 
 // Start initializes this session with the given trigger and runs the flow to the first wait
 func (s *session) start(trigger flows.Trigger) (flows.Sprint, error) {
-	sprint := NewEmptySprint()
+	sprint := newTimedSprint()
+	defer sprint.end()
 
 	if err := s.prepareForSprint(); err != nil {
 		return sprint, err
 	}
 
-	if err := s.trigger.Initialize(s, sprint.LogEvent); err != nil {
+	if err := s.trigger.Initialize(s, s.eventSink(sprint, nil, nil)); err != nil {
 		return sprint, err
 	}
 
 	// ensure groups are correct
-	s.ensureQueryBasedGroups(sprint.LogEvent)
+	s.ensureQueryBasedGroups(s.eventSink(sprint, nil, nil))
 
 	// off to the races...
 	if err := s.continueUntilWait(sprint, nil, noDestination, nil, trigger); err != nil {
@@ -181,12 +210,16 @@ func (s *session) start(trigger flows.Trigger) (flows.Sprint, error) {
 
 // Resume tries to resume a waiting session
 func (s *session) Resume(resume flows.Resume) (flows.Sprint, error) {
-	sprint := NewEmptySprint()
+	sprint := newTimedSprint()
+	defer sprint.end()
 
 	if err := s.prepareForSprint(); err != nil {
 		return sprint, err
 	}
 
+	if s.status == flows.SessionStatusInterrupted {
+		return sprint, flows.ErrSessionInterrupted
+	}
 	if s.status != flows.SessionStatusWaiting {
 		return sprint, errors.Errorf("only waiting sessions can be resumed")
 	}
@@ -206,6 +239,35 @@ func (s *session) Resume(resume flows.Resume) (flows.Sprint, error) {
 	return sprint, nil
 }
 
+// Interrupt ends a waiting session, logging an event with the given reason on the waiting run and ending all runs which
+// haven't already ended. Interrupted sessions can't be resumed.
+func (s *session) Interrupt(reason string) (flows.Sprint, error) {
+	sprint := newTimedSprint()
+	defer sprint.end()
+
+	if s.status != flows.SessionStatusWaiting {
+		return sprint, errors.Errorf("only waiting sessions can be interrupted")
+	}
+
+	if waitingRun := s.waitingRun(); waitingRun != nil {
+		var step flows.Step
+		if path := waitingRun.Path(); len(path) > 0 {
+			step = path[len(path)-1]
+		}
+		s.eventSink(sprint, waitingRun, step).Add(events.NewSessionInterrupted(reason))
+	}
+
+	for _, run := range s.runs {
+		if run.Status() == flows.RunStatusActive || run.Status() == flows.RunStatusWaiting {
+			run.Exit(flows.RunStatusInterrupted)
+		}
+	}
+
+	s.wait = nil
+	s.status = flows.SessionStatusInterrupted
+	return sprint, nil
+}
+
 // prepares the session for starting/resuming
 func (s *session) prepareForSprint() error {
 	if s.parentRun == nil {
@@ -241,17 +303,14 @@ func (s *session) tryToResume(sprint flows.Sprint, waitingRun flows.FlowRun, res
 
 	// try to end our wait which will return and log an error if it can't be ended with this resume
 	if err := node.Router().Wait().End(resume); err != nil {
-		sprint.LogEvent(events.NewError(err))
+		s.eventSink(sprint, nil, nil).Add(events.NewError(err))
 		return nil
 	}
 	s.wait = nil
 	s.status = flows.SessionStatusActive
 	s.currentResume = resume
 
-	logEvent := func(e flows.Event) {
-		waitingRun.LogEvent(step, e)
-		sprint.LogEvent(e)
-	}
+	logEvent := s.eventSink(sprint, waitingRun, step)
 
 	// resumes are allowed to make state changes
 	resume.Apply(waitingRun, logEvent)
@@ -281,10 +340,7 @@ func (s *session) findResumeDestination(sprint flows.Sprint, run flows.FlowRun,
 	if err != nil {
 		return noDestination, err
 	}
-	logEvent := func(e flows.Event) {
-		run.LogEvent(step, e)
-		sprint.LogEvent(e)
-	}
+	logEvent := s.eventSink(sprint, run, step)
 
 	// see if this node can now pick a destination
 	destination, err := s.pickNodeExit(sprint, run, node, step, isTimeout, logEvent)
@@ -401,10 +457,7 @@ func (s *session) continueUntilWait(sprint flows.Sprint, currentRun flows.FlowRu
 // visits the given node, creating a step in our current run path
 func (s *session) visitNode(sprint flows.Sprint, run flows.FlowRun, node flows.Node, trigger flows.Trigger) (flows.Step, flows.NodeUUID, error) {
 	step := run.CreateStep(node)
-	logEvent := func(e flows.Event) {
-		run.LogEvent(step, e)
-		sprint.LogEvent(e)
-	}
+	logEvent := s.eventSink(sprint, run, step)
 
 	// this might be the first run of the session in which case a trigger might need to initialize the run
 	if trigger != nil {
@@ -459,7 +512,7 @@ func (s *session) visitNode(sprint flows.Sprint, run flows.FlowRun, node flows.N
 }
 
 // picks the exit to use on the given node
-func (s *session) pickNodeExit(sprint flows.Sprint, run flows.FlowRun, node flows.Node, step flows.Step, isTimeout bool, logEvent flows.EventCallback) (flows.NodeUUID, error) {
+func (s *session) pickNodeExit(sprint flows.Sprint, run flows.FlowRun, node flows.Node, step flows.Step, isTimeout bool, logEvent flows.EventSink) (flows.NodeUUID, error) {
 	var exitUUID flows.ExitUUID
 	var err error
 
@@ -497,7 +550,7 @@ func (s *session) pickNodeExit(sprint flows.Sprint, run flows.FlowRun, node flow
 }
 
 // ensures that our session contact is in the correct query based groups as as far as the engine is concerned
-func (s *session) ensureQueryBasedGroups(logEvent flows.EventCallback) {
+func (s *session) ensureQueryBasedGroups(logEvent flows.EventSink) {
 	if s.contact == nil {
 		return
 	}
@@ -506,15 +559,45 @@ func (s *session) ensureQueryBasedGroups(logEvent flows.EventCallback) {
 
 	// add error event for each group we couldn't re-evaluate
 	for _, err := range errors {
-		logEvent(events.NewError(err))
+		logEvent.Add(events.NewError(err))
 	}
 
 	// add groups changed event for the groups we were added/removed to/from
 	if len(added) > 0 || len(removed) > 0 {
-		logEvent(events.NewContactGroupsChanged(added, removed))
+		logEvent.Add(events.NewContactGroupsChanged(added, removed))
 	}
 }
 
+// creates an event sink which sets the current step on events, logs them to the given run if there is one, and then
+// passes them through the engine's post processor before logging them to the sprint
+func (s *session) eventSink(sprint flows.Sprint, run flows.FlowRun, step flows.Step) flows.EventSink {
+	return flows.EventCallback(func(e flows.Event) {
+		// events describe changes to session state so cached evaluations may no longer be valid
+		s.exprCache.Clear()
+
+		if step != nil {
+			e.SetStepUUID(step.UUID())
+		}
+
+		// the run's history always gets the original event
+		if run != nil {
+			run.LogEvent(step, e)
+		}
+
+		if eng, isEngine := s.engine.(*engine); isEngine && eng.eventPostProcessor != nil {
+			if e = eng.eventPostProcessor(e); e == nil {
+				return
+			}
+
+			// processors may return a new event in place of the original
+			if step != nil {
+				e.SetStepUUID(step.UUID())
+			}
+		}
+		sprint.LogEvent(e)
+	})
+}
+
 const noDestination = flows.NodeUUID("")
 
 // utility to fail the session and log a failure event
@@ -538,7 +621,7 @@ type sessionEnvelope struct {
 	Trigger     json.RawMessage     `json:"trigger" validate:"required"`
 	Contact     *json.RawMessage    `json:"contact,omitempty"`
 	Runs        []json.RawMessage   `json:"runs"`
-	Status      flows.SessionStatus `json:"status" validate:"required"`
+	Status      flows.SessionStatus `json:"status" validate:"required,session_status"`
 	Wait        json.RawMessage     `json:"wait,omitempty"`
 	Input       json.RawMessage     `json:"input,omitempty" validate:"omitempty"`
 }
@@ -559,6 +642,8 @@ func readSession(eng flows.Engine, sessionAssets flows.SessionAssets, data json.
 		type_:      e.Type,
 		status:     e.Status,
 		runsByUUID: make(map[flows.RunUUID]flows.FlowRun),
+		services:   make(map[string]interface{}),
+		exprCache:  flows.NewExpressionCache(eng.ExpressionCacheSize()),
 	}
 
 	// read our environment
@@ -608,6 +693,9 @@ func readSession(eng flows.Engine, sessionAssets flows.SessionAssets, data json.
 	if s.status == flows.SessionStatusWaiting && s.wait == nil {
 		return nil, errors.Errorf("session has status of \"waiting\" but no wait object")
 	}
+	if s.status.IsEnded() && s.wait != nil {
+		return nil, errors.Errorf("session has status of \"%s\" but has a wait object", s.status)
+	}
 
 	return s, nil
 }
@@ -2,12 +2,13 @@ package engine_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/gocommon/urns"
 	"github.com/nyaruka/gocommon/uuids"
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/assets/static"
@@ -34,7 +35,7 @@ func TestEvaluateTemplate(t *testing.T) {
 	defer dates.SetNowSource(dates.DefaultNowSource)
 
 	uuids.SetGenerator(uuids.NewSeededGenerator(123456))
-	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 4, 11, 13, 24, 30, 123456000, time.UTC)))
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-04-11T13:24:30.123456Z")))
 
 	sessionWithURNs, _, err := test.CreateTestSession(server.URL, envs.RedactionPolicyNone)
 	require.NoError(t, err)
@@ -95,6 +96,98 @@ func TestEvaluateTemplate(t *testing.T) {
 	}
 }
 
+func TestFullRedaction(t *testing.T) {
+	session, _, err := test.CreateTestSession("", envs.RedactionPolicyFull)
+	require.NoError(t, err)
+
+	run := session.Runs()[0]
+	contact := session.Contact()
+
+	for _, tpl := range []string{"@contact", "@(json(contact))", "@fields", "@(json(fields))", "@input", "@(json(input))", "@(json(run))", "@(json(parent))", "@(json(urns))"} {
+		eval, err := run.EvaluateTemplate(tpl)
+		require.NoError(t, err)
+
+		assert.NotContains(t, eval, contact.Name(), "contact name found in output of '%s'", tpl)
+
+		for _, urn := range contact.URNs() {
+			assert.NotContains(t, eval, urn.URN().Path(), "URN found in output of '%s'", tpl)
+		}
+	}
+
+	eval, _ := run.EvaluateTemplate("@contact.name @contact.first_name @fields.gender")
+	assert.Equal(t, "Anonymous Anonymous [redacted]", eval)
+
+	// results which include input from the contact are redacted in expressions
+	urn := contact.URNs()[0].URN()
+	run.SaveResult(flows.NewResult("Phone", "It's "+urn.Path(), "All Responses", "", "", "My number is "+urn.Path(), nil, dates.Now()))
+
+	eval, _ = run.EvaluateTemplate("@results.phone.value | @results.phone.input")
+	assert.Equal(t, "It's ******** | My number is ********", eval)
+
+	// but not in the run itself
+	assert.Equal(t, "It's "+urn.Path(), run.Results().Get("phone").Value)
+	assert.Equal(t, "My number is "+urn.Path(), run.Results().Get("phone").Input)
+}
+
+func TestFullRedactionSessionResume(t *testing.T) {
+	assetsJSON, err := ioutil.ReadFile("../../test/testdata/runner/two_questions.json")
+	require.NoError(t, err)
+
+	sa, err := test.CreateSessionAssets(assetsJSON, "")
+	require.NoError(t, err)
+
+	flow, err := sa.Flows().Get(assets.FlowUUID("615b8a0f-588c-4d20-a05f-363b0b4ce6f4"))
+	require.NoError(t, err)
+
+	contact, err := flows.ReadContact(sa, []byte(`{
+		"uuid": "5d76d86b-3bb9-4d5a-b822-c9d86f5d8e4f",
+		"name": "Ryan Lewis",
+		"language": "eng",
+		"created_on": "2018-06-20T11:40:30.123456789-00:00",
+		"urns": ["tel:+12065551212"],
+		"fields": {"first_name": {"text": "Ryan"}}
+	}`), assets.PanicOnMissing)
+	require.NoError(t, err)
+
+	env := envs.NewBuilder().WithRedactionPolicy(envs.RedactionPolicyFull).Build()
+	trigger := triggers.NewBuilder(env, flow.Reference(), contact).Manual().Build()
+
+	session, _, err := test.NewEngine().NewSession(sa, trigger)
+	require.NoError(t, err)
+	require.Equal(t, flows.SessionStatusWaiting, session.Status())
+
+	_, err = session.Resume(resumes.NewMsg(nil, nil, flows.NewMsgIn(flows.MsgUUID(uuids.New()), urns.URN("tel:+12065551212"), nil, "blue", nil)))
+	require.NoError(t, err)
+	require.Equal(t, flows.SessionStatusWaiting, session.Status())
+
+	eval, _ := session.Runs()[0].EvaluateTemplate("@contact.name @fields.first_name @(urn_parts(urns.tel).path)")
+	assert.Equal(t, "Anonymous [redacted] ********", eval)
+
+	// but the session JSON is unaffected by redaction...
+	sessionJSON, err := jsonx.Marshal(session)
+	require.NoError(t, err)
+
+	read, err := test.NewEngine().ReadSession(sa, sessionJSON, assets.PanicOnMissing)
+	require.NoError(t, err)
+
+	readJSON, err := jsonx.Marshal(read)
+	require.NoError(t, err)
+
+	test.AssertEqualJSON(t, sessionJSON, readJSON, "read session JSON mismatch")
+	assert.True(t, session.Contact().Equal(read.Contact()))
+	assert.Equal(t, "blue", read.Runs()[0].Results().Get("favorite_color").Value)
+
+	// ...so it can be resumed without losing anything
+	_, err = read.Resume(resumes.NewMsg(nil, nil, flows.NewMsgIn(flows.MsgUUID(uuids.New()), urns.URN("tel:+12065551212"), nil, "Coke", nil)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Ryan Lewis", read.Contact().Name())
+	assert.Equal(t, []urns.URN{"tel:+12065551212"}, read.Contact().URNs().RawURNs())
+	assert.Equal(t, "Ryan", read.Contact().Fields().Get(sa.Fields().Get("first_name")).Text.Native())
+	assert.Equal(t, "blue", read.Runs()[0].Results().Get("favorite_color").Value)
+	assert.Equal(t, "Coke", read.Runs()[0].Results().Get("soda").Value)
+}
+
 func BenchmarkEvaluateTemplate(b *testing.B) {
 	testFile, err := ioutil.ReadFile("testdata/templates.json")
 	require.NoError(b, err)
@@ -146,6 +239,50 @@ func TestReadWithMissingAssets(t *testing.T) {
 	assert.Equal(t, assets.NewFlowReference(assets.FlowUUID("b7cf0d83-f1c9-411c-96fd-c511a4cfa86d"), "Collect Age"), missingAssets[14])
 }
 
+func TestReadSessionStatuses(t *testing.T) {
+	session, _, err := test.CreateTestSession("", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	sessionJSON, err := jsonx.Marshal(session)
+	require.NoError(t, err)
+
+	eng := engine.NewBuilder().Build()
+
+	readWithStatus := func(status string, withWait bool) (flows.Session, error) {
+		data := test.JSONReplace(sessionJSON, []string{"status"}, []byte(`"`+status+`"`))
+		if withWait {
+			data = test.JSONReplace(data, []string{"wait"}, []byte(`{"type": "msg"}`))
+		}
+		return eng.ReadSession(session.Assets(), data, assets.PanicOnMissing)
+	}
+
+	for _, status := range []flows.SessionStatus{flows.SessionStatusExpired, flows.SessionStatusInterrupted} {
+		assert.True(t, status.IsEnded())
+
+		read, err := readWithStatus(string(status), false)
+		require.NoError(t, err)
+		assert.Equal(t, status, read.Status())
+
+		// and they can't be resumed
+		_, err = read.Resume(resumes.NewMsg(nil, nil, flows.NewMsgIn(flows.MsgUUID(uuids.New()), urns.NilURN, nil, "Hi", nil)))
+		if status == flows.SessionStatusInterrupted {
+			assert.Equal(t, flows.ErrSessionInterrupted, err)
+		} else {
+			assert.EqualError(t, err, "only waiting sessions can be resumed")
+		}
+
+		// ended sessions can't have a wait
+		_, err = readWithStatus(string(status), true)
+		assert.EqualError(t, err, fmt.Sprintf("session has status of \"%s\" but has a wait object", status))
+	}
+
+	assert.False(t, flows.SessionStatusActive.IsEnded())
+	assert.False(t, flows.SessionStatusWaiting.IsEnded())
+
+	_, err = readWithStatus("paused", false)
+	assert.EqualError(t, err, "unable to read session: field 'status' is not a valid session status")
+}
+
 func TestQueryBasedGroupReevaluationOnTrigger(t *testing.T) {
 	assetsJSON, err := ioutil.ReadFile("testdata/smart_groups.json")
 	require.NoError(t, err)
@@ -240,10 +377,109 @@ func TestResumeAfterWaitWithMissingFlowAssets(t *testing.T) {
 	assert.Equal(t, flows.RunStatusFailed, session3.Runs()[1].Status())
 }
 
+func TestInterrupt(t *testing.T) {
+	assetsJSON, err := ioutil.ReadFile("../../test/testdata/runner/subflow.json")
+	require.NoError(t, err)
+
+	session, _, err := test.CreateSession(assetsJSON, assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"))
+	require.NoError(t, err)
+
+	assert.Equal(t, flows.SessionStatusWaiting, session.Status())
+	assert.Equal(t, flows.RunStatusActive, session.Runs()[0].Status())
+	assert.Equal(t, flows.RunStatusWaiting, session.Runs()[1].Status())
+
+	waitingRun := session.Runs()[1]
+	numEvents := len(waitingRun.Events())
+
+	sprint, err := session.Interrupt("contact opted out")
+	require.NoError(t, err)
+
+	// session and all its runs are ended
+	assert.Equal(t, flows.SessionStatusInterrupted, session.Status())
+	assert.Nil(t, session.Wait())
+	assert.Equal(t, flows.RunStatusInterrupted, session.Runs()[0].Status())
+	assert.Equal(t, flows.RunStatusInterrupted, session.Runs()[1].Status())
+	assert.NotNil(t, session.Runs()[1].ExitedOn())
+
+	// and the waiting run has an event recording why
+	require.Equal(t, numEvents+1, len(waitingRun.Events()))
+	event := waitingRun.Events()[numEvents].(*events.SessionInterruptedEvent)
+	assert.Equal(t, events.TypeSessionInterrupted, event.Type())
+	assert.Equal(t, "contact opted out", event.Reason)
+	assert.Equal(t, waitingRun.Path()[len(waitingRun.Path())-1].UUID(), event.StepUUID())
+
+	// which is also returned in the sprint
+	assert.Equal(t, []flows.Event{event}, sprint.Events())
+
+	// interrupted sessions can't be resumed or interrupted again
+	_, err = session.Resume(resumes.NewWaitTimeout(nil, nil))
+	assert.Equal(t, flows.ErrSessionInterrupted, err)
+
+	_, err = session.Interrupt("again")
+	assert.EqualError(t, err, "only waiting sessions can be interrupted")
+
+	// and that's still true after a round trip through JSON
+	marshaled, err := jsonx.Marshal(session)
+	require.NoError(t, err)
+
+	session, err = session.Engine().ReadSession(session.Assets(), marshaled, assets.PanicOnMissing)
+	require.NoError(t, err)
+
+	assert.Equal(t, flows.SessionStatusInterrupted, session.Status())
+	assert.Equal(t, flows.RunStatusInterrupted, session.Runs()[1].Status())
+
+	_, err = session.Resume(resumes.NewWaitTimeout(nil, nil))
+	assert.Equal(t, flows.ErrSessionInterrupted, err)
+}
+
+func TestStepLimit(t *testing.T) {
+	// a flow with two nodes which exit unconditionally to each other
+	sa, err := test.CreateSessionAssets([]byte(`{
+		"flows": [
+			{
+				"uuid": "76f0a02f-3b75-4b86-9064-e9195e1b3a02",
+				"name": "Loop",
+				"spec_version": "13.0",
+				"language": "eng",
+				"type": "messaging",
+				"nodes": [
+					{
+						"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507",
+						"exits": [{"uuid": "37d8813f-1402-4ad2-9cc2-e9054a96525b", "destination_uuid": "32bc60ad-5c86-465e-a6b8-049c44ecce49"}]
+					},
+					{
+						"uuid": "32bc60ad-5c86-465e-a6b8-049c44ecce49",
+						"exits": [{"uuid": "d2a4052a-3fa9-4608-ab3e-5b9631440447", "destination_uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507"}]
+					}
+				]
+			}
+		]
+	}`), "")
+	require.NoError(t, err)
+
+	flow, err := sa.Flows().Get("76f0a02f-3b75-4b86-9064-e9195e1b3a02")
+	require.NoError(t, err)
+
+	trigger := triggers.NewBuilder(envs.NewBuilder().Build(), flow.Reference(), nil).Manual().Build()
+
+	for _, maxSteps := range []int{10, 100} {
+		session, sprint, err := engine.NewBuilder().WithMaxStepsPerSprint(maxSteps).Build().NewSession(sa, trigger)
+		require.NoError(t, err)
+
+		assert.Equal(t, flows.SessionStatusFailed, session.Status())
+		assert.Equal(t, flows.RunStatusFailed, session.Runs()[0].Status())
+		assert.Equal(t, maxSteps, len(session.Runs()[0].Path()))
+
+		require.Equal(t, 1, len(sprint.Events()))
+		assert.Equal(t, "failure", sprint.Events()[0].Type())
+		assert.Equal(t, "step limit exceeded, stopping execution before entering 'a58be63b-907d-4a1a-856b-0bb5579d7507'", sprint.Events()[0].(*events.FailureEvent).Text)
+	}
+}
+
 func TestWaitTimeout(t *testing.T) {
 	defer dates.SetNowSource(dates.DefaultNowSource)
 
-	t1 := time.Date(2018, 4, 11, 13, 24, 30, 123456000, time.UTC)
+	t1 := test.MustParseTime("2018-04-11T13:24:30.123456Z")
 	dates.SetNowSource(dates.NewFixedNowSource(t1))
 
 	assetsJSON, err := ioutil.ReadFile("testdata/timeout_test.json")
@@ -276,6 +512,103 @@ func TestWaitTimeout(t *testing.T) {
 	require.Equal(t, "", result.Input)
 }
 
+func TestCurrentRun(t *testing.T) {
+	// a session waiting in a single flow
+	assetsJSON, err := ioutil.ReadFile("../../test/testdata/runner/two_questions.json")
+	require.NoError(t, err)
+
+	session, _, err := test.CreateSession(assetsJSON, assets.FlowUUID("615b8a0f-588c-4d20-a05f-363b0b4ce6f4"))
+	require.NoError(t, err)
+
+	assert.Equal(t, flows.SessionStatusWaiting, session.Status())
+	require.NotNil(t, session.CurrentRun())
+	assert.Equal(t, session.Runs()[0], session.CurrentRun())
+
+	// once it completes there is no current run
+	_, err = session.Resume(resumes.NewRunExpiration(nil, nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, flows.SessionStatusCompleted, session.Status())
+	assert.Nil(t, session.CurrentRun())
+
+	// a session waiting in a sub-flow
+	assetsJSON, err = ioutil.ReadFile("../../test/testdata/runner/subflow.json")
+	require.NoError(t, err)
+
+	session, _, err = test.CreateSession(assetsJSON, assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"))
+	require.NoError(t, err)
+
+	assert.Equal(t, flows.SessionStatusWaiting, session.Status())
+	require.NotNil(t, session.CurrentRun())
+	assert.Equal(t, session.Runs()[1], session.CurrentRun())
+	assert.Equal(t, assets.FlowUUID("a8d27b94-d3d0-4a96-8074-0f162f342195"), session.CurrentRun().Flow().UUID())
+}
+
+func TestFlowHistory(t *testing.T) {
+	dates.SetNowSource(dates.NewSequentialNowSource(test.MustParseTime("2021-03-01T12:00:00Z")))
+	defer dates.SetNowSource(dates.DefaultNowSource)
+
+	assetsJSON, err := ioutil.ReadFile("../../test/testdata/runner/subflow.json")
+	require.NoError(t, err)
+
+	// start the parent flow which enters the child flow and waits there
+	session, _, err := test.CreateSession(assetsJSON, assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"))
+	require.NoError(t, err)
+
+	history := session.FlowHistory()
+	require.Equal(t, 2, len(history))
+	assert.Equal(t, assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"), history[0].FlowUUID)
+	assert.Equal(t, assets.FlowUUID("a8d27b94-d3d0-4a96-8074-0f162f342195"), history[1].FlowUUID)
+	assert.True(t, history[1].EnteredOn.After(history[0].EnteredOn))
+	assert.Nil(t, history[0].ExitedOn)
+	assert.Nil(t, history[1].ExitedOn)
+
+	// resume so that the child flow completes and we return to the parent, which also completes
+	session, _, err = test.ResumeSession(session, assetsJSON, "Hello")
+	require.NoError(t, err)
+
+	assert.Equal(t, flows.SessionStatusCompleted, session.Status())
+
+	// history is the same after a round trip through JSON
+	history = session.FlowHistory()
+	require.Equal(t, 2, len(history))
+	assert.Equal(t, assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"), history[0].FlowUUID)
+	assert.Equal(t, assets.FlowUUID("a8d27b94-d3d0-4a96-8074-0f162f342195"), history[1].FlowUUID)
+	assert.True(t, history[1].EnteredOn.After(history[0].EnteredOn))
+	require.NotNil(t, history[0].ExitedOn)
+	require.NotNil(t, history[1].ExitedOn)
+	assert.True(t, history[1].ExitedOn.After(history[1].EnteredOn))
+	assert.True(t, history[0].ExitedOn.After(*history[1].ExitedOn))
+	assert.Equal(t, session.Runs()[0].CreatedOn(), history[0].EnteredOn)
+	assert.Equal(t, *session.Runs()[1].ExitedOn(), *history[1].ExitedOn)
+}
+
+func TestRunEventStepUUIDs(t *testing.T) {
+	assetsJSON, err := ioutil.ReadFile("../../test/testdata/runner/subflow.json")
+	require.NoError(t, err)
+
+	session, _, err := test.CreateSession(assetsJSON, assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"))
+	require.NoError(t, err)
+
+	session, _, err = test.ResumeSession(session, assetsJSON, "Hello")
+	require.NoError(t, err)
+
+	// every event logged to a run should be linked to a step in that run's path
+	for _, run := range session.Runs() {
+		stepUUIDs := make(map[flows.StepUUID]bool)
+		for _, step := range run.Path() {
+			stepUUIDs[step.UUID()] = true
+		}
+
+		require.True(t, len(run.Events()) > 0)
+
+		for _, event := range run.Events() {
+			assert.NotEqual(t, flows.StepUUID(""), event.StepUUID(), "missing step UUID on %s event", event.Type())
+			assert.True(t, stepUUIDs[event.StepUUID()], "step UUID on %s event not in run path", event.Type())
+		}
+	}
+}
+
 func TestCurrentContext(t *testing.T) {
 	assetsJSON, err := ioutil.ReadFile("../../test/testdata/runner/subflow_loop_with_wait.json")
 	require.NoError(t, err)
@@ -355,3 +688,93 @@ func TestSessionHistory(t *testing.T) {
 		AncestorsSinceInput: 1,
 	}, session2.History())
 }
+
+func TestCloneSession(t *testing.T) {
+	assetsJSON := []byte(`{
+		"flows": [
+			{
+				"uuid": "5472a1c3-63e1-484f-8485-cc8ecb16a058",
+				"name": "Survey",
+				"spec_version": "13.1",
+				"language": "eng",
+				"type": "messaging",
+				"nodes": [
+					{
+						"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507",
+						"router": {
+							"type": "switch",
+							"wait": {"type": "msg"},
+							"categories": [
+								{"uuid": "9a43d8f5-a4d0-4d8e-9b5c-4ff0fd5be7ca", "name": "Yes", "exit_uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6"},
+								{"uuid": "2f0e1e7b-5c55-4b3c-bd3a-1ff7e3b2d1a4", "name": "No", "exit_uuid": "5f4b6d8c-1e2a-4b3c-8d9e-0f1a2b3c4d5e"},
+								{"uuid": "f01d693b-2af2-49fb-9e38-146eb00937e9", "name": "Other", "exit_uuid": "0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f"}
+							],
+							"operand": "@input.text",
+							"cases": [
+								{"uuid": "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", "type": "has_any_word", "arguments": ["yes"], "category_uuid": "9a43d8f5-a4d0-4d8e-9b5c-4ff0fd5be7ca"},
+								{"uuid": "76f0a02f-3b75-4b86-9064-e9195e1b3a02", "type": "has_any_word", "arguments": ["no"], "category_uuid": "2f0e1e7b-5c55-4b3c-bd3a-1ff7e3b2d1a4"}
+							],
+							"default_category_uuid": "f01d693b-2af2-49fb-9e38-146eb00937e9"
+						},
+						"exits": [
+							{"uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6"},
+							{"uuid": "5f4b6d8c-1e2a-4b3c-8d9e-0f1a2b3c4d5e"},
+							{"uuid": "0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f"}
+						]
+					}
+				]
+			}
+		]
+	}`)
+
+	session, _, err := test.CreateSession(assetsJSON, assets.FlowUUID("5472a1c3-63e1-484f-8485-cc8ecb16a058"))
+	require.NoError(t, err)
+	require.Equal(t, flows.SessionStatusWaiting, session.Status())
+
+	clone1, err := session.Engine().CloneSession(session)
+	require.NoError(t, err)
+	clone2, err := session.Engine().CloneSession(session)
+	require.NoError(t, err)
+
+	// clones get new session and run UUIDs but the same state
+	assert.NotEqual(t, session.UUID(), clone1.UUID())
+	assert.NotEqual(t, clone1.UUID(), clone2.UUID())
+	assert.Equal(t, flows.SessionStatusWaiting, clone1.Status())
+	assert.Equal(t, session.Contact().UUID(), clone1.Contact().UUID())
+	assert.NotEqual(t, session.Runs()[0].UUID(), clone1.Runs()[0].UUID())
+	assert.NotEqual(t, clone1.Runs()[0].UUID(), clone2.Runs()[0].UUID())
+
+	resume := func(s flows.Session, text string) {
+		msg := flows.NewMsgIn(flows.MsgUUID(uuids.New()), urns.NilURN, nil, text, nil)
+		_, err := s.Resume(resumes.NewMsg(s.Environment(), s.Contact(), msg))
+		require.NoError(t, err)
+	}
+
+	resume(clone1, "yes")
+	resume(clone2, "no")
+
+	path1 := clone1.Runs()[0].Path()
+	path2 := clone2.Runs()[0].Path()
+	assert.Equal(t, flows.ExitUUID("118221f7-e637-4cdb-83ca-7f0a5aae98c6"), path1[len(path1)-1].ExitUUID())
+	assert.Equal(t, flows.ExitUUID("5f4b6d8c-1e2a-4b3c-8d9e-0f1a2b3c4d5e"), path2[len(path2)-1].ExitUUID())
+
+	// and the original session is untouched
+	assert.Equal(t, flows.SessionStatusWaiting, session.Status())
+	assert.Equal(t, flows.ExitUUID(""), session.Runs()[0].Path()[0].ExitUUID())
+}
+
+func TestCloneSessionWithChildRun(t *testing.T) {
+	session, _, err := test.CreateTestSession("", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(session.Runs()))
+
+	clone, err := session.Engine().CloneSession(session)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(clone.Runs()))
+
+	// child run references the new UUID of its parent
+	parent, child := clone.Runs()[0], clone.Runs()[1]
+	assert.NotEqual(t, session.Runs()[0].UUID(), parent.UUID())
+	assert.NotEqual(t, session.Runs()[1].UUID(), child.UUID())
+	assert.Equal(t, parent.UUID(), child.ParentInSession().UUID())
+}
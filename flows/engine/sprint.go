@@ -1,12 +1,17 @@
 package engine
 
 import (
+	"time"
+
 	"github.com/nyaruka/goflow/flows"
 )
 
 type sprint struct {
 	modifiers []flows.Modifier
 	events    []flows.Event
+
+	startedOn time.Time
+	duration  time.Duration
 }
 
 // NewEmptySprint creates a new sprint
@@ -25,8 +30,23 @@ func NewSprint(modifiers []flows.Modifier, events []flows.Event) flows.Sprint {
 	}
 }
 
+// creates a new empty sprint which is timed from now until end is called
+func newTimedSprint() *sprint {
+	return &sprint{
+		modifiers: make([]flows.Modifier, 0),
+		events:    make([]flows.Event, 0),
+		startedOn: time.Now(),
+	}
+}
+
+// records how long this sprint took
+func (s *sprint) end() {
+	s.duration = time.Since(s.startedOn)
+}
+
 func (s *sprint) Modifiers() []flows.Modifier { return s.modifiers }
 func (s *sprint) Events() []flows.Event       { return s.events }
+func (s *sprint) Duration() time.Duration     { return s.duration }
 
 func (s *sprint) LogModifier(m flows.Modifier) {
 	s.modifiers = append(s.modifiers, m)
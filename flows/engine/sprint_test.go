@@ -1,15 +1,23 @@
 package engine_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/nyaruka/gocommon/uuids"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/engine"
 	"github.com/nyaruka/goflow/flows/events"
 	"github.com/nyaruka/goflow/flows/modifiers"
+	"github.com/nyaruka/goflow/flows/triggers"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSprint(t *testing.T) {
@@ -25,4 +33,73 @@ func TestSprint(t *testing.T) {
 
 	assert.Equal(t, []flows.Modifier{mod1, mod2}, sprint.Modifiers())
 	assert.Equal(t, []flows.Event{event1, event2}, sprint.Events())
+
+	// sprints not created by the engine aren't timed
+	assert.Equal(t, 0, int(sprint.Duration()))
+}
+
+func TestSprintDuration(t *testing.T) {
+	sa, trigger := createLongFlowSession(t, 100)
+
+	session, sprint, err := engine.NewBuilder().WithMaxStepsPerSprint(200).Build().NewSession(sa, trigger)
+	require.NoError(t, err)
+
+	assert.Equal(t, flows.SessionStatusCompleted, session.Status())
+	assert.Equal(t, 100, len(session.Runs()[0].Path()))
+	assert.True(t, sprint.Duration() > 0)
+}
+
+func BenchmarkLongFlow(b *testing.B) {
+	sa, trigger := createLongFlowSession(b, 100)
+	eng := engine.NewBuilder().WithMaxStepsPerSprint(200).Build()
+
+	for n := 0; n < b.N; n++ {
+		_, sprint, err := eng.NewSession(sa, trigger)
+		require.NoError(b, err)
+		require.True(b, sprint.Duration() > 0)
+	}
+}
+
+// creates assets with a flow of the given number of nodes, each of which saves a result, and a trigger to start it
+func createLongFlowSession(t require.TestingT, numNodes int) (flows.SessionAssets, flows.Trigger) {
+	nodeUUIDs := make([]uuids.UUID, numNodes)
+	for i := range nodeUUIDs {
+		nodeUUIDs[i] = uuids.New()
+	}
+
+	nodes := make([]string, numNodes)
+	for i := range nodes {
+		destination := ""
+		if i < numNodes-1 {
+			destination = fmt.Sprintf(`, "destination_uuid": "%s"`, nodeUUIDs[i+1])
+		}
+		nodes[i] = fmt.Sprintf(`{
+			"uuid": "%s",
+			"actions": [{"uuid": "%s", "type": "set_run_result", "name": "Node", "value": "@(%d + 1)"}],
+			"exits": [{"uuid": "%s"%s}]
+		}`, nodeUUIDs[i], uuids.New(), i, uuids.New(), destination)
+	}
+
+	source, err := static.NewSource([]byte(`{
+		"flows": [
+			{
+				"uuid": "5472a1c3-63e1-484f-8485-cc8ecb16a058",
+				"name": "Long",
+				"spec_version": "13.1",
+				"language": "eng",
+				"type": "messaging",
+				"nodes": [` + strings.Join(nodes, ",") + `]
+			}
+		]
+	}`))
+	require.NoError(t, err)
+
+	env := envs.NewBuilder().Build()
+	sa, err := engine.NewSessionAssets(env, source, nil)
+	require.NoError(t, err)
+
+	flow := assets.NewFlowReference("5472a1c3-63e1-484f-8485-cc8ecb16a058", "Long")
+	contact := flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil)
+
+	return sa, triggers.NewBuilder(env, flow, contact).Manual().Build()
 }
@@ -20,7 +20,7 @@ func NewEnvironment(base envs.Environment, la *LocationAssets) envs.Environment
 
 	hierarchies := la.Hierarchies()
 	if len(hierarchies) > 0 {
-		locationResolver = &assetLocationResolver{hierarchies[0]}
+		locationResolver = &assetLocationResolver{locations: hierarchies[0], assets: la}
 	}
 
 	return &environment{base, locationResolver}
@@ -32,8 +32,12 @@ func (e *environment) LocationResolver() envs.LocationResolver {
 
 type assetLocationResolver struct {
 	locations assets.LocationHierarchy
+	assets    *LocationAssets
 }
 
+// minimum trigram similarity for a location to be considered a match in FindLocationsSimilar
+const locationSimilarityThreshold = 0.4
+
 // FindLocations returns locations with the matching name (case-insensitive), level and parent (optional)
 func (r *assetLocationResolver) FindLocations(name string, level envs.LocationLevel, parent *envs.Location) []*envs.Location {
 	return r.locations.FindByName(name, level, parent)
@@ -76,6 +80,23 @@ func (r *assetLocationResolver) FindLocationsFuzzy(text string, level envs.Locat
 	return []*envs.Location{}
 }
 
+// FindLocationsSimilar returns locations with the given level and parent (optional) whose names are similar to the
+// given text, with the most similar first. Like the other methods, this only searches the first hierarchy.
+func (r *assetLocationResolver) FindLocationsSimilar(text string, level envs.LocationLevel, parent *envs.Location) []*envs.Location {
+	locations := make([]*envs.Location, 0)
+
+	for _, match := range r.assets.search(text, 0, 1) {
+		if match.Score < locationSimilarityThreshold {
+			break
+		}
+		if match.Location.Level() == level && (parent == nil || match.Location.Parent() == parent) {
+			locations = append(locations, match.Location)
+		}
+	}
+
+	return locations
+}
+
 func (r *assetLocationResolver) LookupLocation(path envs.LocationPath) *envs.Location {
 	return r.locations.FindByPath(path)
 }
@@ -82,4 +82,13 @@ func TestEnvironment(t *testing.T) {
 	matches := fenv.LocationResolver().FindLocationsFuzzy("gisozi town", flows.LocationLevelWard, nil)
 	assert.Equal(t, 1, len(matches))
 	assert.Equal(t, "Gisozi", matches[0].Name())
+
+	similar, isSimilar := fenv.LocationResolver().(envs.SimilarLocationResolver)
+	require.True(t, isSimilar)
+
+	matches = similar.FindLocationsSimilar("gasab0", flows.LocationLevelDistrict, kigali)
+	assert.Equal(t, 1, len(matches))
+	assert.Equal(t, "Gasabo", matches[0].Name())
+
+	assert.Equal(t, 0, len(similar.FindLocationsSimilar("gasab0", flows.LocationLevelWard, nil)))
 }
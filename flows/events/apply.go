@@ -0,0 +1,103 @@
+package events
+
+import (
+	"time"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+
+	"github.com/pkg/errors"
+)
+
+// BatchApplyEvents applies the given events in order to the contact of the given run, e.g. to reconstruct contact
+// state from a stored event log. Errors don't stop the remaining events from being applied, and are returned in a
+// slice with an entry for each event which is nil if that event was applied successfully.
+func BatchApplyEvents(run flows.FlowRun, events []flows.Event) []error {
+	errs := make([]error, len(events))
+
+	for i, event := range events {
+		errs[i] = applyEvent(run, event)
+	}
+	return errs
+}
+
+// applies a single event to the contact of the given run, ignoring event types which don't describe contact changes
+func applyEvent(run flows.FlowRun, event flows.Event) error {
+	sa := run.Session().Assets()
+
+	// a contact refresh replaces the contact entirely
+	if typed, isRefresh := event.(*ContactRefreshedEvent); isRefresh {
+		contact, err := flows.ReadContact(sa, typed.Contact, assets.IgnoreMissing)
+		if err != nil {
+			return errors.Wrap(err, "unable to read refreshed contact")
+		}
+		run.Session().SetContact(contact)
+		return nil
+	}
+
+	contact := run.Contact()
+	if contact == nil {
+		return errors.Errorf("can't apply %s event to session without a contact", event.Type())
+	}
+
+	switch typed := event.(type) {
+	case *ContactNameChangedEvent:
+		contact.SetName(typed.Name)
+
+	case *ContactLanguageChangedEvent:
+		lang, err := envs.ParseLanguage(typed.Language)
+		if err != nil && typed.Language != "" {
+			return err
+		}
+		contact.SetLanguage(lang)
+
+	case *ContactTimezoneChangedEvent:
+		var tz *time.Location
+		if typed.Timezone != "" {
+			var err error
+			if tz, err = time.LoadLocation(typed.Timezone); err != nil {
+				return errors.Wrapf(err, "invalid timezone '%s'", typed.Timezone)
+			}
+		}
+		contact.SetTimezone(tz)
+
+	case *ContactStatusChangedEvent:
+		contact.SetStatus(typed.Status)
+
+	case *ContactFieldChangedEvent:
+		field := sa.Fields().Get(typed.Field.Key)
+		if field == nil {
+			return errors.Errorf("no such field with key '%s'", typed.Field.Key)
+		}
+		contact.Fields().Set(field, typed.Value)
+
+	case *ContactGroupsChangedEvent:
+		var missing []string
+		for _, ref := range typed.GroupsAdded {
+			if group := sa.Groups().Get(ref.UUID); group != nil {
+				contact.Groups().Add(group)
+			} else {
+				missing = append(missing, string(ref.UUID))
+			}
+		}
+		for _, ref := range typed.GroupsRemoved {
+			if group := sa.Groups().Get(ref.UUID); group != nil {
+				contact.Groups().Remove(group)
+			} else {
+				missing = append(missing, string(ref.UUID))
+			}
+		}
+		if len(missing) > 0 {
+			return errors.Errorf("no such groups with UUIDs %v", missing)
+		}
+
+	case *ContactURNsChangedEvent:
+		contact.ClearURNs()
+		for _, urn := range typed.URNs {
+			contact.AddURN(urn, nil)
+		}
+	}
+
+	return nil
+}
@@ -32,7 +32,7 @@ func TestEventMarshaling(t *testing.T) {
 	defer dates.SetNowSource(dates.DefaultNowSource)
 	defer uuids.SetGenerator(uuids.DefaultGenerator)
 
-	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 10, 18, 14, 20, 30, 123456, time.UTC)))
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-10-18T14:20:30.000123456Z")))
 	uuids.SetGenerator(uuids.NewSeededGenerator(12345))
 
 	session, _, err := test.CreateTestSession("", envs.RedactionPolicyNone)
@@ -41,6 +41,7 @@ func TestEventMarshaling(t *testing.T) {
 	tz, _ := time.LoadLocation("Africa/Kigali")
 	timeout := 500
 	gender := session.Assets().Fields().Get("gender")
+	witResponseBody := `{"intents":[]}`
 
 	eventTests := []struct {
 		event     flows.Event
@@ -135,12 +136,13 @@ func TestEventMarshaling(t *testing.T) {
 				assets.NewClassifierReference(assets.ClassifierUUID("4b937f49-7fb7-43a5-8e57-14e2f028a471"), "Booking"),
 				[]*flows.HTTPLog{
 					{
-						CreatedOn: dates.Now(),
-						ElapsedMS: 12,
-						Request:   "GET /message?v=20200513&q=hello HTTP/1.1\r\nHost: api.wit.ai\r\nUser-Agent: Go-http-client/1.1\r\nAccept-Encoding: gzip\r\n\r\n",
-						Response:  "HTTP/1.0 200 OK\r\nContent-Length: 14\r\n\r\n{\"intents\":[]}",
-						Status:    flows.CallStatusSuccess,
-						URL:       "https://api.wit.ai/message?v=20200513&q=hello",
+						CreatedOn:    dates.Now(),
+						ElapsedMS:    12,
+						Request:      "GET /message?v=20200513&q=hello HTTP/1.1\r\nHost: api.wit.ai\r\nUser-Agent: Go-http-client/1.1\r\nAccept-Encoding: gzip\r\n\r\n",
+						Response:     "HTTP/1.0 200 OK\r\nContent-Length: 14\r\n\r\n{\"intents\":[]}",
+						ResponseBody: &witResponseBody,
+						Status:       flows.CallStatusSuccess,
+						URL:          "https://api.wit.ai/message?v=20200513&q=hello",
 					},
 				},
 			),
@@ -158,6 +160,8 @@ func TestEventMarshaling(t *testing.T) {
 						"elapsed_ms": 12,
 						"request": "GET /message?v=20200513&q=hello HTTP/1.1\r\nHost: api.wit.ai\r\nUser-Agent: Go-http-client/1.1\r\nAccept-Encoding: gzip\r\n\r\n",
 						"response": "HTTP/1.0 200 OK\r\nContent-Length: 14\r\n\r\n{\"intents\":[]}",
+						"request_body": null,
+						"response_body": "{\"intents\":[]}",
 						"status": "success",
 						"url": "https://api.wit.ai/message?v=20200513&q=hello"
 					}
@@ -422,6 +426,14 @@ func TestEventMarshaling(t *testing.T) {
 				"type": "wait_timed_out"
 			}`,
 		},
+		{
+			events.NewSessionInterrupted("contact opted out"),
+			`{
+				"created_on": "2018-10-18T14:20:30.000123456Z",
+				"reason": "contact opted out",
+				"type": "session_interrupted"
+			}`,
+		},
 		{
 			events.NewDialEnded(flows.NewDial(flows.DialStatusBusy, 0)),
 			`{
@@ -433,6 +445,24 @@ func TestEventMarshaling(t *testing.T) {
 				}
 			}`,
 		},
+		{
+			events.NewDigitsReceived("123"),
+			`{
+				"type": "digits_received",
+				"created_on": "2018-10-18T14:20:30.000123456Z",
+				"digits": "123"
+			}`,
+		},
+		{
+			events.NewLocationReceived(-2.90875, -79.0117686, 10.5),
+			`{
+				"type": "location_received",
+				"created_on": "2018-10-18T14:20:30.000123456Z",
+				"latitude": -2.90875,
+				"longitude": -79.0117686,
+				"accuracy": 10.5
+			}`,
+		},
 		{
 			events.NewDialWait(urns.URN("tel:+1234567890")),
 			`{
@@ -599,13 +629,70 @@ func TestWebhookCalledEventTrimming(t *testing.T) {
 	assert.Equal(t, 42, len(call.ResponseTrace))
 	assert.Equal(t, 20000, len(call.ResponseBody))
 
-	event := events.NewWebhookCalled(call, flows.CallStatusSuccess, "")
+	event := events.NewWebhookCalled(call, flows.CallStatusSuccess, "", false)
 
 	assert.Equal(t, "http://temba.io/", event.URL)
 	assert.Equal(t, 10000, len(event.Request))
 	assert.Equal(t, "XXXXXXX...", event.Request[9990:])
-	assert.Equal(t, 10000, len(event.Response))
-	assert.Equal(t, "YYYYYYY...", event.Response[9990:])
+	assert.Equal(t, "HTTP/1.0 200 OK\r\nContent-Length: 20000\r\n\r\n", event.Response)
+	assert.Equal(t, strings.Repeat("Y", 256), event.ResponseBodyPreview)
+	assert.True(t, event.ResponseBodyTruncated)
+
+	// engine can be configured to include full bodies, which are still trimmed to 10K
+	event = events.NewWebhookCalled(call, flows.CallStatusSuccess, "", true)
+
+	assert.Equal(t, strings.Repeat("Y", 10000), event.ResponseBodyPreview)
+	assert.True(t, event.ResponseBodyTruncated)
+}
+
+func TestWebhookCalledEventPreview(t *testing.T) {
+	defer httpx.SetRequestor(httpx.DefaultRequestor)
+
+	httpx.SetRequestor(httpx.NewMockRequestor(map[string][]httpx.MockResponse{
+		"http://temba.io/": {
+			httpx.NewMockResponse(200, nil, `{"ok": true}`),
+			httpx.NewMockResponse(200, nil, strings.Repeat("X", 255)+"ñ"),
+		},
+	}))
+
+	svc := webhooks.NewService(http.DefaultClient, nil, nil, nil, 1024*1024)
+
+	request, _ := http.NewRequest("GET", "http://temba.io/", nil)
+	call, err := svc.Call(nil, request)
+	require.NoError(t, err)
+
+	event := events.NewWebhookCalled(call, flows.CallStatusSuccess, "", false)
+	assert.Equal(t, `{"ok": true}`, event.ResponseBodyPreview)
+	assert.False(t, event.ResponseBodyTruncated)
+
+	// preview shouldn't split a multi-byte character
+	request, _ = http.NewRequest("GET", "http://temba.io/", nil)
+	call, err = svc.Call(nil, request)
+	require.NoError(t, err)
+
+	event = events.NewWebhookCalled(call, flows.CallStatusSuccess, "", false)
+	assert.Equal(t, strings.Repeat("X", 255), event.ResponseBodyPreview)
+	assert.True(t, event.ResponseBodyTruncated)
+}
+
+func TestWebhookCalledEventMigration(t *testing.T) {
+	// older events have the response body in the response trace
+	e, err := events.ReadEvent([]byte(`{
+		"type": "webhook_called",
+		"created_on": "2006-01-02T15:04:05Z",
+		"url": "http://temba.io/",
+		"status": "success",
+		"status_code": 200,
+		"request": "GET / HTTP/1.1\r\n\r\n",
+		"response": "HTTP/1.1 200 OK\r\n\r\n{\"ok\": true}",
+		"elapsed_ms": 123
+	}`))
+	require.NoError(t, err)
+
+	event := e.(*events.WebhookCalledEvent)
+	assert.Equal(t, "HTTP/1.1 200 OK\r\n\r\n", event.Response)
+	assert.Equal(t, `{"ok": true}`, event.ResponseBodyPreview)
+	assert.False(t, event.ResponseBodyTruncated)
 }
 
 func TestWebhookCalledEventBadUTF8(t *testing.T) {
@@ -614,6 +701,7 @@ func TestWebhookCalledEventBadUTF8(t *testing.T) {
 	httpx.SetRequestor(httpx.NewMockRequestor(map[string][]httpx.MockResponse{
 		"http://temba.io/": {
 			httpx.NewMockResponse(200, nil, "\xa0\xa1"),
+			httpx.NewMockResponse(200, map[string]string{"X-Thing": "\xa0\xa1"}, "OK"),
 		},
 	}))
 
@@ -623,10 +711,21 @@ func TestWebhookCalledEventBadUTF8(t *testing.T) {
 	call, err := svc.Call(nil, request)
 	require.NoError(t, err)
 
-	event := events.NewWebhookCalled(call, flows.CallStatusSuccess, "")
+	event := events.NewWebhookCalled(call, flows.CallStatusSuccess, "", false)
 
 	assert.Equal(t, "http://temba.io/", event.URL)
-	assert.Equal(t, "HTTP/1.0 200 OK\r\nContent-Length: 2\r\n\r\n...", event.Response)
+	assert.Equal(t, "HTTP/1.0 200 OK\r\nContent-Length: 2\r\n\r\n", event.Response)
+	assert.Equal(t, "...", event.ResponseBodyPreview)
+	assert.True(t, utf8.ValidString(event.ResponseBodyPreview))
+
+	// invalid UTF-8 in the response headers is also replaced
+	request, _ = http.NewRequest("GET", "http://temba.io/", nil)
+	call, err = svc.Call(nil, request)
+	require.NoError(t, err)
+
+	event = events.NewWebhookCalled(call, flows.CallStatusSuccess, "", false)
+
+	assert.Equal(t, "HTTP/1.0 200 OK\r\nContent-Length: 2\r\nX-Thing: ...\r\n\r\n", event.Response)
 	assert.True(t, utf8.ValidString(event.Response))
 }
 
@@ -655,3 +754,54 @@ func TestDeprecatedEvents(t *testing.T) {
 	assert.NoError(t, err)
 	test.AssertEqualJSON(t, eventJSON, marshaled, "marshal event mismatch")
 }
+
+func TestBatchApplyEvents(t *testing.T) {
+	session, _, err := test.CreateTestSession("", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	run := session.Runs()[0]
+
+	eventLog := []string{
+		`{"type": "contact_name_changed", "created_on": "2018-10-18T14:20:30.000123456Z", "name": "Robert"}`,
+		`{"type": "contact_language_changed", "created_on": "2018-10-18T14:20:31.000123456Z", "language": "fra"}`,
+		`{"type": "contact_groups_changed", "created_on": "2018-10-18T14:20:32.000123456Z", "groups_added": [{"uuid": "1e1ce1e1-9288-4504-869e-022d1003c72a", "name": "Customers"}], "groups_removed": [{"uuid": "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", "name": "Testers"}]}`,
+		`{"type": "msg_created", "created_on": "2018-10-18T14:20:33.000123456Z", "msg": {"uuid": "2d611e17-fb22-457f-b802-b8f225a50548", "text": "Hi there"}}`,
+		`{"type": "contact_groups_changed", "created_on": "2018-10-18T14:20:34.000123456Z", "groups_added": [{"uuid": "a3d7b4d6-1a52-4e5c-8b53-5c1b53a3a4c1", "name": "Deleted"}]}`,
+		`{"type": "contact_field_changed", "created_on": "2018-10-18T14:20:35.000123456Z", "field": {"key": "gender", "name": "Gender"}, "value": {"text": "Female"}}`,
+		`{"type": "contact_field_changed", "created_on": "2018-10-18T14:20:36.000123456Z", "field": {"key": "activation_token", "name": "Activation Token"}, "value": null}`,
+		`{"type": "contact_field_changed", "created_on": "2018-10-18T14:20:37.000123456Z", "field": {"key": "shoe_size", "name": "Shoe Size"}, "value": {"text": "12"}}`,
+	}
+
+	evts := make([]flows.Event, len(eventLog))
+	for i, e := range eventLog {
+		evts[i], err = events.ReadEvent(json.RawMessage(e))
+		require.NoError(t, err)
+	}
+
+	errs := events.BatchApplyEvents(run, evts)
+	require.Equal(t, len(evts), len(errs))
+
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	assert.NoError(t, errs[2])
+	assert.NoError(t, errs[3])
+	assert.EqualError(t, errs[4], "no such groups with UUIDs [a3d7b4d6-1a52-4e5c-8b53-5c1b53a3a4c1]")
+	assert.NoError(t, errs[5])
+	assert.NoError(t, errs[6])
+	assert.EqualError(t, errs[7], "no such field with key 'shoe_size'")
+
+	contact := session.Contact()
+	assert.Equal(t, "Robert", contact.Name())
+	assert.Equal(t, envs.Language("fra"), contact.Language())
+
+	groupNames := make([]string, 0)
+	for _, g := range contact.Groups().All() {
+		groupNames = append(groupNames, g.Name())
+	}
+	assert.Equal(t, []string{"Males", "Customers"}, groupNames)
+
+	sa := session.Assets()
+	assert.Equal(t, "Female", contact.Fields().Get(sa.Fields().Get("gender")).Text.Native())
+	assert.Nil(t, contact.Fields().Get(sa.Fields().Get("activation_token")))
+	assert.NotNil(t, contact.Fields().Get(sa.Fields().Get("join_date")))
+}
@@ -0,0 +1,37 @@
+package events
+
+import (
+	"github.com/nyaruka/goflow/flows"
+)
+
+func init() {
+	registerType(TypeDigitsReceived, func() flows.Event { return &DigitsReceivedEvent{} })
+}
+
+// TypeDigitsReceived is the type of our digits received event
+const TypeDigitsReceived string = "digits_received"
+
+// DigitsReceivedEvent events are created when a session is resumed with digits entered by the contact on an IVR call.
+//
+//   {
+//     "type": "digits_received",
+//     "created_on": "2019-01-02T15:04:05Z",
+//     "digits": "123"
+//   }
+//
+// @event digits_received
+type DigitsReceivedEvent struct {
+	baseEvent
+
+	Digits string `json:"digits" validate:"required"`
+}
+
+// NewDigitsReceived returns a new digits received event
+func NewDigitsReceived(digits string) *DigitsReceivedEvent {
+	return &DigitsReceivedEvent{
+		baseEvent: newBaseEvent(TypeDigitsReceived),
+		Digits:    digits,
+	}
+}
+
+var _ flows.Event = (*DigitsReceivedEvent)(nil)
@@ -0,0 +1,44 @@
+package events
+
+import (
+	"github.com/nyaruka/goflow/flows"
+)
+
+func init() {
+	registerType(TypeLocationReceived, func() flows.Event { return &LocationReceivedEvent{} })
+}
+
+// TypeLocationReceived is the type of our location received event
+const TypeLocationReceived string = "location_received"
+
+// LocationReceivedEvent events are created when a session is resumed with a location provided by the contact. The
+// accuracy is the radius in meters and is omitted if it's not known.
+//
+//   {
+//     "type": "location_received",
+//     "created_on": "2019-01-02T15:04:05Z",
+//     "latitude": -2.90875,
+//     "longitude": -79.0117686,
+//     "accuracy": 10.5
+//   }
+//
+// @event location_received
+type LocationReceivedEvent struct {
+	baseEvent
+
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Accuracy  float32 `json:"accuracy,omitempty"`
+}
+
+// NewLocationReceived returns a new location received event
+func NewLocationReceived(latitude, longitude float64, accuracy float32) *LocationReceivedEvent {
+	return &LocationReceivedEvent{
+		baseEvent: newBaseEvent(TypeLocationReceived),
+		Latitude:  latitude,
+		Longitude: longitude,
+		Accuracy:  accuracy,
+	}
+}
+
+var _ flows.Event = (*LocationReceivedEvent)(nil)
@@ -29,7 +29,8 @@ const TypeMsgCreated string = "msg_created"
 type MsgCreatedEvent struct {
 	baseEvent
 
-	Msg *flows.MsgOut `json:"msg" validate:"required,dive"`
+	Msg              *flows.MsgOut `json:"msg" validate:"required,dive"`
+	EvaluationErrors []string      `json:"evaluation_errors,omitempty"`
 }
 
 // NewMsgCreated creates a new outgoing msg event to a single contact
@@ -39,3 +40,10 @@ func NewMsgCreated(msg *flows.MsgOut) *MsgCreatedEvent {
 		Msg:       msg,
 	}
 }
+
+// NewMsgCreatedWithErrors creates a new outgoing msg event whose text couldn't be evaluated
+func NewMsgCreatedWithErrors(msg *flows.MsgOut, evaluationErrors []string) *MsgCreatedEvent {
+	event := NewMsgCreated(msg)
+	event.EvaluationErrors = evaluationErrors
+	return event
+}
@@ -0,0 +1,37 @@
+package events
+
+import (
+	"github.com/nyaruka/goflow/flows"
+)
+
+func init() {
+	registerType(TypeSessionInterrupted, func() flows.Event { return &SessionInterruptedEvent{} })
+}
+
+// TypeSessionInterrupted is the type of our session interrupted event
+const TypeSessionInterrupted string = "session_interrupted"
+
+// SessionInterruptedEvent events are created when the caller interrupts a waiting session.
+//
+//   {
+//     "type": "session_interrupted",
+//     "created_on": "2006-01-02T15:04:05Z",
+//     "reason": "contact opted out"
+//   }
+//
+// @event session_interrupted
+type SessionInterruptedEvent struct {
+	baseEvent
+
+	Reason string `json:"reason,omitempty"`
+}
+
+// NewSessionInterrupted returns a new session interrupted event
+func NewSessionInterrupted(reason string) *SessionInterruptedEvent {
+	return &SessionInterruptedEvent{
+		baseEvent: newBaseEvent(TypeSessionInterrupted),
+		Reason:    reason,
+	}
+}
+
+var _ flows.Event = (*SessionInterruptedEvent)(nil)
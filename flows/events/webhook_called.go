@@ -1,7 +1,9 @@
 package events
 
 import (
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/utils"
@@ -14,12 +16,15 @@ func init() {
 // TypeWebhookCalled is the type for our webhook events
 const TypeWebhookCalled string = "webhook_called"
 
-// trim request and response traces to 10K chars to avoid bloating serialized sessions
+// trim request traces and full response bodies to 10K chars to avoid bloating serialized sessions
 const trimTracesTo = 10000
 
+// by default only the start of response bodies is included
+const responseBodyPreviewBytes = 256
+
 // WebhookCalledEvent events are created when a webhook is called. The event contains
 // the URL and the status of the response, as well as a full dump of the
-// request and response.
+// request, the response headers and a preview of the response body.
 //
 //   {
 //     "type": "webhook_called",
@@ -29,39 +34,90 @@ const trimTracesTo = 10000
 //     "status_code": 200,
 //     "elapsed_ms": 123,
 //     "request": "GET /?format=json HTTP/1.1",
-//     "response": "HTTP/1.1 200 OK\r\n\r\n{\"ip\":\"190.154.48.130\"}"
+//     "response": "HTTP/1.1 200 OK\r\n\r\n",
+//     "response_body_preview": "{\"ip\":\"190.154.48.130\"}"
 //   }
 //
 // @event webhook_called
 type WebhookCalledEvent struct {
 	baseEvent
 
-	URL         string           `json:"url" validate:"required"`
-	Status      flows.CallStatus `json:"status" validate:"required"`
-	Request     string           `json:"request" validate:"required"`
-	Response    string           `json:"response"`
-	ElapsedMS   int              `json:"elapsed_ms"`
-	Resthook    string           `json:"resthook,omitempty"`
-	StatusCode  int              `json:"status_code,omitempty"`
-	BodyIgnored bool             `json:"body_ignored,omitempty"`
+	URL                   string           `json:"url" validate:"required"`
+	Status                flows.CallStatus `json:"status" validate:"required"`
+	Request               string           `json:"request" validate:"required"`
+	Response              string           `json:"response"`
+	ResponseBodyPreview   string           `json:"response_body_preview,omitempty"`
+	ResponseBodyTruncated bool             `json:"response_body_truncated,omitempty"`
+	ElapsedMS             int              `json:"elapsed_ms"`
+	Resthook              string           `json:"resthook,omitempty"`
+	StatusCode            int              `json:"status_code,omitempty"`
+	BodyIgnored           bool             `json:"body_ignored,omitempty"`
 }
 
-// NewWebhookCalled returns a new webhook called event
-func NewWebhookCalled(call *flows.WebhookCall, status flows.CallStatus, resthook string) *WebhookCalledEvent {
+// NewWebhookCalled returns a new webhook called event. Only a preview of the response body is included unless
+// fullBody is true.
+func NewWebhookCalled(call *flows.WebhookCall, status flows.CallStatus, resthook string, fullBody bool) *WebhookCalledEvent {
 	statusCode := 0
 	if call.Response != nil {
 		statusCode = call.Response.StatusCode
 	}
 
+	body := "..."
+	if utf8.Valid(call.ResponseBody) {
+		body = string(call.ResponseBody)
+	}
+
+	bodyLimit := responseBodyPreviewBytes
+	if fullBody {
+		bodyLimit = trimTracesTo
+	}
+	preview, truncated := truncateBody(body, bodyLimit)
+
 	return &WebhookCalledEvent{
-		baseEvent:   newBaseEvent(TypeWebhookCalled),
-		URL:         call.Request.URL.String(),
-		Status:      status,
-		Request:     utils.TruncateEllipsis(string(call.RequestTrace), trimTracesTo),
-		Response:    utils.TruncateEllipsis(string(call.ResponseTraceUTF8("...")), trimTracesTo),
-		ElapsedMS:   int((call.EndTime.Sub(call.StartTime)) / time.Millisecond),
-		Resthook:    resthook,
-		StatusCode:  statusCode,
-		BodyIgnored: len(call.ResponseBody) > 0 && !call.ValidJSON,
+		baseEvent:             newBaseEvent(TypeWebhookCalled),
+		URL:                   call.Request.URL.String(),
+		Status:                status,
+		Request:               utils.TruncateEllipsis(string(call.RequestTrace), trimTracesTo),
+		Response:              utils.TruncateEllipsis(strings.ToValidUTF8(string(call.ResponseTrace), "..."), trimTracesTo),
+		ResponseBodyPreview:   preview,
+		ResponseBodyTruncated: truncated,
+		ElapsedMS:             int((call.EndTime.Sub(call.StartTime)) / time.Millisecond),
+		Resthook:              resthook,
+		StatusCode:            statusCode,
+		BodyIgnored:           len(call.ResponseBody) > 0 && !call.ValidJSON,
+	}
+}
+
+// truncates the given body to at most the given number of bytes without splitting a character
+func truncateBody(body string, limit int) (string, bool) {
+	if len(body) <= limit {
+		return body, false
+	}
+	for limit > 0 && !utf8.RuneStart(body[limit]) {
+		limit--
+	}
+	return body[:limit], true
+}
+
+//------------------------------------------------------------------------------------------
+// JSON Encoding / Decoding
+//------------------------------------------------------------------------------------------
+
+type webhookCalledEnvelope WebhookCalledEvent
+
+// UnmarshalJSON unmarshals this event from the given JSON
+func (e *WebhookCalledEvent) UnmarshalJSON(data []byte) error {
+	if err := utils.UnmarshalAndValidate(data, (*webhookCalledEnvelope)(e)); err != nil {
+		return err
+	}
+
+	// older events included the response body in the response trace so migrate those to a body preview
+	if e.ResponseBodyPreview == "" {
+		if sep := strings.Index(e.Response, "\r\n\r\n"); sep >= 0 && sep+4 < len(e.Response) {
+			body := e.Response[sep+4:]
+			e.Response = e.Response[:sep+4]
+			e.ResponseBodyPreview, e.ResponseBodyTruncated = truncateBody(body, responseBodyPreviewBytes)
+		}
 	}
+	return nil
 }
@@ -0,0 +1,93 @@
+package flows
+
+import (
+	"container/list"
+)
+
+// ExpressionCache is a least-recently-used cache of evaluated templates. Because evaluation results depend on session
+// state, the engine clears the cache whenever a new step is created or that state might have changed. A nil cache is
+// valid and caches nothing.
+type ExpressionCache struct {
+	size   int
+	items  map[string]*list.Element
+	order  *list.List
+	hits   int
+	misses int
+}
+
+type cachedExpression struct {
+	key   string
+	value string
+	err   error
+}
+
+// NewExpressionCache creates a new expression cache with the given maximum number of entries, or returns nil if size
+// isn't positive
+func NewExpressionCache(size int) *ExpressionCache {
+	if size <= 0 {
+		return nil
+	}
+	return &ExpressionCache{size: size, items: make(map[string]*list.Element, size), order: list.New()}
+}
+
+// Lookup returns the cached result of evaluating the given template, or calls evaluate and caches its result if there
+// isn't one, evicting the least recently used entry if the cache is full
+func (c *ExpressionCache) Lookup(key string, evaluate func() (string, error)) (string, error) {
+	if c == nil {
+		return evaluate()
+	}
+
+	if elem, found := c.items[key]; found {
+		c.hits++
+		c.order.MoveToFront(elem)
+		cached := elem.Value.(*cachedExpression)
+		return cached.value, cached.err
+	}
+
+	c.misses++
+	value, err := evaluate()
+
+	if c.order.Len() >= c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cachedExpression).key)
+	}
+
+	c.items[key] = c.order.PushFront(&cachedExpression{key: key, value: value, err: err})
+
+	return value, err
+}
+
+// Clear removes all entries from the cache
+func (c *ExpressionCache) Clear() {
+	if c == nil || c.order.Len() == 0 {
+		return
+	}
+
+	c.items = make(map[string]*list.Element, c.size)
+	c.order.Init()
+}
+
+// Len returns the number of entries in the cache
+func (c *ExpressionCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	return c.order.Len()
+}
+
+// Hits returns the number of lookups which found a cached result
+func (c *ExpressionCache) Hits() int {
+	if c == nil {
+		return 0
+	}
+	return c.hits
+}
+
+// Misses returns the number of lookups which didn't find a cached result
+func (c *ExpressionCache) Misses() int {
+	if c == nil {
+		return 0
+	}
+	return c.misses
+}
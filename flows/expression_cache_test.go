@@ -0,0 +1,72 @@
+package flows_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/flows"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpressionCache(t *testing.T) {
+	evaluations := 0
+	evaluate := func(value string, err error) func() (string, error) {
+		return func() (string, error) {
+			evaluations++
+			return value, err
+		}
+	}
+
+	// a nil cache is valid but always evaluates
+	var cache *flows.ExpressionCache
+	assert.Nil(t, flows.NewExpressionCache(0))
+
+	val, err := cache.Lookup("@foo", evaluate("foo", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", val)
+	cache.Lookup("@foo", evaluate("foo", nil))
+	assert.Equal(t, 2, evaluations)
+	assert.Equal(t, 0, cache.Len())
+	cache.Clear()
+
+	cache = flows.NewExpressionCache(2)
+	evaluations = 0
+
+	val, err = cache.Lookup("@foo", evaluate("foo", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", val)
+
+	// errors are cached too
+	val, err = cache.Lookup("@bar", evaluate("", errors.New("boom")))
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, "", val)
+
+	val, _ = cache.Lookup("@foo", evaluate("xxx", nil))
+	assert.Equal(t, "foo", val)
+	_, err = cache.Lookup("@bar", evaluate("xxx", nil))
+	assert.EqualError(t, err, "boom")
+
+	assert.Equal(t, 2, evaluations)
+	assert.Equal(t, 2, cache.Len())
+	assert.Equal(t, 2, cache.Hits())
+	assert.Equal(t, 2, cache.Misses())
+
+	// cache is full so adding another entry evicts the least recently used
+	cache.Lookup("@foo", evaluate("xxx", nil))
+	cache.Lookup("@baz", evaluate("baz", nil))
+	assert.Equal(t, 2, cache.Len())
+
+	val, _ = cache.Lookup("@foo", evaluate("xxx", nil))
+	assert.Equal(t, "foo", val)
+	val, err = cache.Lookup("@bar", evaluate("bar", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", val)
+
+	// clearing removes everything
+	cache.Clear()
+	assert.Equal(t, 0, cache.Len())
+
+	val, _ = cache.Lookup("@foo", evaluate("new", nil))
+	assert.Equal(t, "new", val)
+}
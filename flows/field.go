@@ -9,6 +9,8 @@ import (
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/utils"
+
+	"github.com/pkg/errors"
 )
 
 // Field represents a contact field
@@ -54,6 +56,23 @@ func NewValue(text types.XText, datetime *types.XDateTime, number *types.XNumber
 	}
 }
 
+// returns a deep copy of this value
+func (v *Value) clone() *Value {
+	if v == nil {
+		return nil
+	}
+	clone := *v
+	if v.Datetime != nil {
+		datetime := *v.Datetime
+		clone.Datetime = &datetime
+	}
+	if v.Number != nil {
+		number := *v.Number
+		clone.Number = &number
+	}
+	return &clone
+}
+
 // Equals determines whether two values are equal
 func (v *Value) Equals(o *Value) bool {
 	if v == nil && o == nil {
@@ -69,6 +88,27 @@ func (v *Value) Equals(o *Value) bool {
 	return v.Text.Equals(o.Text) && dateEqual && numEqual && v.State == o.State && v.District == o.District && v.Ward == o.Ward
 }
 
+// returns whether this value has a value of the given field type
+func (v *Value) hasType(fieldType assets.FieldType) bool {
+	switch fieldType {
+	case assets.FieldTypeNumber:
+		return v.Number != nil
+	case assets.FieldTypeDatetime:
+		return v.Datetime != nil
+	case assets.FieldTypeState:
+		return v.State != ""
+	case assets.FieldTypeDistrict:
+		return v.District != ""
+	case assets.FieldTypeWard:
+		return v.Ward != ""
+	}
+	return true
+}
+
+// ErrFieldTypeMismatch is the error returned (wrapped) when setting a field to a value which doesn't have a value of
+// the field's type
+var ErrFieldTypeMismatch = errors.New("value doesn't match field type")
+
 // FieldValue represents a field and a set of values for that field
 type FieldValue struct {
 	field *Field
@@ -80,6 +120,14 @@ func NewFieldValue(field *Field, value *Value) *FieldValue {
 	return &FieldValue{field: field, Value: value}
 }
 
+// returns a deep copy of this field value, sharing only the field asset
+func (v *FieldValue) clone() *FieldValue {
+	if v == nil {
+		return nil
+	}
+	return NewFieldValue(v.field, v.Value.clone())
+}
+
 // ToXValue returns a representation of this object for use in expressions
 func (v *FieldValue) ToXValue(env envs.Environment) types.XValue {
 	// the typed value of no value is nil
@@ -150,6 +198,9 @@ func (v *FieldValue) QueryValue() interface{} {
 	return nil
 }
 
+// the value used in place of field values when they are redacted
+const redactedFieldValue = "[redacted]"
+
 // FieldValues is the set of all field values for a contact
 type FieldValues map[string]*FieldValue
 
@@ -173,13 +224,43 @@ func NewFieldValues(a SessionAssets, values map[string]*Value, missing assets.Mi
 	return fieldValues
 }
 
-// Clone returns a clone of this set of field values
-func (f FieldValues) clone() FieldValues {
-	clone := make(FieldValues, len(f))
+// Snapshot returns a deep copy of this set of field values which shares no state with the original
+func (f FieldValues) Snapshot() FieldValues {
+	snapshot := make(FieldValues, len(f))
 	for k, v := range f {
-		clone[k] = v
+		snapshot[k] = v.clone()
 	}
-	return clone
+	return snapshot
+}
+
+// Changed returns the sorted keys of fields whose values differ between the given earlier snapshot and this set of
+// field values. Fields which are absent or have an empty value are considered equivalent.
+func (f FieldValues) Changed(since FieldValues) []string {
+	keys := make(map[string]bool, len(f))
+	for k := range f {
+		keys[k] = true
+	}
+	for k := range since {
+		keys[k] = true
+	}
+
+	changed := make([]string, 0)
+	for k := range keys {
+		if !nonEmptyValue(f[k]).Equals(nonEmptyValue(since[k])) {
+			changed = append(changed, k)
+		}
+	}
+
+	sort.Strings(changed)
+	return changed
+}
+
+// returns the value of the given field value, or nil if it is nil or has empty text
+func nonEmptyValue(v *FieldValue) *Value {
+	if v == nil || v.Value == nil || v.Text.Empty() {
+		return nil
+	}
+	return v.Value
 }
 
 // Get gets the value set for the given field
@@ -282,6 +363,9 @@ func (f FieldValues) Context(env envs.Environment) map[string]types.XValue {
 
 	for k, v := range f {
 		val := v.ToXValue(env)
+		if !utils.IsNil(val) && env.RedactionPolicy().RedactsContactDetails() {
+			val = types.NewXText(redactedFieldValue)
+		}
 		entries[string(k)] = val
 
 		if !utils.IsNil(val) {
@@ -335,6 +419,12 @@ func (s *FieldAssets) Get(key string) *Field {
 	return s.byKey[key]
 }
 
+// GetByKey returns the contact field with the given key after normalizing it the same way as field keys, e.g.
+// "Date Of Birth" will match the field with key "date_of_birth"
+func (s *FieldAssets) GetByKey(key string) *Field {
+	return s.byKey[utils.Snakify(key)]
+}
+
 // All returns all the fields in this set
 func (s *FieldAssets) All() []*Field {
 	return s.all
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/nyaruka/goflow/assets"
+	atypes "github.com/nyaruka/goflow/assets/static/types"
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/flows"
@@ -50,6 +51,80 @@ func TestFieldValues(t *testing.T) {
 	}), flows.Context(env, fieldVals))
 }
 
+// creates session assets for field value tests and returns them with the gender and age fields
+func createFieldValueTestAssets(t *testing.T) (flows.SessionAssets, *flows.Field, *flows.Field) {
+	session, _, err := test.CreateTestSession("http://localhost", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	fields := session.Assets().Fields()
+	return session.Assets(), fields.Get("gender"), fields.Get("age")
+}
+
+// creates a field value with only a text value
+func newValue(text string) *flows.Value {
+	return flows.NewValue(types.NewXText(text), nil, nil, "", "", "")
+}
+
+func TestFieldValuesSnapshot(t *testing.T) {
+	sa, gender, age := createFieldValueTestAssets(t)
+
+	num := types.NewXNumberFromInt(33)
+	original := flows.NewFieldValues(sa, map[string]*flows.Value{
+		"gender": newValue("Male"),
+		"age":    flows.NewValue(types.NewXText("33"), nil, &num, "", "", ""),
+	}, assets.PanicOnMissing)
+
+	snapshot := original.Snapshot()
+	assert.Equal(t, original, snapshot)
+
+	// changing the original doesn't affect the snapshot
+	original.Set(gender, newValue("Female"))
+	assert.Equal(t, types.NewXText("Female"), original.Get(gender).Text)
+	assert.Equal(t, types.NewXText("Male"), snapshot.Get(gender).Text)
+
+	// including changes to values in place
+	original.Get(age).Text = types.NewXText("34")
+	*original.Get(age).Number = types.NewXNumberFromInt(34)
+	assert.Equal(t, types.NewXText("33"), snapshot.Get(age).Text)
+	assert.Equal(t, types.NewXNumberFromInt(33), *snapshot.Get(age).Number)
+
+	// and changing the snapshot doesn't affect the original
+	snapshot.Set(gender, newValue("Other"))
+	snapshot.Set(age, nil)
+	assert.Equal(t, types.NewXText("Female"), original.Get(gender).Text)
+	assert.Equal(t, types.NewXText("34"), original.Get(age).Text)
+}
+
+func TestFieldValuesChanged(t *testing.T) {
+	sa, gender, age := createFieldValueTestAssets(t)
+
+	before := flows.NewFieldValues(sa, map[string]*flows.Value{
+		"gender": newValue("Male"),
+		"age":    newValue("33"),
+	}, assets.PanicOnMissing)
+
+	// no changes
+	after := before.Snapshot()
+	assert.Equal(t, []string{}, after.Changed(before))
+
+	// one change
+	after.Set(age, newValue("34"))
+	assert.Equal(t, []string{"age"}, after.Changed(before))
+
+	// clearing a value is a change
+	after.Set(gender, nil)
+	assert.Equal(t, []string{"age", "gender"}, after.Changed(before))
+
+	// absent keys and empty values are equivalent
+	after = before.Snapshot()
+	after["gender"] = flows.NewFieldValue(gender, newValue(""))
+	before["gender"] = nil
+	assert.Equal(t, []string{}, after.Changed(before))
+	delete(before, "gender")
+	assert.Equal(t, []string{}, after.Changed(before))
+	assert.Equal(t, []string{}, before.Changed(after))
+}
+
 func TestValues(t *testing.T) {
 	num1 := types.RequireXNumberFromString("23")
 	num2 := types.RequireXNumberFromString("23")
@@ -71,3 +146,29 @@ func TestValues(t *testing.T) {
 	assert.False(t, v6.Equals(v4))
 	assert.True(t, v6.Equals(v6))
 }
+
+func TestFieldAssetsGetByKey(t *testing.T) {
+	fields := flows.NewFieldAssets([]assets.Field{
+		atypes.NewField("d66a7823-eada-40e5-9a3a-57239d4690bf", "age", "Age", assets.FieldTypeNumber),
+		atypes.NewField("f1b5aea6-6586-41c7-9020-1a6326cc6565", "date_of_birth", "Date Of Birth", assets.FieldTypeDatetime),
+	})
+
+	// exact match
+	age := fields.GetByKey("age")
+	require.NotNil(t, age)
+	assert.Equal(t, "Age", age.Name())
+
+	// keys are normalized before matching
+	dob := fields.GetByKey("Date Of Birth")
+	require.NotNil(t, dob)
+	assert.Equal(t, "date_of_birth", dob.Key())
+	assert.Equal(t, dob, fields.GetByKey("DATE_OF_BIRTH"))
+	assert.Equal(t, age, fields.GetByKey(" Age "))
+
+	// fields are found by key and not by UUID
+	assert.Equal(t, assets.FieldUUID("f1b5aea6-6586-41c7-9020-1a6326cc6565"), dob.UUID())
+	assert.Nil(t, fields.GetByKey("f1b5aea6-6586-41c7-9020-1a6326cc6565"))
+
+	// unknown key
+	assert.Nil(t, fields.GetByKey("gender"))
+}
@@ -0,0 +1,130 @@
+// Package graph builds graphs of the dependencies between flows.
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/actions"
+
+	"github.com/pkg/errors"
+)
+
+// FlowNode is a flow in a dependency graph
+type FlowNode struct {
+	UUID        assets.FlowUUID `json:"uuid"`
+	Name        string          `json:"name"`
+	SpecVersion string          `json:"spec_version"`
+}
+
+// FlowEdge is a subflow reference from one flow to another in a dependency graph
+type FlowEdge struct {
+	ParentUUID assets.FlowUUID  `json:"parent_uuid"`
+	ChildUUID  assets.FlowUUID  `json:"child_uuid"`
+	ActionUUID flows.ActionUUID `json:"action_uuid"`
+}
+
+// DependencyGraph is the graph of flows reachable from a root flow via subflows
+type DependencyGraph struct {
+	Nodes   []FlowNode              `json:"nodes"`
+	Edges   []FlowEdge              `json:"edges"`
+	Missing []*assets.FlowReference `json:"missing"`
+}
+
+// BuildDependencyGraph builds the graph of flows which can be entered as subflows from the given root flow. Subflows
+// which can't be loaded are recorded as missing rather than failing the whole graph.
+func BuildDependencyGraph(sa flows.SessionAssets, rootUUID assets.FlowUUID) (*DependencyGraph, error) {
+	b := newBuilder(sa)
+	if err := b.visit(rootUUID); err != nil {
+		return nil, err
+	}
+	return b.graph, nil
+}
+
+// BuildCompleteDependencyGraph builds the graph of all flows in the given assets and the subflows they can enter
+func BuildCompleteDependencyGraph(sa flows.SessionAssets) (*DependencyGraph, error) {
+	b := newBuilder(sa)
+	for _, uuid := range sa.Flows().AllUUIDs() {
+		if err := b.visit(uuid); err != nil {
+			return nil, err
+		}
+	}
+	return b.graph, nil
+}
+
+type builder struct {
+	sa    flows.SessionAssets
+	graph *DependencyGraph
+	seen  map[assets.FlowUUID]bool
+}
+
+func newBuilder(sa flows.SessionAssets) *builder {
+	return &builder{
+		sa:    sa,
+		graph: &DependencyGraph{Nodes: make([]FlowNode, 0), Edges: make([]FlowEdge, 0), Missing: make([]*assets.FlowReference, 0)},
+		seen:  make(map[assets.FlowUUID]bool),
+	}
+}
+
+// visits the given flow and recursively any flows it enters
+func (b *builder) visit(uuid assets.FlowUUID) error {
+	if b.seen[uuid] {
+		return nil
+	}
+	b.seen[uuid] = true
+
+	flow, err := b.sa.Flows().Get(uuid)
+	if err != nil {
+		return errors.Wrapf(err, "unable to load flow '%s'", uuid)
+	}
+
+	b.graph.Nodes = append(b.graph.Nodes, FlowNode{UUID: flow.UUID(), Name: flow.Name(), SpecVersion: flow.SpecVersion().String()})
+
+	for _, node := range flow.Nodes() {
+		for _, action := range node.Actions() {
+			enter, isEnter := action.(*actions.EnterFlowAction)
+			if !isEnter {
+				continue
+			}
+
+			b.graph.Edges = append(b.graph.Edges, FlowEdge{ParentUUID: flow.UUID(), ChildUUID: enter.Flow.UUID, ActionUUID: enter.UUID()})
+
+			if b.seen[enter.Flow.UUID] {
+				continue
+			}
+
+			// a missing subflow is a missing dependency of this flow, not a reason to give up on the rest of the graph
+			if _, err := b.sa.Flows().Get(enter.Flow.UUID); err != nil {
+				b.seen[enter.Flow.UUID] = true
+				b.graph.Missing = append(b.graph.Missing, enter.Flow)
+				continue
+			}
+
+			if err := b.visit(enter.Flow.UUID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ToDOT returns this graph in the Graphviz DOT language
+func (g *DependencyGraph) ToDOT() string {
+	b := &strings.Builder{}
+	b.WriteString("digraph flows {\n")
+
+	for _, n := range g.Nodes {
+		b.WriteString(fmt.Sprintf("  \"%s\" [label=%q];\n", n.UUID, fmt.Sprintf("%s (v%s)", n.Name, n.SpecVersion)))
+	}
+	for _, m := range g.Missing {
+		b.WriteString(fmt.Sprintf("  \"%s\" [label=%q, style=dashed];\n", m.UUID, fmt.Sprintf("%s (missing)", m.Name)))
+	}
+	for _, e := range g.Edges {
+		b.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\";\n", e.ParentUUID, e.ChildUUID))
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
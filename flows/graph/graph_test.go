@@ -0,0 +1,185 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows/engine"
+	"github.com/nyaruka/goflow/flows/graph"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var assetsJSON = `{
+	"flows": [
+		{
+			"uuid": "76f0a02f-3b75-4b86-9064-e9195e1b3a02",
+			"name": "Parent",
+			"spec_version": "13.1.0",
+			"language": "eng",
+			"type": "messaging",
+			"revision": 3,
+			"nodes": [
+				{
+					"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507",
+					"actions": [
+						{
+							"uuid": "f01d693b-2af2-49fb-9e38-146eb00937e9",
+							"type": "enter_flow",
+							"flow": {"uuid": "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", "name": "Child"}
+						}
+					],
+					"exits": [{"uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6"}]
+				}
+			]
+		},
+		{
+			"uuid": "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d",
+			"name": "Child",
+			"spec_version": "13.1.0",
+			"language": "eng",
+			"type": "messaging",
+			"revision": 1,
+			"nodes": [
+				{
+					"uuid": "9a43d8f5-a4d0-4d8e-9b5c-4ff0fd5be7ca",
+					"actions": [
+						{
+							"uuid": "2f0e1e7b-5c55-4b3c-bd3a-1ff7e3b2d1a4",
+							"type": "enter_flow",
+							"flow": {"uuid": "0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f", "name": "Grandchild"}
+						}
+					],
+					"exits": [{"uuid": "5f4b6d8c-1e2a-4b3c-8d9e-0f1a2b3c4d5e"}]
+				}
+			]
+		},
+		{
+			"uuid": "0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f",
+			"name": "Grandchild",
+			"spec_version": "13.1.0",
+			"language": "eng",
+			"type": "messaging",
+			"revision": 7,
+			"nodes": []
+		}
+	]
+}`
+
+func TestBuildDependencyGraph(t *testing.T) {
+	source, err := static.NewSource([]byte(assetsJSON))
+	require.NoError(t, err)
+
+	sa, err := engine.NewSessionAssets(envs.NewBuilder().Build(), source, nil)
+	require.NoError(t, err)
+
+	g, err := graph.BuildDependencyGraph(sa, assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []graph.FlowNode{
+		{UUID: "76f0a02f-3b75-4b86-9064-e9195e1b3a02", Name: "Parent", SpecVersion: "13.1.0"},
+		{UUID: "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", Name: "Child", SpecVersion: "13.1.0"},
+		{UUID: "0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f", Name: "Grandchild", SpecVersion: "13.1.0"},
+	}, g.Nodes)
+	assert.Equal(t, []graph.FlowEdge{
+		{ParentUUID: "76f0a02f-3b75-4b86-9064-e9195e1b3a02", ChildUUID: "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", ActionUUID: "f01d693b-2af2-49fb-9e38-146eb00937e9"},
+		{ParentUUID: "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", ChildUUID: "0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f", ActionUUID: "2f0e1e7b-5c55-4b3c-bd3a-1ff7e3b2d1a4"},
+	}, g.Edges)
+	assert.Equal(t, []*assets.FlowReference{}, g.Missing)
+
+	assert.Equal(t, `digraph flows {
+  "76f0a02f-3b75-4b86-9064-e9195e1b3a02" [label="Parent (v13.1.0)"];
+  "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d" [label="Child (v13.1.0)"];
+  "0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f" [label="Grandchild (v13.1.0)"];
+  "76f0a02f-3b75-4b86-9064-e9195e1b3a02" -> "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d";
+  "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d" -> "0fad12a0-d53c-4ba0-811c-6bfde8aa9f8f";
+}
+`, g.ToDOT())
+
+	// a missing root flow is an error
+	_, err = graph.BuildDependencyGraph(sa, assets.FlowUUID("ddba5842-252f-4a20-b901-08696fc773e2"))
+	assert.EqualError(t, err, "unable to load flow 'ddba5842-252f-4a20-b901-08696fc773e2': no such flow with UUID 'ddba5842-252f-4a20-b901-08696fc773e2'")
+}
+
+func TestBuildCompleteDependencyGraph(t *testing.T) {
+	source, err := static.NewSource([]byte(assetsJSON))
+	require.NoError(t, err)
+
+	sa, err := engine.NewSessionAssets(envs.NewBuilder().Build(), source, nil)
+	require.NoError(t, err)
+
+	// starting from every flow in the source gives us the same graph as starting from the parent flow
+	g, err := graph.BuildCompleteDependencyGraph(sa)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, len(g.Nodes))
+	assert.Equal(t, 2, len(g.Edges))
+	assert.Equal(t, assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"), g.Nodes[0].UUID)
+}
+
+func TestBuildDependencyGraphWithMissingSubflow(t *testing.T) {
+	source, err := static.NewSource([]byte(`{
+		"flows": [
+			{
+				"uuid": "76f0a02f-3b75-4b86-9064-e9195e1b3a02",
+				"name": "Parent",
+				"spec_version": "13.1.0",
+				"language": "eng",
+				"type": "messaging",
+				"nodes": [
+					{
+						"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507",
+						"actions": [
+							{
+								"uuid": "f01d693b-2af2-49fb-9e38-146eb00937e9",
+								"type": "enter_flow",
+								"flow": {"uuid": "ddba5842-252f-4a20-b901-08696fc773e2", "name": "Deleted"}
+							},
+							{
+								"uuid": "b8e8f5b1-0f23-4c5e-9a5d-3b8e6d5f0c1a",
+								"type": "enter_flow",
+								"flow": {"uuid": "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", "name": "Child"}
+							}
+						],
+						"exits": [{"uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6"}]
+					}
+				]
+			},
+			{
+				"uuid": "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d",
+				"name": "Child",
+				"spec_version": "13.1.0",
+				"language": "eng",
+				"type": "messaging",
+				"nodes": []
+			}
+		]
+	}`))
+	require.NoError(t, err)
+
+	sa, err := engine.NewSessionAssets(envs.NewBuilder().Build(), source, nil)
+	require.NoError(t, err)
+
+	// the missing subflow is recorded and the rest of the graph is still built
+	g, err := graph.BuildDependencyGraph(sa, assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []graph.FlowNode{
+		{UUID: "76f0a02f-3b75-4b86-9064-e9195e1b3a02", Name: "Parent", SpecVersion: "13.1.0"},
+		{UUID: "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", Name: "Child", SpecVersion: "13.1.0"},
+	}, g.Nodes)
+	assert.Equal(t, 2, len(g.Edges))
+	assert.Equal(t, []*assets.FlowReference{assets.NewFlowReference("ddba5842-252f-4a20-b901-08696fc773e2", "Deleted")}, g.Missing)
+
+	assert.Equal(t, `digraph flows {
+  "76f0a02f-3b75-4b86-9064-e9195e1b3a02" [label="Parent (v13.1.0)"];
+  "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d" [label="Child (v13.1.0)"];
+  "ddba5842-252f-4a20-b901-08696fc773e2" [label="Deleted (missing)", style=dashed];
+  "76f0a02f-3b75-4b86-9064-e9195e1b3a02" -> "ddba5842-252f-4a20-b901-08696fc773e2";
+  "76f0a02f-3b75-4b86-9064-e9195e1b3a02" -> "b7cf0d83-f1c9-411c-96fd-c511a4cfa86d";
+}
+`, g.ToDOT())
+}
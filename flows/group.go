@@ -150,6 +150,7 @@ func (l GroupList) ToXValue(env envs.Environment) types.XValue {
 type GroupAssets struct {
 	all    []*Group
 	byUUID map[assets.GroupUUID]*Group
+	byName map[string]*Group
 }
 
 // NewGroupAssets creates a new set of group assets
@@ -158,6 +159,7 @@ func NewGroupAssets(env envs.Environment, fields *FieldAssets, groups []assets.G
 	s := &GroupAssets{
 		all:    make([]*Group, 0, len(groups)),
 		byUUID: make(map[assets.GroupUUID]*Group, len(groups)),
+		byName: make(map[string]*Group, len(groups)),
 	}
 	for _, asset := range groups {
 		group, err := NewGroup(env, fields, asset)
@@ -166,6 +168,12 @@ func NewGroupAssets(env envs.Environment, fields *FieldAssets, groups []assets.G
 		} else {
 			s.all = append(s.all, group)
 			s.byUUID[group.UUID()] = group
+
+			// if names collide, the first group wins
+			key := normalizeAssetName(group.Name())
+			if _, exists := s.byName[key]; !exists {
+				s.byName[key] = group
+			}
 		}
 	}
 	return s, broken
@@ -181,6 +189,12 @@ func (s *GroupAssets) Get(uuid assets.GroupUUID) *Group {
 	return s.byUUID[uuid]
 }
 
+// GetByName returns the group with the given name, ignoring case and surrounding whitespace, or nil if there isn't one.
+// If more than one group has that name, the first one is returned.
+func (s *GroupAssets) GetByName(name string) *Group {
+	return s.byName[normalizeAssetName(name)]
+}
+
 // FindByName looks for a group with the given name (case-insensitive)
 func (s *GroupAssets) FindByName(name string) *Group {
 	name = strings.ToLower(name)
@@ -191,3 +205,8 @@ func (s *GroupAssets) FindByName(name string) *Group {
 	}
 	return nil
 }
+
+// normalizes an asset name for case-insensitive lookups
+func normalizeAssetName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -41,7 +41,7 @@ func TestGroupList(t *testing.T) {
 				"query": "gender = \"M\""
 			},
 			{
-				"uuid": "f4f4b78e-f072-42e2-987d-f5c13da3166d",
+				"uuid": "5a93f0e4-d7d4-4ad5-a7d0-ab61d8b8a1b1",
 				"name": "Broken",
 				"query": "xyz = \"X\""
 			}
@@ -86,3 +86,43 @@ func TestGroupList(t *testing.T) {
 	// check use in expressions
 	test.AssertXEqual(t, types.NewXArray(testers.ToXValue(env), males.ToXValue(env)), groups.ToXValue(env))
 }
+
+func TestGroupAssetsGetByName(t *testing.T) {
+	env := envs.NewBuilder().Build()
+
+	source, err := static.NewSource([]byte(`{
+		"groups": [
+			{
+				"uuid": "e25852ea-b014-4ac1-9982-d6dcb0c2a1d5",
+				"name": "Customers"
+			},
+			{
+				"uuid": "990e1392-1f49-40c5-9662-f39609324bf9",
+				"name": "Beta Testers"
+			},
+			{
+				"uuid": "0ec97956-c451-48a0-a180-1a4ab4ec2ad0",
+				"name": "customers"
+			}
+		]
+	}`))
+	require.NoError(t, err)
+
+	sa, err := engine.NewSessionAssets(env, source, nil)
+	require.NoError(t, err)
+
+	testers := sa.Groups().Get("990e1392-1f49-40c5-9662-f39609324bf9")
+
+	assert.Equal(t, testers, sa.Groups().GetByName("Beta Testers"))
+	assert.Equal(t, testers, sa.Groups().GetByName("beta TESTERS"))
+	assert.Equal(t, testers, sa.Groups().GetByName("  Beta Testers\n"))
+	assert.Equal(t, testers, sa.Groups().FindByName("BETA TESTERS"))
+	assert.Nil(t, sa.Groups().FindByName("  Beta Testers\n")) // only GetByName ignores whitespace
+	assert.Nil(t, sa.Groups().GetByName("Beta"))
+	assert.Nil(t, sa.Groups().GetByName(""))
+
+	// if names collide, the first group is returned
+	customers := sa.Groups().Get("e25852ea-b014-4ac1-9982-d6dcb0c2a1d5")
+	assert.Equal(t, customers, sa.Groups().GetByName("CUSTOMERS"))
+	assert.Equal(t, customers, sa.Groups().FindByName("CUSTOMERS"))
+}
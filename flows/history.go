@@ -1,5 +1,11 @@
 package flows
 
+import (
+	"time"
+
+	"github.com/nyaruka/goflow/assets"
+)
+
 // SessionHistory provides information about the sessions that caused this session
 type SessionHistory struct {
 	ParentUUID          SessionUUID `json:"parent_uuid"`
@@ -29,6 +35,26 @@ func NewChildHistory(parent Session) *SessionHistory {
 	return parent.History().Advance(parent.UUID(), sessionReceivedInput(parent))
 }
 
+// FlowHistoryEntry records a flow being entered during a session
+type FlowHistoryEntry struct {
+	FlowUUID  assets.FlowUUID
+	EnteredOn time.Time
+	ExitedOn  *time.Time
+}
+
+// NewFlowHistory creates the history of flows entered during a session from its runs
+func NewFlowHistory(runs []FlowRun) []*FlowHistoryEntry {
+	entries := make([]*FlowHistoryEntry, len(runs))
+	for i, run := range runs {
+		entries[i] = &FlowHistoryEntry{
+			FlowUUID:  run.FlowReference().UUID,
+			EnteredOn: run.CreatedOn(),
+			ExitedOn:  run.ExitedOn(),
+		}
+	}
+	return entries
+}
+
 // looks through a session's events to see if it received input
 func sessionReceivedInput(s Session) bool {
 	for _, r := range s.Runs() {
@@ -2,11 +2,14 @@ package flows
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/utils"
+
+	"github.com/pkg/errors"
 )
 
 type baseExtractedItem struct {
@@ -20,13 +23,15 @@ type baseExtractedItem struct {
 type ExtractedTemplate struct {
 	baseExtractedItem
 
+	Field    string
 	Template string
 }
 
 // NewExtractedTemplate creates a new extracted template
-func NewExtractedTemplate(n Node, a Action, r Router, l envs.Language, t string) ExtractedTemplate {
+func NewExtractedTemplate(n Node, a Action, r Router, f string, l envs.Language, t string) ExtractedTemplate {
 	return ExtractedTemplate{
 		baseExtractedItem: baseExtractedItem{Node: n, Action: a, Router: r, Language: l},
+		Field:             f,
 		Template:          t,
 	}
 }
@@ -46,6 +51,22 @@ func NewExtractedReference(n Node, a Action, r Router, l envs.Language, ref asse
 	}
 }
 
+// DeduplicateExtractedReferences returns the given references with only the first occurrence of each asset in each
+// language, so that references only found in translations are kept
+func DeduplicateExtractedReferences(refs []ExtractedReference) []ExtractedReference {
+	seen := make(map[string]bool, len(refs))
+	deduped := make([]ExtractedReference, 0, len(refs))
+
+	for _, r := range refs {
+		key := string(r.Language) + "|" + assets.ReferenceKey(r.Reference)
+		if !seen[key] {
+			seen[key] = true
+			deduped = append(deduped, r)
+		}
+	}
+	return deduped
+}
+
 // Inspection contains the results of flow inspection
 type Inspection struct {
 	Dependencies []Dependency  `json:"dependencies"`
@@ -71,6 +92,20 @@ func NewResultInfo(name string, categories []string) *ResultInfo {
 	}
 }
 
+// result keys must be valid names in expressions so that they can be used as @results.<key>
+var resultKeyRegex = regexp.MustCompile(`^[\p{L}_][\p{L}\p{N}_]*$`)
+
+// Validate checks that this result has a name, and that its key can be used in expressions
+func (r *ResultInfo) Validate() error {
+	if r.Name == "" {
+		return errors.New("result name can't be empty")
+	}
+	if !resultKeyRegex.MatchString(r.Key) {
+		return errors.Errorf("result name '%s' doesn't have a valid key for use in expressions", r.Name)
+	}
+	return nil
+}
+
 func (r *ResultInfo) String() string {
 	return fmt.Sprintf("key=%s|name=%s|categories=%s", r.Key, r.Name, strings.Join(r.Categories, ","))
 }
@@ -63,3 +63,27 @@ func TestNewResultSpecs(t *testing.T) {
 
 	assert.Equal(t, `key=response_1|name=Response 1|categories=Red,Green`, flows.NewResultInfo("Response 1", []string{"Red", "Green"}).String())
 }
+
+func TestResultInfoValidate(t *testing.T) {
+	tcs := []struct {
+		name  string
+		error string
+	}{
+		{"score_1", ""},
+		{"Score", ""},    // key is score
+		{"score-1", ""},  // key is score_1
+		{"Résultat", ""}, // non-ASCII letters are fine in expressions
+		{"1score", "result name '1score' doesn't have a valid key for use in expressions"},
+		{"2 Factor", "result name '2 Factor' doesn't have a valid key for use in expressions"},
+		{"", "result name can't be empty"},
+	}
+
+	for _, tc := range tcs {
+		err := flows.NewResultInfo(tc.name, nil).Validate()
+		if tc.error == "" {
+			assert.NoError(t, err, "unexpected error for name '%s'", tc.name)
+		} else {
+			assert.EqualError(t, err, tc.error, "error mismatch for name '%s'", tc.name)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package inputs
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/types"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/utils"
+)
+
+func init() {
+	registerType(TypeDigits, readDigitsInput)
+}
+
+// TypeDigits is a constant for digits entered on an IVR call
+const TypeDigits string = "digits"
+
+// DigitsInput is a sequence of digits entered by the contact on an IVR call which can be used as input
+type DigitsInput struct {
+	baseInput
+
+	digits string
+}
+
+// NewDigits creates a new user input based on digits entered on the given channel
+func NewDigits(assets flows.SessionAssets, uuid flows.InputUUID, channel *assets.ChannelReference, digits string, createdOn time.Time) *DigitsInput {
+	var ch *flows.Channel
+	if channel != nil {
+		ch = assets.Channels().Get(channel.UUID)
+	}
+
+	return &DigitsInput{
+		baseInput: newBaseInput(TypeDigits, uuid, ch, createdOn),
+		digits:    digits,
+	}
+}
+
+// Digits returns the digits of this input
+func (i *DigitsInput) Digits() string { return i.digits }
+
+// Context returns the properties available in expressions. The digits are also exposed as text so that routers
+// written for message input work the same way.
+func (i *DigitsInput) Context(env envs.Environment) map[string]types.XValue {
+	return map[string]types.XValue{
+		"__default__": types.NewXText(i.digits),
+		"type":        types.NewXText(i.type_),
+		"uuid":        types.NewXText(string(i.uuid)),
+		"created_on":  types.NewXDateTime(i.createdOn),
+		"channel":     flows.Context(env, i.channel),
+		"text":        types.NewXText(i.digits),
+		"digits":      types.NewXText(i.digits),
+	}
+}
+
+var _ flows.Input = (*DigitsInput)(nil)
+
+//------------------------------------------------------------------------------------------
+// JSON Encoding / Decoding
+//------------------------------------------------------------------------------------------
+
+type digitsInputEnvelope struct {
+	baseInputEnvelope
+	Digits string `json:"digits"`
+}
+
+func readDigitsInput(sessionAssets flows.SessionAssets, data json.RawMessage, missing assets.MissingCallback) (flows.Input, error) {
+	e := &digitsInputEnvelope{}
+	err := utils.UnmarshalAndValidate(data, e)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &DigitsInput{
+		digits: e.Digits,
+	}
+
+	if err := i.unmarshal(sessionAssets, &e.baseInputEnvelope, missing); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// MarshalJSON marshals this digits input into JSON
+func (i *DigitsInput) MarshalJSON() ([]byte, error) {
+	e := &digitsInputEnvelope{
+		Digits: i.digits,
+	}
+
+	i.marshal(&e.baseInputEnvelope)
+
+	return jsonx.Marshal(e)
+}
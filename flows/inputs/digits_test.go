@@ -0,0 +1,58 @@
+package inputs_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/types"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/inputs"
+	"github.com/nyaruka/goflow/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigitsInput(t *testing.T) {
+	session, _, err := test.CreateTestSession("", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	env := session.Environment()
+
+	channel := session.Assets().Channels().Get("57f1078f-88aa-46f4-a59a-948a5739c03d")
+
+	input := inputs.NewDigits(
+		session.Assets(),
+		flows.InputUUID("f51d7220-10b3-4faa-a91c-1ae70beaae3e"),
+		assets.NewChannelReference("57f1078f-88aa-46f4-a59a-948a5739c03d", "Nexmo"),
+		"1234",
+		test.MustParseTime("2018-10-22T16:12:30.000123456Z"),
+	)
+	assert.Equal(t, "digits", input.Type())
+	assert.Equal(t, flows.InputUUID("f51d7220-10b3-4faa-a91c-1ae70beaae3e"), input.UUID())
+	assert.Equal(t, channel, input.Channel())
+	assert.Equal(t, "1234", input.Digits())
+
+	// check use in expressions
+	test.AssertXEqual(t, types.NewXObject(map[string]types.XValue{
+		"__default__": types.NewXText("1234"),
+		"type":        types.NewXText("digits"),
+		"uuid":        types.NewXText("f51d7220-10b3-4faa-a91c-1ae70beaae3e"),
+		"channel":     flows.Context(env, channel),
+		"created_on":  types.NewXDateTime(input.CreatedOn()),
+		"text":        types.NewXText("1234"),
+		"digits":      types.NewXText("1234"),
+	}), flows.Context(env, input))
+
+	// check marshaling to JSON
+	marshaled, err := jsonx.Marshal(input)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"digits","uuid":"f51d7220-10b3-4faa-a91c-1ae70beaae3e","channel":{"uuid":"57f1078f-88aa-46f4-a59a-948a5739c03d","name":"My Android Phone"},"created_on":"2018-10-22T16:12:30.000123456Z","digits":"1234"}`, string(marshaled))
+
+	// and reading it back
+	read, err := inputs.ReadInput(session.Assets(), marshaled, assets.PanicOnMissing)
+	require.NoError(t, err)
+	assert.Equal(t, input, read)
+}
@@ -29,22 +29,34 @@ type MsgInput struct {
 	text        string
 	attachments []utils.Attachment
 	externalID  string
+	quickReply  string
 }
 
 // NewMsg creates a new user input based on a message
 func NewMsg(assets flows.SessionAssets, msg *flows.MsgIn, createdOn time.Time) *MsgInput {
 	// load the channel
 	var channel *flows.Channel
+	var country envs.Country
 	if msg.Channel() != nil {
 		channel = assets.Channels().Get(msg.Channel().UUID)
+		if channel != nil {
+			country = channel.Country()
+		}
+	}
+
+	// normalize the URN if possible so that it matches how contact URNs are stored
+	urn := flows.NewContactURN(msg.URN(), nil)
+	if normalized, err := urn.Normalized(country); err == nil {
+		urn = normalized
 	}
 
 	return &MsgInput{
 		baseInput:   newBaseInput(TypeMsg, flows.InputUUID(msg.UUID()), channel, createdOn),
-		urn:         flows.NewContactURN(msg.URN(), nil),
+		urn:         urn,
 		text:        msg.Text(),
 		attachments: msg.Attachments(),
 		externalID:  msg.ExternalID(),
+		quickReply:  msg.QuickReply(),
 	}
 }
 
@@ -58,6 +70,7 @@ func NewMsg(assets flows.SessionAssets, msg *flows.MsgIn, createdOn time.Time) *
 //   text:text -> the text part of the input
 //   attachments:[]text -> any attachments on the input
 //   external_id:text -> the external ID of the input
+//   quick_reply:text -> the payload of the quick reply selected to send the input
 //
 // @context input
 func (i *MsgInput) Context(env envs.Environment) map[string]types.XValue {
@@ -68,20 +81,27 @@ func (i *MsgInput) Context(env envs.Environment) map[string]types.XValue {
 	}
 
 	var urn types.XValue
+	text, formatted := i.text, i.format()
 	if i.urn != nil {
 		urn = i.urn.ToXValue(env)
+
+		// the message text might include the sender's URN
+		if env.RedactionPolicy().RedactsContactDetails() {
+			text, formatted = i.urn.RedactIn(text), i.urn.RedactIn(formatted)
+		}
 	}
 
 	return map[string]types.XValue{
-		"__default__": types.NewXText(i.format()),
+		"__default__": types.NewXText(formatted),
 		"type":        types.NewXText(i.type_),
 		"uuid":        types.NewXText(string(i.uuid)),
 		"created_on":  types.NewXDateTime(i.createdOn),
 		"channel":     flows.Context(env, i.channel),
 		"urn":         urn,
-		"text":        types.NewXText(i.text),
+		"text":        types.NewXText(text),
 		"attachments": types.NewXArray(attachments...),
 		"external_id": types.NewXText(i.externalID),
+		"quick_reply": types.NewXText(i.quickReply),
 	}
 }
 
@@ -108,6 +128,7 @@ type msgInputEnvelope struct {
 	Text        string             `json:"text"`
 	Attachments []utils.Attachment `json:"attachments,omitempty"`
 	ExternalID  string             `json:"external_id,omitempty"`
+	QuickReply  string             `json:"quick_reply,omitempty"`
 }
 
 func readMsgInput(sessionAssets flows.SessionAssets, data json.RawMessage, missing assets.MissingCallback) (flows.Input, error) {
@@ -122,6 +143,7 @@ func readMsgInput(sessionAssets flows.SessionAssets, data json.RawMessage, missi
 		text:        e.Text,
 		attachments: e.Attachments,
 		externalID:  e.ExternalID,
+		quickReply:  e.QuickReply,
 	}
 
 	if err := i.unmarshal(sessionAssets, &e.baseInputEnvelope, missing); err != nil {
@@ -138,6 +160,7 @@ func (i *MsgInput) MarshalJSON() ([]byte, error) {
 		Text:        i.text,
 		Attachments: i.attachments,
 		ExternalID:  i.externalID,
+		QuickReply:  i.quickReply,
 	}
 
 	i.marshal(&e.baseInputEnvelope)
@@ -2,7 +2,6 @@ package inputs_test
 
 import (
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/jsonx"
 	"github.com/nyaruka/gocommon/urns"
@@ -37,12 +36,13 @@ func TestMsgInput(t *testing.T) {
 		},
 	)
 	msg.SetExternalID("ext12345")
+	msg.SetQuickReply("YES")
 
-	input := inputs.NewMsg(session.Assets(), msg, time.Date(2018, 10, 22, 16, 12, 30, 123456, time.UTC))
+	input := inputs.NewMsg(session.Assets(), msg, test.MustParseTime("2018-10-22T16:12:30.000123456Z"))
 	assert.Equal(t, "msg", input.Type())
 	assert.Equal(t, flows.InputUUID("f51d7220-10b3-4faa-a91c-1ae70beaae3e"), input.UUID())
 	assert.Equal(t, channel, input.Channel())
-	assert.Equal(t, time.Date(2018, 10, 22, 16, 12, 30, 123456, time.UTC), input.CreatedOn())
+	assert.Equal(t, test.MustParseTime("2018-10-22T16:12:30.000123456Z"), input.CreatedOn())
 
 	// check use in expressions
 	test.AssertXEqual(t, types.NewXObject(map[string]types.XValue{
@@ -55,10 +55,31 @@ func TestMsgInput(t *testing.T) {
 		"text":        types.NewXText("Hi there!"),
 		"attachments": types.NewXArray(types.NewXText("image/jpg:http://example.com/test.jpg"), types.NewXText("video/mp4:http://example.com/test.mp4")),
 		"external_id": types.NewXText("ext12345"),
+		"quick_reply": types.NewXText("YES"),
 	}), flows.Context(env, input))
 
 	// check marshaling to JSON
 	marshaled, err := jsonx.Marshal(input)
 	assert.NoError(t, err)
-	assert.Equal(t, `{"type":"msg","uuid":"f51d7220-10b3-4faa-a91c-1ae70beaae3e","channel":{"uuid":"57f1078f-88aa-46f4-a59a-948a5739c03d","name":"My Android Phone"},"created_on":"2018-10-22T16:12:30.000123456Z","urn":"tel:+1234567890","text":"Hi there!","attachments":["image/jpg:http://example.com/test.jpg","video/mp4:http://example.com/test.mp4"],"external_id":"ext12345"}`, string(marshaled))
+	assert.Equal(t, `{"type":"msg","uuid":"f51d7220-10b3-4faa-a91c-1ae70beaae3e","channel":{"uuid":"57f1078f-88aa-46f4-a59a-948a5739c03d","name":"My Android Phone"},"created_on":"2018-10-22T16:12:30.000123456Z","urn":"tel:+1234567890","text":"Hi there!","attachments":["image/jpg:http://example.com/test.jpg","video/mp4:http://example.com/test.mp4"],"external_id":"ext12345","quick_reply":"YES"}`, string(marshaled))
+
+	// and back again
+	read, err := inputs.ReadInput(session.Assets(), marshaled, assets.PanicOnMissing)
+	require.NoError(t, err)
+	assert.Equal(t, "YES", read.(*inputs.MsgInput).Context(env)["quick_reply"].(types.XText).Native())
+
+	// check message text is redacted if it contains the sender's URN
+	msg = flows.NewMsgIn(flows.MsgUUID("f51d7220-10b3-4faa-a91c-1ae70beaae3e"), urns.URN("tel:+1234567890"), nil, "My number is +1234567890", nil)
+	input = inputs.NewMsg(session.Assets(), msg, test.MustParseTime("2018-10-22T16:12:30.000123456Z"))
+
+	fullEnv := envs.NewBuilder().WithRedactionPolicy(envs.RedactionPolicyFull).Build()
+	context := input.Context(fullEnv)
+
+	test.AssertXEqual(t, types.NewXText("My number is ********"), context["text"])
+	test.AssertXEqual(t, types.NewXText("My number is ********"), context["__default__"])
+	test.AssertXEqual(t, types.NewXText("tel:********"), context["urn"])
+	test.AssertXEqual(t, types.NewXText("My number is +1234567890"), input.Context(env)["text"])
+
+	// a message without a quick reply has an empty one
+	test.AssertXEqual(t, types.XTextEmpty, input.Context(env)["quick_reply"])
 }
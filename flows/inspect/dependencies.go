@@ -69,7 +69,8 @@ func CheckReference(sa flows.SessionAssets, ref assets.Reference) bool {
 	case *assets.ChannelReference:
 		return sa.Channels().Get(typed.UUID) != nil
 	case *assets.ClassifierReference:
-		return sa.Classifiers().Get(typed.UUID) != nil
+		// call_classifier actions fallback to looking up classifiers by name
+		return sa.Classifiers().Get(typed.UUID) != nil || (typed.Name != "" && sa.Classifiers().GetByName(typed.Name) != nil)
 	case *flows.ContactReference:
 		return true // have to assume contacts exist
 	case *assets.FieldReference:
@@ -112,7 +113,7 @@ func dependencies(v reflect.Value, localization flows.Localization, include func
 				asDepCon.Dependencies(localization, include)
 			}
 		},
-		func(sv reflect.Value, fv reflect.Value, ef *EngineField) {
+		func(sv reflect.Value, fv reflect.Value, fp string, ef *EngineField) {
 			// extract any asset.Reference fields automatically as dependencies
 			extractAssetReferences(fv, include)
 		},
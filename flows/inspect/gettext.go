@@ -13,7 +13,7 @@ func LocalizableText(s interface{}, include func(uuids.UUID, string, []string, f
 }
 
 func localizableText(v reflect.Value, include func(uuids.UUID, string, []string, func([]string))) {
-	walk(v, nil, func(sv reflect.Value, fv reflect.Value, ef *EngineField) {
+	walk(v, nil, func(sv reflect.Value, fv reflect.Value, fp string, ef *EngineField) {
 		if ef.Localized {
 			localizable := sv.Interface().(flows.Localizable)
 
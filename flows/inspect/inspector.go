@@ -0,0 +1,95 @@
+package inspect
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nyaruka/gocommon/dates"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/flows"
+)
+
+// Inspector is something which can inspect flows
+type Inspector interface {
+	Inspect(flows.Flow, flows.SessionAssets) *flows.Inspection
+}
+
+type inspector struct{}
+
+// NewInspector creates a new inspector which inspects flows on every call
+func NewInspector() Inspector { return &inspector{} }
+
+// Inspect inspects the given flow
+func (i *inspector) Inspect(flow flows.Flow, sa flows.SessionAssets) *flows.Inspection {
+	return flow.Inspect(sa)
+}
+
+type cacheKey struct {
+	uuid     assets.FlowUUID
+	revision int
+}
+
+type cachedInspection struct {
+	inspection *flows.Inspection
+	expiresOn  time.Time
+	ready      chan struct{} // closed once inspection has been set
+}
+
+type cachedInspector struct {
+	inner     Inspector
+	ttl       time.Duration
+	cache     map[cacheKey]*cachedInspection
+	lastSweep time.Time
+	mutex     sync.Mutex
+}
+
+// NewCachedInspector creates a new inspector which caches the results of the given inspector by flow UUID and
+// revision for the given duration. Note that this assumes the assets used for inspecting a flow revision don't
+// change during that time.
+func NewCachedInspector(inner Inspector, ttl time.Duration) Inspector {
+	return &cachedInspector{inner: inner, ttl: ttl, cache: make(map[cacheKey]*cachedInspection)}
+}
+
+// Inspect returns the cached inspection of the given flow if there is one which hasn't expired, and otherwise
+// inspects the flow and caches the result. Concurrent calls for the same flow revision wait for a single inspection
+// rather than each inspecting the flow, but the cache isn't locked whilst inspecting.
+func (i *cachedInspector) Inspect(flow flows.Flow, sa flows.SessionAssets) *flows.Inspection {
+	key := cacheKey{uuid: flow.UUID(), revision: flow.Revision()}
+	now := dates.Now()
+
+	i.mutex.Lock()
+
+	cached, found := i.cache[key]
+	if found && now.Before(cached.expiresOn) {
+		i.mutex.Unlock()
+
+		<-cached.ready
+		return cached.inspection
+	}
+
+	i.sweep(now)
+
+	cached = &cachedInspection{expiresOn: now.Add(i.ttl), ready: make(chan struct{})}
+	i.cache[key] = cached
+
+	i.mutex.Unlock()
+
+	defer close(cached.ready)
+
+	cached.inspection = i.inner.Inspect(flow, sa)
+	return cached.inspection
+}
+
+// removes expired inspections from the cache, at most once per TTL period (must be called with the mutex locked)
+func (i *cachedInspector) sweep(now time.Time) {
+	if now.Sub(i.lastSweep) < i.ttl {
+		return
+	}
+
+	for key, cached := range i.cache {
+		if !now.Before(cached.expiresOn) {
+			delete(i.cache, key)
+		}
+	}
+	i.lastSweep = now
+}
@@ -0,0 +1,86 @@
+package inspect_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/gocommon/dates"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/definition"
+	"github.com/nyaruka/goflow/flows/inspect"
+	"github.com/nyaruka/goflow/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingInspector struct {
+	calls int
+}
+
+func (i *countingInspector) Inspect(flow flows.Flow, sa flows.SessionAssets) *flows.Inspection {
+	i.calls++
+	return flow.Inspect(sa)
+}
+
+func TestCachedInspector(t *testing.T) {
+	defer dates.SetNowSource(dates.DefaultNowSource)
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2020-11-01T12:00:00Z")))
+
+	newFlow := func(revision int) flows.Flow {
+		flow, err := definition.NewFlow(assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"), "Test", envs.Language("eng"), flows.FlowTypeMessaging, revision, 0, nil, nil, nil)
+		require.NoError(t, err)
+		return flow
+	}
+
+	inner := &countingInspector{}
+	inspector := inspect.NewCachedInspector(inner, time.Minute)
+
+	flow1 := newFlow(1)
+	info1 := inspector.Inspect(flow1, nil)
+	info2 := inspector.Inspect(newFlow(1), nil)
+
+	assert.Equal(t, 1, inner.calls)
+	assert.Same(t, info1, info2)
+	assert.Equal(t, flow1.Inspect(nil), info1)
+
+	// a new revision is inspected separately
+	inspector.Inspect(newFlow(2), nil)
+	assert.Equal(t, 2, inner.calls)
+
+	// once the cached inspection expires, the flow is re-inspected
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2020-11-01T12:01:00Z")))
+
+	info3 := inspector.Inspect(flow1, nil)
+	assert.Equal(t, 3, inner.calls)
+	assert.NotSame(t, info1, info3)
+
+	inspector.Inspect(flow1, nil)
+	assert.Equal(t, 3, inner.calls)
+
+	// check that concurrent calls are safe and still only inspect once
+	inner = &countingInspector{}
+	inspector = inspect.NewCachedInspector(inner, time.Minute)
+	wg := &sync.WaitGroup{}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			inspector.Inspect(flow1, nil)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, inner.calls)
+}
+
+func TestInspector(t *testing.T) {
+	flow, err := definition.NewFlow(assets.FlowUUID("76f0a02f-3b75-4b86-9064-e9195e1b3a02"), "Test", envs.Language("eng"), flows.FlowTypeMessaging, 1, 0, nil, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, flow.Inspect(nil), inspect.NewInspector().Inspect(flow, nil))
+}
@@ -19,7 +19,7 @@ func registerType(name string, report reportFunc) {
 // base of all issue types
 type baseIssue struct {
 	Type_        string           `json:"type"`
-	NodeUUID_    flows.NodeUUID   `json:"node_uuid"`
+	NodeUUID_    flows.NodeUUID   `json:"node_uuid,omitempty"`
 	ActionUUID_  flows.ActionUUID `json:"action_uuid,omitempty"`
 	Language_    envs.Language    `json:"language,omitempty"`
 	Description_ string           `json:"description"`
@@ -58,14 +58,21 @@ func Check(sa flows.SessionAssets, flow flows.Flow, tpls []flows.ExtractedTempla
 		issues = append(issues, i)
 	}
 
-	for _, fn := range RegisteredTypes {
-		fn(sa, flow, tpls, refs, report)
+	// run checks in a consistent order so that issues on the same node are always in the same order
+	typeNames := make([]string, 0, len(RegisteredTypes))
+	for name := range RegisteredTypes {
+		typeNames = append(typeNames, name)
 	}
+	sort.Strings(typeNames)
 
-	// sort issues by node order
+	for _, name := range typeNames {
+		RegisteredTypes[name](sa, flow, tpls, refs, report)
+	}
+
+	// sort issues by node order, with flow level issues (which have no node) first
 	nodeOrder := make(map[flows.NodeUUID]int, len(flow.Nodes()))
 	for i, node := range flow.Nodes() {
-		nodeOrder[node.UUID()] = i
+		nodeOrder[node.UUID()] = i + 1
 	}
 	sort.SliceStable(issues, func(i, j int) bool {
 		return nodeOrder[issues[i].NodeUUID()] < nodeOrder[issues[j].NodeUUID()]
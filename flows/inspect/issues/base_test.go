@@ -23,11 +23,27 @@ func TestIssueTypes(t *testing.T) {
 	require.NoError(t, err)
 
 	for typeName := range issues.RegisteredTypes {
-		testIssueType(t, assets, typeName)
+		testIssueType(t, assets, typeName, func(sa flows.SessionAssets, flow flows.Flow) []flows.Issue {
+			return flow.Inspect(sa).Issues
+		})
 	}
 }
 
-func testIssueType(t *testing.T, sa flows.SessionAssets, typeName string) {
+func TestWebhookURLCheck(t *testing.T) {
+	env := envs.NewBuilder().Build()
+
+	assets, err := test.LoadSessionAssets(env, "testdata/_assets.json")
+	require.NoError(t, err)
+
+	// this check isn't registered so has to be run directly
+	testIssueType(t, assets, "webhook_url", func(sa flows.SessionAssets, flow flows.Flow) []flows.Issue {
+		found := make([]flows.Issue, 0)
+		issues.WebhookURLCheck(sa, flow, nil, nil, func(i flows.Issue) { found = append(found, i) })
+		return found
+	})
+}
+
+func testIssueType(t *testing.T, sa flows.SessionAssets, typeName string, check func(flows.SessionAssets, flows.Flow) []flows.Issue) {
 	testPath := fmt.Sprintf("testdata/%s.json", typeName)
 	testFile, err := ioutil.ReadFile(testPath)
 	require.NoError(t, err)
@@ -55,8 +71,7 @@ func testIssueType(t *testing.T, sa flows.SessionAssets, typeName string) {
 			sessionAssets = sa
 		}
 
-		info := flow.Inspect(sessionAssets)
-		issuesJSON, _ := jsonx.Marshal(info.Issues)
+		issuesJSON, _ := jsonx.Marshal(check(sessionAssets, flow))
 
 		// clone test case and populate with actual values
 		actual := tc
@@ -0,0 +1,84 @@
+package issues
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/routers"
+)
+
+func init() {
+	registerType(TypeDuplicateCase, DuplicateCaseCheck)
+}
+
+// TypeDuplicateCase is our type for a router case which duplicates an earlier case
+const TypeDuplicateCase string = "duplicate_case"
+
+// DuplicateCase is a router case which can never match because an earlier case has the same test
+type DuplicateCase struct {
+	baseIssue
+
+	CaseIndex   int `json:"case_index"`
+	DuplicateOf int `json:"duplicate_of"`
+}
+
+func newDuplicateCase(nodeUUID flows.NodeUUID, caseIndex, duplicateOf int) *DuplicateCase {
+	return &DuplicateCase{
+		baseIssue: newBaseIssue(
+			TypeDuplicateCase,
+			nodeUUID,
+			"",
+			envs.NilLanguage,
+			fmt.Sprintf("case %d duplicates case %d", caseIndex, duplicateOf),
+		),
+		CaseIndex:   caseIndex,
+		DuplicateOf: duplicateOf,
+	}
+}
+
+// tests which compare their arguments to the operand case-insensitively, ignoring surrounding whitespace
+var caseInsensitiveTests = map[string]bool{
+	"has_all_words":   true,
+	"has_any_word":    true,
+	"has_beginning":   true,
+	"has_only_phrase": true,
+	"has_pattern":     true,
+	"has_phrase":      true,
+}
+
+// DuplicateCaseCheck checks for switch router cases with the same test and arguments as an earlier case
+func DuplicateCaseCheck(sa flows.SessionAssets, flow flows.Flow, tpls []flows.ExtractedTemplate, refs []flows.ExtractedReference, report func(flows.Issue)) {
+	for _, node := range flow.Nodes() {
+		if node.Router() != nil && node.Router().Type() == routers.TypeSwitch {
+			router := node.Router().(*routers.SwitchRouter)
+			seen := make(map[string]int, len(router.Cases()))
+
+			for i, kase := range router.Cases() {
+				key := caseKey(kase)
+
+				if first, exists := seen[key]; exists {
+					report(newDuplicateCase(node.UUID(), i, first))
+				} else {
+					seen[key] = i
+				}
+			}
+		}
+	}
+}
+
+// gets the key used to compare cases, which normalizes arguments which are compared case-insensitively
+func caseKey(kase *routers.Case) string {
+	testType := strings.ToLower(kase.Type)
+	args := kase.Arguments
+
+	if caseInsensitiveTests[testType] {
+		args = make([]string, len(kase.Arguments))
+		for i, arg := range kase.Arguments {
+			args[i] = strings.ToLower(strings.TrimSpace(arg))
+		}
+	}
+
+	return testType + "\x00" + strings.Join(args, "\x00")
+}
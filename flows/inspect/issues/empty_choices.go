@@ -0,0 +1,51 @@
+package issues
+
+import (
+	"fmt"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/routers"
+)
+
+func init() {
+	registerType(TypeEmptyChoices, EmptyChoicesCheck)
+}
+
+// TypeEmptyChoices is our type for a router which has no cases to choose between
+const TypeEmptyChoices string = "empty_choices"
+
+// EmptyChoices is a switch router with no cases, so it always routes to its default category. Routers which wait for
+// input or save a result are still useful without cases so aren't reported.
+type EmptyChoices struct {
+	baseIssue
+
+	RouterType string `json:"router_type"`
+}
+
+func newEmptyChoices(nodeUUID flows.NodeUUID, routerType string) *EmptyChoices {
+	return &EmptyChoices{
+		baseIssue: newBaseIssue(
+			TypeEmptyChoices,
+			nodeUUID,
+			"",
+			envs.NilLanguage,
+			fmt.Sprintf("%s router has no cases", routerType),
+		),
+		RouterType: routerType,
+	}
+}
+
+// EmptyChoicesCheck checks for switch routers without any cases which don't wait for input or save a result
+func EmptyChoicesCheck(sa flows.SessionAssets, flow flows.Flow, tpls []flows.ExtractedTemplate, refs []flows.ExtractedReference, report func(flows.Issue)) {
+	for _, node := range flow.Nodes() {
+		router, isSwitch := node.Router().(*routers.SwitchRouter)
+		if !isSwitch {
+			continue
+		}
+
+		if len(router.Cases()) == 0 && router.Wait() == nil && router.ResultName() == "" {
+			report(newEmptyChoices(node.UUID(), router.Type()))
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package issues
+
+import (
+	"strings"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+)
+
+func init() {
+	registerType(TypeEmptyFlowName, EmptyFlowNameCheck)
+}
+
+// TypeEmptyFlowName is our type for a flow without a name
+const TypeEmptyFlowName string = "empty_flow_name"
+
+// EmptyFlowName is a flow without a name which makes it hard to identify. This is a flow level issue
+// so it has no node or action UUID.
+type EmptyFlowName struct {
+	baseIssue
+}
+
+func newEmptyFlowName() *EmptyFlowName {
+	return &EmptyFlowName{
+		baseIssue: newBaseIssue(
+			TypeEmptyFlowName,
+			"",
+			"",
+			envs.NilLanguage,
+			"flow has no name",
+		),
+	}
+}
+
+// EmptyFlowNameCheck checks for flows without names
+func EmptyFlowNameCheck(sa flows.SessionAssets, flow flows.Flow, tpls []flows.ExtractedTemplate, refs []flows.ExtractedReference, report func(flows.Issue)) {
+	if strings.TrimSpace(flow.Name()) == "" {
+		report(newEmptyFlowName())
+	}
+}
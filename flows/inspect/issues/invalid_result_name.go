@@ -0,0 +1,48 @@
+package issues
+
+import (
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+)
+
+func init() {
+	registerType(TypeInvalidResultName, InvalidResultNameCheck)
+}
+
+// TypeInvalidResultName is our type for an invalid result name issue
+const TypeInvalidResultName string = "invalid_result_name"
+
+// InvalidResultName is a result name which can't be referenced as @results.<key> in expressions
+type InvalidResultName struct {
+	baseIssue
+
+	Name string `json:"name"`
+}
+
+func newInvalidResultName(nodeUUID flows.NodeUUID, actionUUID flows.ActionUUID, name string, err error) *InvalidResultName {
+	return &InvalidResultName{
+		baseIssue: newBaseIssue(
+			TypeInvalidResultName,
+			nodeUUID,
+			actionUUID,
+			envs.NilLanguage,
+			err.Error(),
+		),
+		Name: name,
+	}
+}
+
+// InvalidResultNameCheck checks for results whose names can't be used in expressions
+func InvalidResultNameCheck(sa flows.SessionAssets, flow flows.Flow, tpls []flows.ExtractedTemplate, refs []flows.ExtractedReference, report func(flows.Issue)) {
+	for _, node := range flow.Nodes() {
+		node.EnumerateResults(func(a flows.Action, r flows.Router, info *flows.ResultInfo) {
+			if err := info.Validate(); err != nil {
+				var actionUUID flows.ActionUUID
+				if a != nil {
+					actionUUID = a.UUID()
+				}
+				report(newInvalidResultName(node.UUID(), actionUUID, info.Name, err))
+			}
+		})
+	}
+}
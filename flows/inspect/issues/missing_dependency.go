@@ -43,7 +43,7 @@ func MissingDependencyCheck(sa flows.SessionAssets, flow flows.Flow, tpls []flow
 		return
 	}
 
-	for _, ref := range refs {
+	for _, ref := range flows.DeduplicateExtractedReferences(refs) {
 		if !inspect.CheckReference(sa, ref.Reference) {
 			var actionUUID flows.ActionUUID
 			if ref.Action != nil {
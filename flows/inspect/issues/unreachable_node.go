@@ -0,0 +1,64 @@
+package issues
+
+import (
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+)
+
+func init() {
+	registerType(TypeUnreachableNode, UnreachableNodeCheck)
+}
+
+// TypeUnreachableNode is our type for a node that can't be reached
+const TypeUnreachableNode string = "unreachable_node"
+
+// UnreachableNode is a node which can never be visited because there's no path to it from the flow's entry node.
+type UnreachableNode struct {
+	baseIssue
+}
+
+func newUnreachableNode(nodeUUID flows.NodeUUID) *UnreachableNode {
+	return &UnreachableNode{
+		baseIssue: newBaseIssue(
+			TypeUnreachableNode,
+			nodeUUID,
+			"",
+			envs.NilLanguage,
+			"node can't be reached from the start of the flow",
+		),
+	}
+}
+
+// UnreachableNodeCheck checks for nodes which can't be reached by following exits from the entry node. This includes
+// nodes which are only reachable from other unreachable nodes or from themselves.
+func UnreachableNodeCheck(sa flows.SessionAssets, flow flows.Flow, tpls []flows.ExtractedTemplate, refs []flows.ExtractedReference, report func(flows.Issue)) {
+	nodes := flow.Nodes()
+	if len(nodes) == 0 {
+		return
+	}
+
+	reachable := make(map[flows.NodeUUID]bool, len(nodes))
+	queue := []flows.Node{nodes[0]}
+	reachable[nodes[0].UUID()] = true
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, exit := range node.Exits() {
+			dest := exit.DestinationUUID()
+			if dest != "" && !reachable[dest] {
+				if destNode := flow.GetNode(dest); destNode != nil {
+					reachable[dest] = true
+					queue = append(queue, destNode)
+				}
+			}
+		}
+	}
+
+	for _, node := range nodes {
+		if !reachable[node.UUID()] {
+			report(newUnreachableNode(node.UUID()))
+		}
+	}
+}
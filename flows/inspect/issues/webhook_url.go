@@ -0,0 +1,85 @@
+package issues
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/actions"
+)
+
+// TypeInsecureWebhook is our type for a webhook which doesn't use HTTPS
+const TypeInsecureWebhook string = "insecure_webhook"
+
+// TypeDynamicWebhookURL is our type for a webhook URL which contains expressions
+const TypeDynamicWebhookURL string = "dynamic_webhook_url"
+
+// InsecureWebhook is a webhook call over plain HTTP which exposes session data in transit
+type InsecureWebhook struct {
+	baseIssue
+
+	URL string `json:"url"`
+}
+
+func newInsecureWebhook(nodeUUID flows.NodeUUID, actionUUID flows.ActionUUID, url string) *InsecureWebhook {
+	return &InsecureWebhook{
+		baseIssue: newBaseIssue(
+			TypeInsecureWebhook,
+			nodeUUID,
+			actionUUID,
+			envs.NilLanguage,
+			fmt.Sprintf("webhook URL doesn't use HTTPS: %s", url),
+		),
+		URL: url,
+	}
+}
+
+// DynamicWebhookURL is a webhook URL which contains expressions and so can't be validated until it is called
+type DynamicWebhookURL struct {
+	baseIssue
+
+	URL string `json:"url"`
+}
+
+func newDynamicWebhookURL(nodeUUID flows.NodeUUID, actionUUID flows.ActionUUID, url string) *DynamicWebhookURL {
+	return &DynamicWebhookURL{
+		baseIssue: newBaseIssue(
+			TypeDynamicWebhookURL,
+			nodeUUID,
+			actionUUID,
+			envs.NilLanguage,
+			fmt.Sprintf("webhook URL contains expressions and can't be validated: %s", url),
+		),
+		URL: url,
+	}
+}
+
+// matches unresolved expressions in webhook URLs
+var webhookExpressionRegex = regexp.MustCompile(`@[^ ]+`)
+
+// WebhookURLCheck checks for webhook URLs which use HTTP rather than HTTPS, and for webhook URLs which contain
+// expressions and so can't be validated statically. Unlike the registered checks, it isn't run by Check because many
+// flows deliberately call plain HTTP or templated URLs, so callers who want these warnings must run it themselves.
+func WebhookURLCheck(sa flows.SessionAssets, flow flows.Flow, tpls []flows.ExtractedTemplate, refs []flows.ExtractedReference, report func(flows.Issue)) {
+	webhookURLs(flow, func(n flows.Node, a flows.Action, url string) {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(url)), "http://") {
+			report(newInsecureWebhook(n.UUID(), a.UUID(), url))
+		}
+		if webhookExpressionRegex.MatchString(url) {
+			report(newDynamicWebhookURL(n.UUID(), a.UUID(), url))
+		}
+	})
+}
+
+// enumerates the URLs of all call_webhook actions in the given flow
+func webhookURLs(flow flows.Flow, include func(flows.Node, flows.Action, string)) {
+	for _, node := range flow.Nodes() {
+		for _, action := range node.Actions() {
+			if webhook, isWebhook := action.(*actions.CallWebhookAction); isWebhook {
+				include(node, action, webhook.URL)
+			}
+		}
+	}
+}
@@ -102,13 +102,17 @@ func parseEngineTag(st reflect.Type, f reflect.StructField) (localized bool, eva
 	return localized, evaluated
 }
 
-func walk(v reflect.Value, visitStruct func(reflect.Value), visitField func(reflect.Value, reflect.Value, *EngineField)) {
+func walk(v reflect.Value, visitStruct func(reflect.Value), visitField func(reflect.Value, reflect.Value, string, *EngineField)) {
+	walkPath(v, "", visitStruct, visitField)
+}
+
+func walkPath(v reflect.Value, path string, visitStruct func(reflect.Value), visitField func(reflect.Value, reflect.Value, string, *EngineField)) {
 	// get the real underlying value
 	rv := derefValue(v)
 
 	if rv.Kind() == reflect.Slice {
 		for i := 0; i < rv.Len(); i++ {
-			walk(rv.Index(i), visitStruct, visitField)
+			walkPath(rv.Index(i), path, visitStruct, visitField)
 		}
 	} else if rv.Kind() == reflect.Struct {
 		if visitStruct != nil {
@@ -119,12 +123,16 @@ func walk(v reflect.Value, visitStruct func(reflect.Value), visitField func(refl
 
 		for _, ef := range fields {
 			fv := ef.Getter(rv)
+			fp := ef.JSONName
+			if path != "" {
+				fp = path + "." + ef.JSONName
+			}
 
 			if visitField != nil {
-				visitField(v, fv, ef)
+				visitField(v, fv, fp, ef)
 			}
 
-			walk(fv, visitStruct, visitField)
+			walkPath(fv, fp, visitStruct, visitField)
 		}
 	}
 }
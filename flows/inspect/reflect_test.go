@@ -111,7 +111,7 @@ func TestWalk(t *testing.T) {
 		})
 
 	values := make([]interface{}, 0)
-	walk(v, nil, func(sv reflect.Value, fv reflect.Value, ef *EngineField) {
+	walk(v, nil, func(sv reflect.Value, fv reflect.Value, fp string, ef *EngineField) {
 		values = append(values, fv.Interface())
 	})
 
@@ -134,7 +134,7 @@ func TestWalk(t *testing.T) {
 	})
 
 	values = make([]interface{}, 0)
-	walk(v, nil, func(sv reflect.Value, fv reflect.Value, ef *EngineField) {
+	walk(v, nil, func(sv reflect.Value, fv reflect.Value, fp string, ef *EngineField) {
 		values = append(values, fv.Interface())
 	})
 
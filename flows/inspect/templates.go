@@ -11,21 +11,24 @@ import (
 	"github.com/nyaruka/goflow/flows"
 )
 
-// Templates extracts template values by reading engine tags on a struct
-func Templates(s interface{}, localization flows.Localization, include func(envs.Language, string)) {
+// Templates extracts template values by reading engine tags on a struct, along with the path of the field each was
+// read from, e.g. "templating.variables"
+func Templates(s interface{}, localization flows.Localization, include func(string, envs.Language, string)) {
 	templateValues(reflect.ValueOf(s), localization, include)
 }
 
-func templateValues(v reflect.Value, localization flows.Localization, include func(envs.Language, string)) {
-	walk(v, nil, func(sv reflect.Value, fv reflect.Value, ef *EngineField) {
+func templateValues(v reflect.Value, localization flows.Localization, include func(string, envs.Language, string)) {
+	walk(v, nil, func(sv reflect.Value, fv reflect.Value, fp string, ef *EngineField) {
 		if ef.Evaluated {
-			extractTemplates(fv, envs.NilLanguage, include)
+			includeField := func(l envs.Language, t string) { include(fp, l, t) }
+
+			extractTemplates(fv, envs.NilLanguage, includeField)
 
 			// if this field is also localized, each translation is a template and needs to be included
 			if ef.Localized && localization != nil {
 				localizable := sv.Interface().(flows.Localizable)
 
-				Translations(localization, localizable.LocalizationUUID(), ef.JSONName, include)
+				Translations(localization, localizable.LocalizationUUID(), ef.JSONName, includeField)
 			}
 		}
 	})
@@ -56,6 +59,22 @@ func extractTemplates(v reflect.Value, lang envs.Language, include func(envs.Lan
 	}
 }
 
+// ExtractTemplates extracts all non-empty templates in the given flow, including translations, with the node, action
+// or router and field where each was found
+func ExtractTemplates(flow flows.Flow) []flows.ExtractedTemplate {
+	templates := make([]flows.ExtractedTemplate, 0)
+
+	for _, n := range flow.Nodes() {
+		n.EnumerateTemplates(flow.Localization(), func(a flows.Action, r flows.Router, f string, l envs.Language, t string) {
+			if t != "" {
+				templates = append(templates, flows.NewExtractedTemplate(n, a, r, f, l, t))
+			}
+		})
+	}
+
+	return templates
+}
+
 func TemplatePaths(t reflect.Type, base string, include func(string)) {
 	walkTypes(t, base, func(path string, ef *EngineField) {
 		if ef.Evaluated {
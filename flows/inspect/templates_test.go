@@ -15,6 +15,7 @@ import (
 	"github.com/nyaruka/goflow/flows/inspect"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type testFlowThing struct {
@@ -34,15 +35,18 @@ func TestTemplates(t *testing.T) {
 	thing := &testFlowThing{UUID: uuids.UUID("f50df34b-18f8-489b-b8e8-ccb14d720641"), Foo: "Hello", Bar: "World"}
 
 	templates := make(map[envs.Language][]string)
-	inspect.Templates(thing, l, func(l envs.Language, t string) {
+	fields := make([]string, 0)
+	inspect.Templates(thing, l, func(f string, l envs.Language, t string) {
 		templates[l] = append(templates[l], t)
+		fields = append(fields, f)
 	})
 
 	assert.Equal(t, map[envs.Language][]string{"": []string{"Hello", "World"}, "spa": []string{"Hola"}}, templates)
+	assert.Equal(t, []string{"foo", "foo", "bar"}, fields)
 
 	// can also extract from slice of things
 	templates = make(map[envs.Language][]string)
-	inspect.Templates([]*testFlowThing{thing}, l, func(l envs.Language, t string) {
+	inspect.Templates([]*testFlowThing{thing}, l, func(f string, l envs.Language, t string) {
 		templates[l] = append(templates[l], t)
 	})
 
@@ -55,7 +59,7 @@ func TestTemplates(t *testing.T) {
 	}
 
 	templates = make(map[envs.Language][]string)
-	inspect.Templates(actions, nil, func(l envs.Language, t string) {
+	inspect.Templates(actions, nil, func(f string, l envs.Language, t string) {
 		templates[l] = append(templates[l], t)
 	})
 
@@ -111,6 +115,85 @@ func TestTemplatePaths(t *testing.T) {
 	}, paths)
 }
 
+func TestExtractTemplates(t *testing.T) {
+	flow, err := definition.ReadFlow([]byte(`{
+		"uuid": "76f0a02f-3b75-4b86-9064-e9195e1b3a02",
+		"name": "Templates",
+		"spec_version": "13.1",
+		"language": "eng",
+		"type": "messaging",
+		"localization": {
+			"spa": {
+				"e97cd6d5-3354-4dbd-85bc-6c1f87849eec": {"text": ["Hola @contact.name"]},
+				"98388930-7a0f-4eb8-9a0a-09be2f006420": {"arguments": ["si"]}
+			}
+		},
+		"nodes": [
+			{
+				"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507",
+				"actions": [
+					{"uuid": "e97cd6d5-3354-4dbd-85bc-6c1f87849eec", "type": "send_msg", "text": "Hi @contact.name", "quick_replies": ["Yes", "No"]},
+					{"uuid": "f01d693b-2af2-49fb-9e38-146eb00937e9", "type": "call_webhook", "method": "POST", "url": "http://example.com/@contact.uuid", "headers": {"Authorization": "Token @globals.token"}, "body": "", "result_name": "Webhook"},
+					{"uuid": "9a43d8f5-a4d0-4d8e-9b5c-4ff0fd5be7ca", "type": "set_run_result", "name": "Status", "value": "@results.webhook.category"}
+				],
+				"router": {
+					"type": "switch",
+					"operand": "@input.text",
+					"cases": [
+						{"uuid": "98388930-7a0f-4eb8-9a0a-09be2f006420", "type": "has_any_word", "arguments": ["yes"], "category_uuid": "c70fe86c-9aac-4cc2-a5cb-d35cbe3fed6e"}
+					],
+					"categories": [
+						{"uuid": "c70fe86c-9aac-4cc2-a5cb-d35cbe3fed6e", "name": "Yes", "exit_uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6"},
+						{"uuid": "9f593e22-7886-4c08-a52f-0e8780504d75", "name": "Other", "exit_uuid": "5f4b6d8c-1e2a-4b3c-8d9e-0f1a2b3c4d5e"}
+					],
+					"default_category_uuid": "9f593e22-7886-4c08-a52f-0e8780504d75"
+				},
+				"exits": [
+					{"uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6"},
+					{"uuid": "5f4b6d8c-1e2a-4b3c-8d9e-0f1a2b3c4d5e"}
+				]
+			}
+		]
+	}`), nil)
+	require.NoError(t, err)
+
+	nodeUUID := flows.NodeUUID("a58be63b-907d-4a1a-856b-0bb5579d7507")
+	sendMsgUUID := flows.ActionUUID("e97cd6d5-3354-4dbd-85bc-6c1f87849eec")
+	webhookUUID := flows.ActionUUID("f01d693b-2af2-49fb-9e38-146eb00937e9")
+	resultUUID := flows.ActionUUID("9a43d8f5-a4d0-4d8e-9b5c-4ff0fd5be7ca")
+
+	type template struct {
+		nodeUUID   flows.NodeUUID
+		actionUUID flows.ActionUUID
+		isRouter   bool
+		field      string
+		language   envs.Language
+		template   string
+	}
+
+	actual := make([]template, 0)
+	for _, et := range inspect.ExtractTemplates(flow) {
+		tpl := template{nodeUUID: et.Node.UUID(), isRouter: et.Router != nil, field: et.Field, language: et.Language, template: et.Template}
+		if et.Action != nil {
+			tpl.actionUUID = et.Action.UUID()
+		}
+		actual = append(actual, tpl)
+	}
+
+	assert.Equal(t, []template{
+		{nodeUUID, sendMsgUUID, false, "text", "", "Hi @contact.name"},
+		{nodeUUID, sendMsgUUID, false, "text", "spa", "Hola @contact.name"},
+		{nodeUUID, sendMsgUUID, false, "quick_replies", "", "Yes"},
+		{nodeUUID, sendMsgUUID, false, "quick_replies", "", "No"},
+		{nodeUUID, webhookUUID, false, "url", "", "http://example.com/@contact.uuid"},
+		{nodeUUID, webhookUUID, false, "headers", "", "Token @globals.token"},
+		{nodeUUID, resultUUID, false, "value", "", "@results.webhook.category"},
+		{nodeUUID, "", true, "operand", "", "@input.text"},
+		{nodeUUID, "", true, "cases.arguments", "", "yes"},
+		{nodeUUID, "", true, "cases.arguments", "spa", "si"},
+	}, actual)
+}
+
 func TestExtractFromTemplate(t *testing.T) {
 	testCases := []struct {
 		template   string
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/Masterminds/semver"
 	"github.com/nyaruka/gocommon/uuids"
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/contactql"
@@ -11,8 +12,17 @@ import (
 	"github.com/nyaruka/goflow/excellent"
 	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/utils"
+
+	"github.com/pkg/errors"
+	validator "gopkg.in/go-playground/validator.v9"
 )
 
+func init() {
+	utils.RegisterValidatorAlias("session_status", "eq=active|eq=completed|eq=waiting|eq=failed|eq=expired|eq=interrupted", func(validator.FieldError) string {
+		return "is not a valid session status"
+	})
+}
+
 // NodeUUID is a UUID of a flow node
 type NodeUUID uuids.UUID
 
@@ -67,8 +77,19 @@ const (
 
 	// SessionStatusFailed represents a session that encountered an unrecoverable error
 	SessionStatusFailed SessionStatus = "failed"
+
+	// SessionStatusExpired represents a session that was ended because it timed out
+	SessionStatusExpired SessionStatus = "expired"
+
+	// SessionStatusInterrupted represents a session that was explicitly stopped before it could complete
+	SessionStatusInterrupted SessionStatus = "interrupted"
 )
 
+// IsEnded returns whether this status is a final status from which a session can't be resumed
+func (s SessionStatus) IsEnded() bool {
+	return s != SessionStatusActive && s != SessionStatusWaiting
+}
+
 // RunStatus represents the current status of the flow run
 type RunStatus string
 
@@ -87,11 +108,28 @@ const (
 
 	// RunStatusExpired represents a run that expired due to inactivity
 	RunStatusExpired RunStatus = "expired"
+
+	// RunStatusInterrupted represents a run that was ended because its session was interrupted
+	RunStatusInterrupted RunStatus = "interrupted"
 )
 
+// ErrFlowNotFound is the error returned (possibly wrapped) when looking up a flow by name which doesn't exist
+var ErrFlowNotFound = errors.New("flow not found")
+
+// ErrSessionInterrupted is the error returned when trying to resume a session which has been interrupted
+var ErrSessionInterrupted = errors.New("session has been interrupted")
+
 // FlowAssets provides access to flow assets
 type FlowAssets interface {
 	Get(assets.FlowUUID) (Flow, error)
+	AllUUIDs() []assets.FlowUUID
+}
+
+// NamedFlowAssets is implemented by flow assets which can also look up flows by name
+type NamedFlowAssets interface {
+	FlowAssets
+
+	GetByName(string) (Flow, error)
 }
 
 // SessionAssets is the assets available to a session
@@ -125,6 +163,7 @@ type Flow interface {
 	// spec properties
 	UUID() assets.FlowUUID
 	Name() string
+	SpecVersion() *semver.Version
 	Revision() int
 	Language() envs.Language
 	Type() FlowType
@@ -150,7 +189,7 @@ type Node interface {
 
 	Validate(Flow, map[uuids.UUID]bool) error
 
-	EnumerateTemplates(Localization, func(Action, Router, envs.Language, string))
+	EnumerateTemplates(Localization, func(Action, Router, string, envs.Language, string))
 	EnumerateDependencies(Localization, func(Action, Router, envs.Language, assets.Reference))
 	EnumerateResults(func(Action, Router, *ResultInfo))
 	EnumerateLocalizables(func(uuids.UUID, string, []string, func([]string)))
@@ -163,7 +202,7 @@ type Action interface {
 	FlowTypeRestricted
 
 	UUID() ActionUUID
-	Execute(FlowRun, Step, ModifierCallback, EventCallback) error
+	Execute(FlowRun, Step, ModifierCallback, EventSink) error
 	Validate() error
 }
 
@@ -186,10 +225,10 @@ type Router interface {
 
 	Validate(Flow, []Exit) error
 	AllowTimeout() bool
-	Route(FlowRun, Step, EventCallback) (ExitUUID, error)
-	RouteTimeout(FlowRun, Step, EventCallback) (ExitUUID, error)
+	Route(FlowRun, Step, EventSink) (ExitUUID, error)
+	RouteTimeout(FlowRun, Step, EventSink) (ExitUUID, error)
 
-	EnumerateTemplates(Localization, func(envs.Language, string))
+	EnumerateTemplates(Localization, func(string, envs.Language, string))
 	EnumerateDependencies(Localization, func(envs.Language, assets.Reference))
 	EnumerateResults(func(*ResultInfo))
 	EnumerateLocalizables(func(uuids.UUID, string, []string, func([]string)))
@@ -214,7 +253,7 @@ type Wait interface {
 
 	Timeout() Timeout
 
-	Begin(FlowRun, EventCallback) ActivatedWait
+	Begin(FlowRun, EventSink) ActivatedWait
 	End(Resume) error
 }
 
@@ -242,8 +281,8 @@ type Trigger interface {
 	utils.Typed
 	Contextable
 
-	Initialize(Session, EventCallback) error
-	InitializeRun(FlowRun, EventCallback) error
+	Initialize(Session, EventSink) error
+	InitializeRun(FlowRun, EventSink) error
 
 	Environment() envs.Environment
 	Flow() *assets.FlowReference
@@ -267,7 +306,7 @@ type Resume interface {
 	utils.Typed
 	Contextable
 
-	Apply(FlowRun, EventCallback)
+	Apply(FlowRun, EventSink)
 
 	Environment() envs.Environment
 	Contact() *Contact
@@ -278,7 +317,7 @@ type Resume interface {
 type Modifier interface {
 	utils.Typed
 
-	Apply(envs.Environment, SessionAssets, *Contact, EventCallback)
+	Apply(envs.Environment, SessionAssets, *Contact, EventSink)
 }
 
 // ModifierCallback is a callback invoked when a modifier has been generated
@@ -293,9 +332,17 @@ type Event interface {
 	SetStepUUID(StepUUID)
 }
 
-// EventCallback is a callback invoked when an event has been generated
+// EventSink receives events as they are generated
+type EventSink interface {
+	Add(Event)
+}
+
+// EventCallback is an adapter to allow the use of ordinary functions as event sinks
 type EventCallback func(Event)
 
+// Add calls f(e)
+func (f EventCallback) Add(e Event) { f(e) }
+
 // Input describes input from the contact and currently we only support one type of input: `msg`
 type Input interface {
 	utils.Typed
@@ -322,10 +369,15 @@ type Step interface {
 type Engine interface {
 	NewSession(SessionAssets, Trigger) (Session, Sprint, error)
 	ReadSession(SessionAssets, json.RawMessage, assets.MissingCallback) (Session, error)
+	CloneSession(Session) (Session, error)
 
 	Services() Services
 	MaxStepsPerSprint() int
 	MaxTemplateChars() int
+	MaxBodyBytes() int
+	ExpressionCacheSize() int
+	IncludeFullWebhookBody() bool
+	HTTPRequestTimeout(string) time.Duration
 }
 
 // Sprint is an interaction with the engine - i.e. a start or resume of a session
@@ -334,6 +386,7 @@ type Sprint interface {
 	LogModifier(Modifier)
 	Events() []Event
 	LogEvent(Event)
+	Duration() time.Duration
 }
 
 // Session represents the session of a flow run which may contain many runs
@@ -361,12 +414,16 @@ type Session interface {
 	Wait() ActivatedWait
 
 	Resume(Resume) (Sprint, error)
+	Interrupt(string) (Sprint, error)
 	Runs() []FlowRun
 	GetRun(RunUUID) (FlowRun, error)
 	GetCurrentChild(FlowRun) FlowRun
+	CurrentRun() FlowRun
 	ParentRun() RunSummary
 	CurrentContext() *types.XObject
 	History() *SessionHistory
+	FlowHistory() []*FlowHistoryEntry
+	ExpressionCache() *ExpressionCache
 
 	Engine() Engine
 }
@@ -423,6 +480,7 @@ type FlowRun interface {
 	ExpiresOn() *time.Time
 	ResetExpiration(*time.Time)
 	ExitedOn() *time.Time
+	Elapsed() time.Duration
 	Exit(RunStatus)
 }
 
@@ -33,6 +33,7 @@ var _ assets.Label = (*Label)(nil)
 type LabelAssets struct {
 	all    []*Label
 	byUUID map[assets.LabelUUID]*Label
+	byName map[string]*Label
 }
 
 // NewLabelAssets creates a new set of label assets
@@ -40,11 +41,18 @@ func NewLabelAssets(labels []assets.Label) *LabelAssets {
 	s := &LabelAssets{
 		all:    make([]*Label, len(labels)),
 		byUUID: make(map[assets.LabelUUID]*Label, len(labels)),
+		byName: make(map[string]*Label, len(labels)),
 	}
 	for i, asset := range labels {
 		label := NewLabel(asset)
 		s.all[i] = label
 		s.byUUID[label.UUID()] = label
+
+		// if names collide, the first label wins
+		key := normalizeAssetName(label.Name())
+		if _, exists := s.byName[key]; !exists {
+			s.byName[key] = label
+		}
 	}
 	return s
 }
@@ -59,6 +67,12 @@ func (s *LabelAssets) Get(uuid assets.LabelUUID) *Label {
 	return s.byUUID[uuid]
 }
 
+// GetByName returns the label with the given name, ignoring case and surrounding whitespace, or nil if there isn't one.
+// If more than one label has that name, the first one is returned.
+func (s *LabelAssets) GetByName(name string) *Label {
+	return s.byName[normalizeAssetName(name)]
+}
+
 // FindByName looks for a label with the given name (case-insensitive)
 func (s *LabelAssets) FindByName(name string) *Label {
 	name = strings.ToLower(name)
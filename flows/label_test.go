@@ -0,0 +1,45 @@
+package flows_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/assets"
+	atypes "github.com/nyaruka/goflow/assets/static/types"
+	"github.com/nyaruka/goflow/flows"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabels(t *testing.T) {
+	la1 := atypes.NewLabel("3f65d88a-95dc-4140-9451-943e94e06fea", "Spam")
+	la2 := atypes.NewLabel("b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", "Testing Stuff")
+
+	la := flows.NewLabelAssets([]assets.Label{la1, la2})
+
+	assert.Equal(t, la1, la.Get("3f65d88a-95dc-4140-9451-943e94e06fea").Asset())
+	assert.Nil(t, la.Get("f5a3a3a4-8e9c-4b42-b1b4-0a7d3ad1f7b9"))
+
+	assert.Equal(t, la1, la.GetByName("Spam").Asset())
+	assert.Equal(t, la1, la.GetByName("SPAM").Asset())
+	assert.Equal(t, la2, la.GetByName("testing stuff").Asset())
+	assert.Equal(t, la2, la.GetByName(" Testing Stuff ").Asset())
+	assert.Equal(t, la2, la.FindByName("TESTING STUFF").Asset())
+	assert.Nil(t, la.FindByName(" Testing Stuff ")) // only GetByName ignores whitespace
+	assert.Nil(t, la.GetByName("Testing"))
+	assert.Nil(t, la.GetByName(""))
+
+	l1 := la.GetByName("spam")
+	assert.Equal(t, assets.NewLabelReference("3f65d88a-95dc-4140-9451-943e94e06fea", "Spam"), l1.Reference())
+}
+
+func TestLabelsWithSameName(t *testing.T) {
+	la1 := atypes.NewLabel("3f65d88a-95dc-4140-9451-943e94e06fea", "Spam")
+	la2 := atypes.NewLabel("b7cf0d83-f1c9-411c-96fd-c511a4cfa86d", "SPAM ")
+
+	la := flows.NewLabelAssets([]assets.Label{la1, la2})
+
+	// if names collide, the first label is returned
+	assert.Equal(t, la1, la.GetByName("spam").Asset())
+	assert.Equal(t, la1, la.FindByName("spam").Asset())
+	assert.Equal(t, la2, la.FindByName("spam ").Asset())
+}
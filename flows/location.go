@@ -1,6 +1,11 @@
 package flows
 
 import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/envs"
 )
@@ -15,6 +20,10 @@ const (
 // LocationAssets provides access to location assets
 type LocationAssets struct {
 	hierarchies []assets.LocationHierarchy
+
+	// trigrams of location names and aliases in each hierarchy, built on first search
+	searchIndex     [][]*indexedLocation
+	searchIndexOnce sync.Once
 }
 
 // NewLocationAssets creates a new set of location assets
@@ -26,3 +35,129 @@ func NewLocationAssets(hierarchies []assets.LocationHierarchy) *LocationAssets {
 func (s *LocationAssets) Hierarchies() []assets.LocationHierarchy {
 	return s.hierarchies
 }
+
+// FindByPath looks for a location with the given path (e.g. "Rwanda > Kigali City") in any hierarchy (case-insensitive)
+func (s *LocationAssets) FindByPath(path string) *envs.Location {
+	for _, hierarchy := range s.hierarchies {
+		if location := hierarchy.FindByPath(envs.LocationPath(path)); location != nil {
+			return location
+		}
+	}
+	return nil
+}
+
+// Children returns the child locations of the given location
+func (s *LocationAssets) Children(l *envs.Location) []*envs.Location {
+	if l == nil {
+		return nil
+	}
+	return l.Children()
+}
+
+// LocationMatch is a location found by a similarity search
+type LocationMatch struct {
+	Location *envs.Location
+	Score    float64
+}
+
+// a location with the trigrams of its name and aliases
+type indexedLocation struct {
+	location *envs.Location
+	trigrams []map[string]bool
+}
+
+// Search looks for locations whose name or aliases are similar to the given query, returning at most maxResults
+// matches (or all if maxResults is zero) ordered by descending trigram similarity
+func (s *LocationAssets) Search(query string, maxResults int) []*LocationMatch {
+	return s.search(query, maxResults, len(s.hierarchies))
+}
+
+// searches the first numHierarchies hierarchies
+func (s *LocationAssets) search(query string, maxResults int, numHierarchies int) []*LocationMatch {
+	s.searchIndexOnce.Do(s.buildSearchIndex)
+
+	queryTrigrams := trigrams(query)
+	matches := make([]*LocationMatch, 0)
+
+	for _, indexedLocations := range s.searchIndex[:numHierarchies] {
+		for _, indexed := range indexedLocations {
+			score := 0.0
+			for _, t := range indexed.trigrams {
+				if nameScore := trigramSimilarity(queryTrigrams, t); nameScore > score {
+					score = nameScore
+				}
+			}
+			if score > 0 {
+				matches = append(matches, &LocationMatch{Location: indexed.location, Score: score})
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+
+	if maxResults > 0 && len(matches) > maxResults {
+		matches = matches[:maxResults]
+	}
+	return matches
+}
+
+// builds the search index from all hierarchies which can provide their root location
+func (s *LocationAssets) buildSearchIndex() {
+	s.searchIndex = make([][]*indexedLocation, len(s.hierarchies))
+
+	for i, hierarchy := range s.hierarchies {
+		rooted, isRooted := hierarchy.(assets.RootedLocationHierarchy)
+		if !isRooted {
+			continue
+		}
+
+		visitLocations(rooted.Root(), func(l *envs.Location) {
+			indexed := &indexedLocation{location: l, trigrams: []map[string]bool{trigrams(l.Name())}}
+			for _, alias := range l.Aliases() {
+				indexed.trigrams = append(indexed.trigrams, trigrams(alias))
+			}
+			s.searchIndex[i] = append(s.searchIndex[i], indexed)
+		})
+	}
+}
+
+func visitLocations(l *envs.Location, visitor func(*envs.Location)) {
+	if l == nil {
+		return
+	}
+	visitor(l)
+	for _, child := range l.Children() {
+		visitLocations(child, visitor)
+	}
+}
+
+var nonWordChars = regexp.MustCompile(`[^\pL\pN]+`)
+
+// gets the set of trigrams in the given text, padding each word like Postgres' pg_trgm
+func trigrams(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range nonWordChars.Split(strings.ToLower(text), -1) {
+		if word == "" {
+			continue
+		}
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+// calculates the similarity of two trigram sets as the ratio of shared trigrams to all trigrams
+func trigramSimilarity(t1, t2 map[string]bool) float64 {
+	if len(t1) == 0 || len(t2) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range t1 {
+		if t2[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(t1)+len(t2)-shared)
+}
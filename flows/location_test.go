@@ -0,0 +1,93 @@
+package flows_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocationAssets(t *testing.T) {
+	hierarchy, err := envs.ReadLocationHierarchy([]byte(`{
+		"name": "Rwanda",
+		"children": [
+			{"name": "Kigali City", "children": [{"name": "Gasabo"}, {"name": "Nyarugenge"}]},
+			{"name": "Eastern Province"}
+		]
+	}`))
+	require.NoError(t, err)
+
+	la := flows.NewLocationAssets([]assets.LocationHierarchy{hierarchy})
+
+	rwanda := la.FindByPath("Rwanda")
+	require.NotNil(t, rwanda)
+	assert.Equal(t, "Rwanda", rwanda.Name())
+
+	kigali := la.FindByPath("Rwanda > Kigali City")
+	require.NotNil(t, kigali)
+	assert.Equal(t, "Kigali City", kigali.Name())
+	assert.Equal(t, kigali, la.FindByPath("rwanda > KIGALI CITY"))
+	assert.Equal(t, kigali, la.FindByPath("Rwanda>Kigali City"))
+
+	assert.Nil(t, la.FindByPath("Rwanda > Boston"))
+	assert.Nil(t, la.FindByPath("Kigali City"))
+	assert.Nil(t, la.FindByPath(""))
+
+	children := la.Children(rwanda)
+	assert.Equal(t, 2, len(children))
+	assert.Equal(t, kigali, children[0])
+	assert.Equal(t, "Eastern Province", children[1].Name())
+
+	assert.Equal(t, []string{"Gasabo", "Nyarugenge"}, []string{la.Children(kigali)[0].Name(), la.Children(kigali)[1].Name()})
+	assert.Equal(t, 0, len(la.Children(children[1])))
+	assert.Nil(t, la.Children(nil))
+
+	// no hierarchies means nothing to find
+	assert.Nil(t, flows.NewLocationAssets(nil).FindByPath("Rwanda"))
+}
+
+func TestLocationAssetsSearch(t *testing.T) {
+	hierarchy, err := envs.ReadLocationHierarchy([]byte(`{
+		"name": "Rwanda",
+		"children": [
+			{"name": "Kigali City", "aliases": ["Kigali"], "children": [{"name": "Gasabo"}, {"name": "Nyarugenge"}]},
+			{"name": "Eastern Province"}
+		]
+	}`))
+	require.NoError(t, err)
+
+	la := flows.NewLocationAssets([]assets.LocationHierarchy{hierarchy})
+
+	// exact match
+	matches := la.Search("Gasabo", 0)
+	require.Equal(t, 1, len(matches))
+	assert.Equal(t, "Gasabo", matches[0].Location.Name())
+	assert.Equal(t, 1.0, matches[0].Score)
+
+	// one character off
+	matches = la.Search("gasab0", 0)
+	require.Equal(t, 1, len(matches))
+	assert.Equal(t, "Gasabo", matches[0].Location.Name())
+	assert.InDelta(t, 0.556, matches[0].Score, 0.001)
+
+	// completely different
+	assert.Equal(t, 0, len(la.Search("Boston", 0)))
+
+	// aliases are also searched and results are ordered by score
+	matches = la.Search("kigali", 0)
+	require.Equal(t, 1, len(matches))
+	assert.Equal(t, "Kigali City", matches[0].Location.Name())
+	assert.Equal(t, 1.0, matches[0].Score)
+
+	matches = la.Search("rwanda eastern", 0)
+	require.Equal(t, 2, len(matches))
+	assert.Equal(t, "Rwanda", matches[0].Location.Name())
+	assert.Equal(t, "Eastern Province", matches[1].Location.Name())
+	assert.True(t, matches[0].Score > matches[1].Score)
+
+	assert.Equal(t, 1, len(la.Search("rwanda eastern", 1)))
+}
@@ -39,12 +39,12 @@ func newBaseModifier(typeName string) baseModifier {
 func (m *baseModifier) Type() string { return m.Type_ }
 
 // helper to re-evaluate groups and log any changes to membership
-func (m *baseModifier) reevaluateGroups(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventCallback) {
+func (m *baseModifier) reevaluateGroups(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventSink) {
 	added, removed, errors := contact.ReevaluateQueryBasedGroups(env)
 
 	// add error event for each group we couldn't re-evaluate
 	for _, err := range errors {
-		log(events.NewError(err))
+		log.Add(events.NewError(err))
 	}
 
 	// make sure from all static groups are removed for non-active contacts
@@ -59,7 +59,7 @@ func (m *baseModifier) reevaluateGroups(env envs.Environment, assets flows.Sessi
 
 	// add groups changed event for the groups we were added/removed to/from
 	if len(added) > 0 || len(removed) > 0 {
-		log(events.NewContactGroupsChanged(added, removed))
+		log.Add(events.NewContactGroupsChanged(added, removed))
 	}
 }
 
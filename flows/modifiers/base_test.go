@@ -54,7 +54,7 @@ func testModifierType(t *testing.T, sessionAssets flows.SessionAssets, typeName
 	defer uuids.SetGenerator(uuids.DefaultGenerator)
 
 	for i, tc := range tests {
-		dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 10, 18, 14, 20, 30, 123456, time.UTC)))
+		dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-10-18T14:20:30.000123456Z")))
 		uuids.SetGenerator(uuids.NewSeededGenerator(12345))
 
 		testName := fmt.Sprintf("test '%s' for modifier type '%s'", tc.Description, typeName)
@@ -70,7 +70,7 @@ func testModifierType(t *testing.T, sessionAssets flows.SessionAssets, typeName
 
 		// apply the modifier
 		eventLog := test.NewEventLog()
-		modifier.Apply(envs.NewBuilder().WithMaxValueLength(256).Build(), sessionAssets, contact, eventLog.Log)
+		modifier.Apply(envs.NewBuilder().WithMaxValueLength(256).Build(), sessionAssets, contact, eventLog)
 
 		// clone test case and populate with actual values
 		actual := tc
@@ -206,6 +206,21 @@ func TestConstructors(t *testing.T) {
 				"modification": "append"
 			}`,
 		},
+		{
+			modifiers.NewURNChannel(urns.URN("tel:+1234567890"), nexmo),
+			`{
+				"type": "urn_channel",
+				"urn": "tel:+1234567890",
+				"channel": {"uuid": "3a05eaf5-cb1b-4246-bef1-f277419c83a7", "name": "Nexmo"}
+			}`,
+		},
+		{
+			modifiers.NewURNChannel(urns.URN("tel:+1234567890"), nil),
+			`{
+				"type": "urn_channel",
+				"urn": "tel:+1234567890"
+			}`,
+		},
 		{
 			modifiers.NewURNs([]urns.URN{urns.URN("tel:+1234567890"), urns.URN("tel:+1234567891")}, modifiers.URNsSet),
 			`{
@@ -34,13 +34,13 @@ func NewChannel(channel *flows.Channel) *ChannelModifier {
 }
 
 // Apply applies this modification to the given contact
-func (m *ChannelModifier) Apply(env envs.Environment, sa flows.SessionAssets, contact *flows.Contact, log flows.EventCallback) {
+func (m *ChannelModifier) Apply(env envs.Environment, sa flows.SessionAssets, contact *flows.Contact, log flows.EventSink) {
 	if m.channel != nil && !m.channel.HasRole(assets.ChannelRoleSend) {
-		log(events.NewErrorf("can't set channel that can't send as the preferred channel"))
+		log.Add(events.NewErrorf("can't set channel that can't send as the preferred channel"))
 
 	} else if contact.UpdatePreferredChannel(m.channel) {
 		// if URNs change in anyway, generate a URNs changed event
-		log(events.NewContactURNsChanged(contact.URNs().RawURNs()))
+		log.Add(events.NewContactURNsChanged(contact.URNs().RawURNs()))
 	}
 }
 
@@ -37,7 +37,7 @@ func NewField(field *flows.Field, value string) *FieldModifier {
 }
 
 // Apply applies this modification to the given contact
-func (m *FieldModifier) Apply(env envs.Environment, sa flows.SessionAssets, contact *flows.Contact, log flows.EventCallback) {
+func (m *FieldModifier) Apply(env envs.Environment, sa flows.SessionAssets, contact *flows.Contact, log flows.EventSink) {
 	oldValue := contact.Fields().Get(m.field)
 
 	newValue := contact.Fields().Parse(env, sa.Fields(), m.field, m.value)
@@ -49,7 +49,7 @@ func (m *FieldModifier) Apply(env envs.Environment, sa flows.SessionAssets, cont
 
 	if !newValue.Equals(oldValue) {
 		contact.Fields().Set(m.field, newValue)
-		log(events.NewContactFieldChanged(m.field, newValue))
+		log.Add(events.NewContactFieldChanged(m.field, newValue))
 		m.reevaluateGroups(env, sa, contact, log)
 	}
 }
@@ -45,9 +45,9 @@ func NewGroups(groups []*flows.Group, modification GroupsModification) *GroupsMo
 }
 
 // Apply applies this modification to the given contact
-func (m *GroupsModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventCallback) {
+func (m *GroupsModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventSink) {
 	if contact.Status() == flows.ContactStatusBlocked || contact.Status() == flows.ContactStatusStopped {
-		log(events.NewErrorf("can't add blocked or stopped contacts to groups"))
+		log.Add(events.NewErrorf("can't add blocked or stopped contacts to groups"))
 		return
 	}
 
@@ -56,12 +56,12 @@ func (m *GroupsModifier) Apply(env envs.Environment, assets flows.SessionAssets,
 	if m.modification == GroupsAdd {
 		for _, group := range m.groups {
 			if group.UsesQuery() {
-				log(events.NewErrorf("can't add contacts to the query based group '%s'", group.Name()))
+				log.Add(events.NewErrorf("can't add contacts to the query based group '%s'", group.Name()))
 				continue
 			}
 
 			// ignore group if contact is already in it
-			if contact.Groups().FindByUUID(group.UUID()) != nil {
+			if contact.HasGroup(group.UUID()) {
 				continue
 			}
 
@@ -71,18 +71,18 @@ func (m *GroupsModifier) Apply(env envs.Environment, assets flows.SessionAssets,
 
 		// only generate event if contact's groups change
 		if len(diff) > 0 {
-			log(events.NewContactGroupsChanged(diff, nil))
+			log.Add(events.NewContactGroupsChanged(diff, nil))
 		}
 
 	} else if m.modification == GroupsRemove {
 		for _, group := range m.groups {
 			if group.UsesQuery() {
-				log(events.NewErrorf("can't remove contacts from the query based group '%s'", group.Name()))
+				log.Add(events.NewErrorf("can't remove contacts from the query based group '%s'", group.Name()))
 				continue
 			}
 
 			// ignore group if contact isn't actually in it
-			if contact.Groups().FindByUUID(group.UUID()) == nil {
+			if !contact.HasGroup(group.UUID()) {
 				continue
 			}
 
@@ -92,7 +92,7 @@ func (m *GroupsModifier) Apply(env envs.Environment, assets flows.SessionAssets,
 
 		// only generate event if contact's groups change
 		if len(diff) > 0 {
-			log(events.NewContactGroupsChanged(nil, diff))
+			log.Add(events.NewContactGroupsChanged(nil, diff))
 		}
 	}
 }
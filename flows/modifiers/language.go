@@ -33,10 +33,10 @@ func NewLanguage(language envs.Language) *LanguageModifier {
 }
 
 // Apply applies this modification to the given contact
-func (m *LanguageModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventCallback) {
+func (m *LanguageModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventSink) {
 	if contact.Language() != m.Language {
 		contact.SetLanguage(m.Language)
-		log(events.NewContactLanguageChanged(m.Language))
+		log.Add(events.NewContactLanguageChanged(m.Language))
 		m.reevaluateGroups(env, assets, contact, log)
 	}
 }
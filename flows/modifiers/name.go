@@ -33,13 +33,13 @@ func NewName(name string) *NameModifier {
 }
 
 // Apply applies this modification to the given contact
-func (m *NameModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventCallback) {
+func (m *NameModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventSink) {
 	if contact.Name() != m.Name {
 		// truncate value if necessary
 		name := utils.Truncate(m.Name, env.MaxValueLength())
 
 		contact.SetName(name)
-		log(events.NewContactNameChanged(name))
+		log.Add(events.NewContactNameChanged(name))
 		m.reevaluateGroups(env, assets, contact, log)
 	}
 }
@@ -33,11 +33,11 @@ func NewStatus(status flows.ContactStatus) *StatusModifier {
 }
 
 // Apply applies this modification to the given contact
-func (m *StatusModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventCallback) {
+func (m *StatusModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventSink) {
 
 	if contact.Status() != m.Status {
 		contact.SetStatus(m.Status)
-		log(events.NewContactStatusChanged(m.Status))
+		log.Add(events.NewContactStatusChanged(m.Status))
 		m.reevaluateGroups(env, assets, contact, log)
 	}
 }
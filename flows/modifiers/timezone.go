@@ -35,10 +35,10 @@ func NewTimezone(timezone *time.Location) *TimezoneModifier {
 }
 
 // Apply applies this modification to the given contact
-func (m *TimezoneModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventCallback) {
+func (m *TimezoneModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventSink) {
 	if !timezonesEqual(contact.Timezone(), m.timezone) {
 		contact.SetTimezone(m.timezone)
-		log(events.NewContactTimezoneChanged(m.timezone))
+		log.Add(events.NewContactTimezoneChanged(m.timezone))
 		m.reevaluateGroups(env, assets, contact, log)
 	}
 }
@@ -46,7 +46,7 @@ func NewURN(urn urns.URN, modification URNModification) *URNModifier {
 }
 
 // Apply applies this modification to the given contact
-func (m *URNModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventCallback) {
+func (m *URNModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventSink) {
 	urn := m.URN.Normalize(string(env.DefaultCountry()))
 	modified := false
 
@@ -57,7 +57,7 @@ func (m *URNModifier) Apply(env envs.Environment, assets flows.SessionAssets, co
 	}
 
 	if modified {
-		log(events.NewContactURNsChanged(contact.URNs().RawURNs()))
+		log.Add(events.NewContactURNsChanged(contact.URNs().RawURNs()))
 		m.reevaluateGroups(env, assets, contact, log)
 	}
 }
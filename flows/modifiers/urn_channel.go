@@ -0,0 +1,90 @@
+package modifiers
+
+import (
+	"encoding/json"
+
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/gocommon/urns"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/events"
+	"github.com/nyaruka/goflow/utils"
+)
+
+func init() {
+	registerType(TypeURNChannel, readURNChannelModifier)
+}
+
+// TypeURNChannel is the type of our URN channel modifier
+const TypeURNChannel string = "urn_channel"
+
+// URNChannelModifier modifies the preferred channel of a single URN on a contact. A nil channel clears the preferred
+// channel of the URN, e.g. when that channel has been deactivated.
+type URNChannelModifier struct {
+	baseModifier
+
+	urn     urns.URN
+	channel *flows.Channel
+}
+
+// NewURNChannel creates a new URN channel modifier
+func NewURNChannel(urn urns.URN, channel *flows.Channel) *URNChannelModifier {
+	return &URNChannelModifier{
+		baseModifier: newBaseModifier(TypeURNChannel),
+		urn:          urn,
+		channel:      channel,
+	}
+}
+
+// Apply applies this modification to the given contact
+func (m *URNChannelModifier) Apply(env envs.Environment, sa flows.SessionAssets, contact *flows.Contact, log flows.EventSink) {
+	urn := m.urn.Normalize(string(env.DefaultCountry()))
+
+	if m.channel != nil && !m.channel.HasRole(assets.ChannelRoleSend) {
+		log.Add(events.NewErrorf("can't set channel that can't send as the preferred channel"))
+
+	} else if m.channel != nil && !m.channel.SupportsScheme(urn.Scheme()) {
+		log.Add(events.NewErrorf("can't set channel that doesn't support '%s' URNs as the preferred channel", urn.Scheme()))
+
+	} else if contact.SetURNChannel(urn, m.channel) {
+		log.Add(events.NewContactURNsChanged(contact.URNs().RawURNs()))
+	}
+}
+
+var _ flows.Modifier = (*URNChannelModifier)(nil)
+
+//------------------------------------------------------------------------------------------
+// JSON Encoding / Decoding
+//------------------------------------------------------------------------------------------
+
+type urnChannelModifierEnvelope struct {
+	utils.TypedEnvelope
+	URN     urns.URN                 `json:"urn" validate:"required,urn"`
+	Channel *assets.ChannelReference `json:"channel,omitempty" validate:"omitempty,dive"`
+}
+
+func readURNChannelModifier(assets flows.SessionAssets, data json.RawMessage, missing assets.MissingCallback) (flows.Modifier, error) {
+	e := &urnChannelModifierEnvelope{}
+	if err := utils.UnmarshalAndValidate(data, e); err != nil {
+		return nil, err
+	}
+
+	var channel *flows.Channel
+	if e.Channel != nil {
+		channel = assets.Channels().Get(e.Channel.UUID)
+		if channel == nil {
+			missing(e.Channel, nil)
+			return nil, ErrNoModifier // nothing left to modify without the channel
+		}
+	}
+	return NewURNChannel(e.URN, channel), nil
+}
+
+func (m *URNChannelModifier) MarshalJSON() ([]byte, error) {
+	return jsonx.Marshal(&urnChannelModifierEnvelope{
+		TypedEnvelope: utils.TypedEnvelope{Type: m.Type()},
+		URN:           m.urn,
+		Channel:       m.channel.Reference(),
+	})
+}
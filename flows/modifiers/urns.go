@@ -46,7 +46,7 @@ func NewURNs(urnz []urns.URN, modification URNsModification) *URNsModifier {
 }
 
 // Apply applies this modification to the given contact
-func (m *URNsModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventCallback) {
+func (m *URNsModifier) Apply(env envs.Environment, assets flows.SessionAssets, contact *flows.Contact, log flows.EventSink) {
 	modified := false
 
 	if m.Modification == URNsSet {
@@ -58,7 +58,7 @@ func (m *URNsModifier) Apply(env envs.Environment, assets flows.SessionAssets, c
 		urn := urn.Normalize(string(env.DefaultCountry()))
 
 		if err := urn.Validate(); err != nil {
-			log(events.NewErrorf("'%s' is not valid URN", urn))
+			log.Add(events.NewErrorf("'%s' is not valid URN", urn))
 		} else {
 			if m.Modification == URNsAppend || m.Modification == URNsSet {
 				modified = contact.AddURN(urn, nil)
@@ -69,7 +69,7 @@ func (m *URNsModifier) Apply(env envs.Environment, assets flows.SessionAssets, c
 	}
 
 	if modified {
-		log(events.NewContactURNsChanged(contact.URNs().RawURNs()))
+		log.Add(events.NewContactURNsChanged(contact.URNs().RawURNs()))
 		m.reevaluateGroups(env, assets, contact, log)
 	}
 }
@@ -45,6 +45,7 @@ type MsgIn struct {
 	BaseMsg
 
 	ExternalID_ string `json:"external_id,omitempty"`
+	QuickReply_ string `json:"quick_reply,omitempty"`
 }
 
 // MsgOut represents a outgoing message to the session contact
@@ -138,6 +139,12 @@ func (m *MsgIn) ExternalID() string { return m.ExternalID_ }
 // SetExternalID sets the external ID of this message
 func (m *MsgIn) SetExternalID(id string) { m.ExternalID_ = id }
 
+// QuickReply returns the payload of the quick reply selected to send this message (if any)
+func (m *MsgIn) QuickReply() string { return m.QuickReply_ }
+
+// SetQuickReply sets the payload of the quick reply selected to send this message
+func (m *MsgIn) SetQuickReply(payload string) { m.QuickReply_ = payload }
+
 // QuickReplies returns the quick replies of this outgoing message
 func (m *MsgOut) QuickReplies() []string { return m.QuickReplies_ }
 
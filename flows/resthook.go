@@ -1,6 +1,8 @@
 package flows
 
 import (
+	"strings"
+
 	"github.com/nyaruka/goflow/assets"
 )
 
@@ -19,21 +21,30 @@ func (r *Resthook) Asset() assets.Resthook { return r.Resthook }
 
 // ResthookAssets provides access to all resthook assets
 type ResthookAssets struct {
+	all    []*Resthook
 	bySlug map[string]*Resthook
 }
 
 // NewResthookAssets creates a new set of resthook assets
 func NewResthookAssets(resthooks []assets.Resthook) *ResthookAssets {
 	s := &ResthookAssets{
+		all:    make([]*Resthook, 0, len(resthooks)),
 		bySlug: make(map[string]*Resthook, len(resthooks)),
 	}
 	for _, asset := range resthooks {
-		s.bySlug[asset.Slug()] = NewResthook(asset)
+		resthook := NewResthook(asset)
+		s.all = append(s.all, resthook)
+		s.bySlug[strings.ToLower(asset.Slug())] = resthook
 	}
 	return s
 }
 
-// FindBySlug finds the group with the given UUID
+// All returns all the resthooks
+func (s *ResthookAssets) All() []*Resthook {
+	return s.all
+}
+
+// FindBySlug finds the resthook with the given slug (case-insensitive)
 func (s *ResthookAssets) FindBySlug(slug string) *Resthook {
-	return s.bySlug[slug]
+	return s.bySlug[strings.ToLower(slug)]
 }
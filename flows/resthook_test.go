@@ -0,0 +1,28 @@
+package flows_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/assets"
+	atypes "github.com/nyaruka/goflow/assets/static/types"
+	"github.com/nyaruka/goflow/flows"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResthooks(t *testing.T) {
+	ra1 := atypes.NewResthook("new-registration", []string{"http://example.com/register"})
+	ra2 := atypes.NewResthook("Survey-Complete", []string{"http://example.com/survey"})
+
+	ra := flows.NewResthookAssets([]assets.Resthook{ra1, ra2})
+
+	all := ra.All()
+	assert.Equal(t, 2, len(all))
+	assert.Equal(t, ra1, all[0].Asset())
+	assert.Equal(t, ra2, all[1].Asset())
+
+	assert.Equal(t, ra1, ra.FindBySlug("new-registration").Asset())
+	assert.Equal(t, ra1, ra.FindBySlug("New-Registration").Asset())
+	assert.Equal(t, ra2, ra.FindBySlug("survey-complete").Asset())
+	assert.Nil(t, ra.FindBySlug("xyz"))
+}
@@ -91,6 +91,18 @@ func (r Results) Clone() Results {
 	return clone
 }
 
+// Redacted returns a copy of this results set with the given redaction applied to result values and inputs
+func (r Results) Redacted(redact func(string) string) Results {
+	redacted := make(Results, len(r))
+	for k, v := range r {
+		result := *v
+		result.Value = redact(v.Value)
+		result.Input = redact(v.Input)
+		redacted[k] = &result
+	}
+	return redacted
+}
+
 // Save saves a new result in our map. The key is saved in a snakified format
 func (r Results) Save(result *Result) {
 	r[utils.Snakify(result.Name)] = result
@@ -101,6 +113,17 @@ func (r Results) Get(key string) *Result {
 	return r[key]
 }
 
+// Keys returns the sorted keys of the results in this set
+func (r Results) Keys() []string {
+	keys := make([]string, 0, len(r))
+	for k := range r {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
 // Context returns the properties available in expressions
 func (r Results) Context(env envs.Environment) map[string]types.XValue {
 	entries := make(map[string]types.XValue, len(r)+1)
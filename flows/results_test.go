@@ -2,7 +2,6 @@ package flows_test
 
 import (
 	"testing"
-	"time"
 
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent/types"
@@ -15,12 +14,17 @@ import (
 func TestResults(t *testing.T) {
 	env := envs.NewBuilder().Build()
 
-	result1 := flows.NewResult("Beer", "skol!", "Skol", "", flows.NodeUUID("26493ebb-a254-4461-a28d-c7761784e276"), "", nil, time.Date(2019, 4, 5, 14, 16, 30, 123456, time.UTC))
-	result2 := flows.NewResult("Empty", "", "", "", flows.NodeUUID("26493ebb-a254-4461-a28d-c7761784e276"), "", nil, time.Date(2019, 4, 5, 14, 16, 30, 123456, time.UTC))
+	result1 := flows.NewResult("Beer", "skol!", "Skol", "", flows.NodeUUID("26493ebb-a254-4461-a28d-c7761784e276"), "", nil, test.MustParseTime("2019-04-05T14:16:30.000123456Z"))
+	result2 := flows.NewResult("Empty", "", "", "", flows.NodeUUID("26493ebb-a254-4461-a28d-c7761784e276"), "", nil, test.MustParseTime("2019-04-05T14:16:30.000123456Z"))
 
 	results := flows.NewResults()
-	results.Save(result1)
+	assert.Equal(t, []string{}, results.Keys())
+
 	results.Save(result2)
+	assert.Equal(t, []string{"empty"}, results.Keys())
+
+	results.Save(result1)
+	assert.Equal(t, []string{"beer", "empty"}, results.Keys())
 
 	assert.Equal(t, result1, results.Get("beer"))
 	assert.Equal(t, result2, results.Get("empty"))
@@ -36,7 +40,7 @@ func TestResults(t *testing.T) {
 			"categories":           types.NewXArray(types.NewXText("Skol")),
 			"category_localized":   types.NewXText("Skol"),
 			"categories_localized": types.NewXArray(types.NewXText("Skol")),
-			"created_on":           types.NewXDateTime(time.Date(2019, 4, 5, 14, 16, 30, 123456, time.UTC)),
+			"created_on":           types.NewXDateTime(test.MustParseTime("2019-04-05T14:16:30.000123456Z")),
 			"extra":                nil,
 			"input":                types.XTextEmpty,
 			"name":                 types.NewXText("Beer"),
@@ -50,7 +54,7 @@ func TestResults(t *testing.T) {
 			"categories":           types.NewXArray(types.NewXText("")),
 			"category_localized":   types.NewXText(""),
 			"categories_localized": types.NewXArray(types.NewXText("")),
-			"created_on":           types.NewXDateTime(time.Date(2019, 4, 5, 14, 16, 30, 123456, time.UTC)),
+			"created_on":           types.NewXDateTime(test.MustParseTime("2019-04-05T14:16:30.000123456Z")),
 			"extra":                nil,
 			"input":                types.XTextEmpty,
 			"name":                 types.NewXText("Empty"),
@@ -52,17 +52,17 @@ func (r *baseResume) Contact() *flows.Contact       { return r.contact }
 func (r *baseResume) ResumedOn() time.Time          { return r.resumedOn }
 
 // Apply applies our state changes and saves any events to the run
-func (r *baseResume) Apply(run flows.FlowRun, logEvent flows.EventCallback) {
+func (r *baseResume) Apply(run flows.FlowRun, logEvent flows.EventSink) {
 	if r.environment != nil {
 		if !run.Session().Environment().Equal(r.environment) {
-			logEvent(events.NewEnvironmentRefreshed(r.environment))
+			logEvent.Add(events.NewEnvironmentRefreshed(r.environment))
 		}
 
 		run.Session().SetEnvironment(r.environment)
 	}
 	if r.contact != nil {
 		if !run.Session().Contact().Equal(r.contact) {
-			logEvent(events.NewContactRefreshed(r.contact))
+			logEvent.Add(events.NewContactRefreshed(r.contact))
 		}
 
 		run.Session().SetContact(r.contact)
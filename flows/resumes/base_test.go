@@ -6,7 +6,6 @@ import (
 	"io/ioutil"
 	"sort"
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/jsonx"
@@ -18,6 +17,7 @@ import (
 	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/engine"
+	"github.com/nyaruka/goflow/flows/events"
 	"github.com/nyaruka/goflow/flows/resumes"
 	"github.com/nyaruka/goflow/flows/triggers"
 	"github.com/nyaruka/goflow/test"
@@ -66,7 +66,7 @@ func testResumeType(t *testing.T, assetsJSON json.RawMessage, typeName string) {
 	defer uuids.SetGenerator(uuids.DefaultGenerator)
 
 	for i, tc := range tests {
-		dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 10, 18, 14, 20, 30, 123456, time.UTC)))
+		dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-10-18T14:20:30.000123456Z")))
 		uuids.SetGenerator(uuids.NewSeededGenerator(12345))
 
 		testName := fmt.Sprintf("test '%s' for resume type '%s'", tc.Description, typeName)
@@ -182,3 +182,168 @@ func TestResumeContext(t *testing.T) {
 	assert.Equal(t, types.NewXText("dial"), context["type"])
 	assert.NotNil(t, context["dial"])
 }
+
+// starts a session in the first flow of the given assets, which will be waiting for a message
+func startWaitingSession(t *testing.T, sa flows.SessionAssets) flows.Session {
+	flow, err := sa.Flows().Get("ed352c17-191e-4e75-b366-1b2c54bb32d8")
+	require.NoError(t, err)
+
+	env := envs.NewBuilder().Build()
+	trigger := triggers.NewBuilder(env, flow.Reference(), flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil)).Manual().Build()
+
+	session, _, err := engine.NewBuilder().Build().NewSession(sa, trigger)
+	require.NoError(t, err)
+	require.Equal(t, flows.SessionStatusWaiting, session.Status())
+
+	return session
+}
+
+func TestDigitsResume(t *testing.T) {
+	assetsJSON, err := ioutil.ReadFile("testdata/_assets.json")
+	require.NoError(t, err)
+
+	// route on the value of the digits rather than the text
+	router := []string{"flows", "[0]", "nodes", "[0]", "router"}
+	assetsJSON = test.JSONReplace(assetsJSON, append(router, "wait"), []byte(`{"type": "msg", "hint": {"type": "digits", "count": 1}}`))
+	assetsJSON = test.JSONReplace(assetsJSON, append(router, "operand"), []byte(`"@input.digits"`))
+	assetsJSON = test.JSONReplace(assetsJSON, append(router, "cases"), []byte(`[
+		{"uuid": "98503572-25bf-40ce-ad72-8836b6549a38", "type": "has_number_eq", "arguments": ["1"], "category_uuid": "598ae7a5-2f81-48f1-afac-595262514aa1"},
+		{"uuid": "a51e5c8c-c891-401d-9c62-15fc37278c94", "type": "has_number_eq", "arguments": ["2"], "category_uuid": "c70fe86c-9aac-4cc2-a5cb-d35cbe3fed6e"}
+	]`))
+
+	sa, err := test.CreateSessionAssets(assetsJSON, "")
+	require.NoError(t, err)
+
+	tcs := []struct {
+		digits   string
+		category string
+	}{
+		{"1", "Red"},
+		{"2", "Blue"},
+		{"3", "Other"},
+		{"21", "Blue"}, // truncated to the single digit expected
+	}
+
+	for _, tc := range tcs {
+		session := startWaitingSession(t, sa)
+
+		resume := resumes.NewDigits(nil, nil, tc.digits)
+		assert.Equal(t, resumes.TypeDigits, resume.Type())
+		assert.Equal(t, tc.digits, resume.Digits())
+
+		_, err = session.Resume(resume)
+		require.NoError(t, err)
+
+		result := session.Runs()[0].Results().Get("favorite_color")
+		require.NotNil(t, result, "no result for digits %s", tc.digits)
+		assert.Equal(t, tc.category, result.Category, "category mismatch for digits %s", tc.digits)
+	}
+}
+
+func TestLocationResume(t *testing.T) {
+	defer dates.SetNowSource(dates.DefaultNowSource)
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-10-18T14:20:30.000123456Z")))
+
+	assetsJSON, err := ioutil.ReadFile("testdata/_assets.json")
+	require.NoError(t, err)
+
+	assetsJSON = test.JSONReplace(assetsJSON, []string{"flows", "[0]", "nodes", "[0]", "router", "wait"}, []byte(`{"type": "msg", "hint": {"type": "location"}}`))
+
+	sa, err := test.CreateSessionAssets(assetsJSON, "")
+	require.NoError(t, err)
+
+	resume := resumes.NewLocation(nil, nil, -2.90875, -79.0117686, 10.5)
+	assert.Equal(t, resumes.TypeLocation, resume.Type())
+	assert.Equal(t, -2.90875, resume.Latitude())
+	assert.Equal(t, -79.0117686, resume.Longitude())
+	assert.Equal(t, float32(10.5), resume.Accuracy())
+
+	// check it round trips through JSON
+	marshaled, err := jsonx.Marshal(resume)
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"location","resumed_on":"2018-10-18T14:20:30.000123456Z","latitude":-2.90875,"longitude":-79.0117686,"accuracy":10.5}`, string(marshaled))
+
+	read, err := resumes.ReadResume(sa, marshaled, assets.PanicOnMissing)
+	require.NoError(t, err)
+	assert.Equal(t, resume, read)
+
+	// accuracy is optional
+	marshaled, err = jsonx.Marshal(resumes.NewLocation(nil, nil, -2.90875, -79.0117686, 0))
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"location","resumed_on":"2018-10-18T14:20:30.000123456Z","latitude":-2.90875,"longitude":-79.0117686}`, string(marshaled))
+
+	// resuming a session with a location sets input to a message with a geo attachment
+	session := startWaitingSession(t, sa)
+
+	_, err = session.Resume(resume)
+	require.NoError(t, err)
+
+	attachment, err := session.Runs()[0].EvaluateTemplate(`@input.attachments.0`)
+	assert.NoError(t, err)
+	assert.Equal(t, "geo:-2.90875,-79.0117686", attachment)
+}
+
+func TestMsgResumeQuickReply(t *testing.T) {
+	sa, err := test.LoadSessionAssets(envs.NewBuilder().Build(), "testdata/_assets.json")
+	require.NoError(t, err)
+
+	tcs := []struct {
+		quickReply string
+		expected   string
+	}{
+		{"COLOR_RED", "COLOR_RED"},
+		{"", ""},
+	}
+
+	for _, tc := range tcs {
+		session := startWaitingSession(t, sa)
+
+		msg := flows.NewMsgIn(flows.MsgUUID(uuids.New()), "tel:+12065551212", nil, "Red", nil)
+		msg.SetQuickReply(tc.quickReply)
+
+		_, err = session.Resume(resumes.NewMsg(nil, nil, msg))
+		require.NoError(t, err)
+
+		value, err := session.Runs()[0].EvaluateTemplate("@input.quick_reply")
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, value, "quick reply mismatch for '%s'", tc.quickReply)
+	}
+}
+
+func TestMsgResumeExternalID(t *testing.T) {
+	sa, err := test.LoadSessionAssets(envs.NewBuilder().Build(), "testdata/_assets.json")
+	require.NoError(t, err)
+
+	session := startWaitingSession(t, sa)
+
+	msg := flows.NewMsgIn(flows.MsgUUID(uuids.New()), "tel:+12065551212", nil, "Red", nil)
+	msg.SetExternalID("SMS123")
+
+	sprint, err := session.Resume(resumes.NewMsg(nil, nil, msg))
+	require.NoError(t, err)
+
+	// external ID is included in the msg_received event
+	require.Equal(t, events.TypeMsgReceived, sprint.Events()[0].Type())
+	event := sprint.Events()[0].(*events.MsgReceivedEvent)
+	assert.Equal(t, "SMS123", event.Msg.ExternalID())
+
+	// and survives a round trip through JSON
+	eventJSON, err := jsonx.Marshal(event)
+	require.NoError(t, err)
+	assert.Contains(t, string(eventJSON), `"external_id":"SMS123"`)
+
+	read, err := events.ReadEvent(eventJSON)
+	require.NoError(t, err)
+	assert.Equal(t, "SMS123", read.(*events.MsgReceivedEvent).Msg.ExternalID())
+
+	// as does the session's input
+	sessionJSON, err := jsonx.Marshal(session)
+	require.NoError(t, err)
+
+	session, err = engine.NewBuilder().Build().ReadSession(sa, sessionJSON, assets.PanicOnMissing)
+	require.NoError(t, err)
+
+	value, err := session.Runs()[0].EvaluateTemplate("@input.external_id")
+	require.NoError(t, err)
+	assert.Equal(t, "SMS123", value)
+}
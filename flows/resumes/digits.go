@@ -0,0 +1,137 @@
+package resumes
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/gocommon/uuids"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/events"
+	"github.com/nyaruka/goflow/flows/inputs"
+	"github.com/nyaruka/goflow/flows/routers/waits/hints"
+	"github.com/nyaruka/goflow/utils"
+)
+
+func init() {
+	registerType(TypeDigits, readDigitsResume)
+}
+
+// TypeDigits is the type for resuming a session with digits entered on an IVR call
+const TypeDigits string = "digits"
+
+// DigitsResume is used when a session is resumed with digits entered by the contact on an IVR call. If the wait
+// being resumed has a digits hint, a trailing terminator is removed and the digits are truncated to the expected count.
+//
+//   {
+//     "type": "digits",
+//     "digits": "123#",
+//     "resumed_on": "2000-01-01T00:00:00.000000000-00:00"
+//   }
+//
+// @resume digits
+type DigitsResume struct {
+	baseResume
+	digits string
+}
+
+// NewDigits creates a new digits resume with the passed in values
+func NewDigits(env envs.Environment, contact *flows.Contact, digits string) *DigitsResume {
+	return &DigitsResume{
+		baseResume: newBaseResume(TypeDigits, env, contact),
+		digits:     digits,
+	}
+}
+
+// Digits returns the digits this resume is based on
+func (r *DigitsResume) Digits() string { return r.digits }
+
+// Apply applies our state changes and saves any events to the run
+func (r *DigitsResume) Apply(run flows.FlowRun, logEvent flows.EventSink) {
+	// do base changes (contact, environment)
+	r.baseResume.Apply(run, logEvent)
+
+	var channel *assets.ChannelReference
+	if run.Session().Trigger().Connection() != nil {
+		channel = run.Session().Trigger().Connection().Channel()
+	}
+
+	// update our input
+	input := inputs.NewDigits(run.Session().Assets(), flows.InputUUID(uuids.New()), channel, r.normalize(run), r.ResumedOn())
+
+	run.Session().SetInput(input)
+	run.ResetExpiration(nil)
+
+	logEvent.Add(events.NewDigitsReceived(r.digits))
+}
+
+// normalizes our digits according to the digits hint of the wait being resumed, if there is one
+func (r *DigitsResume) normalize(run flows.FlowRun) string {
+	digits := r.digits
+
+	_, node, err := run.PathLocation()
+	if err != nil || node.Router() == nil {
+		return digits
+	}
+
+	withHint, hasHint := node.Router().Wait().(interface{ Hint() flows.Hint })
+	if !hasHint {
+		return digits
+	}
+
+	hint, isDigits := withHint.Hint().(*hints.DigitsHint)
+	if !isDigits {
+		return digits
+	}
+
+	if hint.TerminatedBy != "" {
+		digits = strings.TrimSuffix(digits, hint.TerminatedBy)
+	}
+	if hint.Count != nil && len(digits) > *hint.Count {
+		digits = digits[:*hint.Count]
+	}
+	return digits
+}
+
+var _ flows.Resume = (*DigitsResume)(nil)
+
+//------------------------------------------------------------------------------------------
+// JSON Encoding / Decoding
+//------------------------------------------------------------------------------------------
+
+type digitsResumeEnvelope struct {
+	baseResumeEnvelope
+	Digits string `json:"digits" validate:"required"`
+}
+
+func readDigitsResume(sessionAssets flows.SessionAssets, data json.RawMessage, missing assets.MissingCallback) (flows.Resume, error) {
+	e := &digitsResumeEnvelope{}
+	if err := utils.UnmarshalAndValidate(data, e); err != nil {
+		return nil, err
+	}
+
+	r := &DigitsResume{
+		digits: e.Digits,
+	}
+
+	if err := r.unmarshal(sessionAssets, &e.baseResumeEnvelope, missing); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// MarshalJSON marshals this resume into JSON
+func (r *DigitsResume) MarshalJSON() ([]byte, error) {
+	e := &digitsResumeEnvelope{
+		Digits: r.digits,
+	}
+
+	if err := r.marshal(&e.baseResumeEnvelope); err != nil {
+		return nil, err
+	}
+
+	return jsonx.Marshal(e)
+}
@@ -0,0 +1,137 @@
+package resumes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/gocommon/urns"
+	"github.com/nyaruka/gocommon/uuids"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/events"
+	"github.com/nyaruka/goflow/flows/inputs"
+	"github.com/nyaruka/goflow/utils"
+
+	"gopkg.in/go-playground/validator.v9"
+)
+
+func init() {
+	registerType(TypeLocation, readLocationResume)
+
+	utils.RegisterValidatorAlias("geo_latitude", "min=-90,max=90", func(validator.FieldError) string {
+		return "is not a valid latitude"
+	})
+	utils.RegisterValidatorAlias("geo_longitude", "min=-180,max=180", func(validator.FieldError) string {
+		return "is not a valid longitude"
+	})
+}
+
+// TypeLocation is the type for resuming a session with a location
+const TypeLocation string = "location"
+
+// LocationResume is used when a session is resumed with a location provided by the contact, e.g. from the GPS of
+// their device. The input for the run will be a message with a geo:<lat>,<long> attachment.
+//
+//   {
+//     "type": "location",
+//     "latitude": -2.90875,
+//     "longitude": -79.0117686,
+//     "accuracy": 10.5,
+//     "resumed_on": "2000-01-01T00:00:00.000000000-00:00"
+//   }
+//
+// @resume location
+type LocationResume struct {
+	baseResume
+
+	latitude  float64
+	longitude float64
+	accuracy  float32
+}
+
+// NewLocation creates a new location resume with the passed in values
+func NewLocation(env envs.Environment, contact *flows.Contact, latitude, longitude float64, accuracy float32) *LocationResume {
+	return &LocationResume{
+		baseResume: newBaseResume(TypeLocation, env, contact),
+		latitude:   latitude,
+		longitude:  longitude,
+		accuracy:   accuracy,
+	}
+}
+
+// Latitude returns the latitude of the location
+func (r *LocationResume) Latitude() float64 { return r.latitude }
+
+// Longitude returns the longitude of the location
+func (r *LocationResume) Longitude() float64 { return r.longitude }
+
+// Accuracy returns the accuracy radius of the location in meters, or zero if that's not known
+func (r *LocationResume) Accuracy() float32 { return r.accuracy }
+
+// Apply applies our state changes and saves any events to the run
+func (r *LocationResume) Apply(run flows.FlowRun, logEvent flows.EventSink) {
+	// do base changes (contact, environment)
+	r.baseResume.Apply(run, logEvent)
+
+	attachment := utils.Attachment(fmt.Sprintf("geo:%s,%s", strconv.FormatFloat(r.latitude, 'f', -1, 64), strconv.FormatFloat(r.longitude, 'f', -1, 64)))
+	msg := flows.NewMsgIn(flows.MsgUUID(uuids.New()), urns.NilURN, nil, "", []utils.Attachment{attachment})
+
+	// update our input
+	input := inputs.NewMsg(run.Session().Assets(), msg, r.ResumedOn())
+
+	run.Session().SetInput(input)
+	run.ResetExpiration(nil)
+
+	logEvent.Add(events.NewLocationReceived(r.latitude, r.longitude, r.accuracy))
+}
+
+var _ flows.Resume = (*LocationResume)(nil)
+
+//------------------------------------------------------------------------------------------
+// JSON Encoding / Decoding
+//------------------------------------------------------------------------------------------
+
+type locationResumeEnvelope struct {
+	baseResumeEnvelope
+
+	Latitude  float64 `json:"latitude" validate:"geo_latitude"`
+	Longitude float64 `json:"longitude" validate:"geo_longitude"`
+	Accuracy  float32 `json:"accuracy,omitempty"`
+}
+
+func readLocationResume(sessionAssets flows.SessionAssets, data json.RawMessage, missing assets.MissingCallback) (flows.Resume, error) {
+	e := &locationResumeEnvelope{}
+	if err := utils.UnmarshalAndValidate(data, e); err != nil {
+		return nil, err
+	}
+
+	r := &LocationResume{
+		latitude:  e.Latitude,
+		longitude: e.Longitude,
+		accuracy:  e.Accuracy,
+	}
+
+	if err := r.unmarshal(sessionAssets, &e.baseResumeEnvelope, missing); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// MarshalJSON marshals this resume into JSON
+func (r *LocationResume) MarshalJSON() ([]byte, error) {
+	e := &locationResumeEnvelope{
+		Latitude:  r.latitude,
+		Longitude: r.longitude,
+		Accuracy:  r.accuracy,
+	}
+
+	if err := r.marshal(&e.baseResumeEnvelope); err != nil {
+		return nil, err
+	}
+
+	return jsonx.Marshal(e)
+}
@@ -46,10 +46,10 @@ func NewRunExpiration(env envs.Environment, contact *flows.Contact) *RunExpirati
 }
 
 // Apply applies our state changes and saves any events to the run
-func (r *RunExpirationResume) Apply(run flows.FlowRun, logEvent flows.EventCallback) {
+func (r *RunExpirationResume) Apply(run flows.FlowRun, logEvent flows.EventSink) {
 	run.Exit(flows.RunStatusExpired)
 
-	logEvent(events.NewRunExpired(run))
+	logEvent.Add(events.NewRunExpired(run))
 
 	r.baseResume.Apply(run, logEvent)
 }
@@ -46,10 +46,10 @@ func NewWaitTimeout(env envs.Environment, contact *flows.Contact) *WaitTimeoutRe
 }
 
 // Apply applies our state changes and saves any events to the run
-func (r *WaitTimeoutResume) Apply(run flows.FlowRun, logEvent flows.EventCallback) {
+func (r *WaitTimeoutResume) Apply(run flows.FlowRun, logEvent flows.EventSink) {
 	// clear the last input
 	run.Session().SetInput(nil)
-	logEvent(events.NewWaitTimedOut())
+	logEvent.Add(events.NewWaitTimedOut())
 
 	r.baseResume.Apply(run, logEvent)
 }
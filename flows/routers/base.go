@@ -2,6 +2,7 @@ package routers
 
 import (
 	"encoding/json"
+	"reflect"
 	"time"
 
 	"github.com/nyaruka/gocommon/dates"
@@ -67,7 +68,7 @@ func (r *baseRouter) AllowTimeout() bool {
 func (r *baseRouter) ResultName() string { return r.resultName }
 
 // EnumerateTemplates enumerates all expressions on this object and its children
-func (r *baseRouter) EnumerateTemplates(localization flows.Localization, include func(envs.Language, string)) {
+func (r *baseRouter) EnumerateTemplates(localization flows.Localization, include func(string, envs.Language, string)) {
 }
 
 // EnumerateDependencies enumerates all dependencies on this object
@@ -77,9 +78,12 @@ func (r *baseRouter) EnumerateDependencies(localization flows.Localization, incl
 // EnumerateResults enumerates all potential results on this object
 func (r *baseRouter) EnumerateResults(include func(*flows.ResultInfo)) {
 	if r.resultName != "" {
-		categoryNames := make([]string, len(r.categories))
-		for i := range r.categories {
-			categoryNames[i] = r.categories[i].Name()
+		// categories with the same name are the same outcome so only include each name once
+		categoryNames := make([]string, 0, len(r.categories))
+		for _, cat := range r.categories {
+			if !utils.StringSliceContains(categoryNames, cat.Name(), false) {
+				categoryNames = append(categoryNames, cat.Name())
+			}
 		}
 
 		include(flows.NewResultInfo(r.resultName, categoryNames))
@@ -134,10 +138,10 @@ func (r *baseRouter) isValidExit(uuid flows.ExitUUID, exits []flows.Exit) bool {
 	return false
 }
 
-type routerFunc func(run flows.FlowRun, step flows.Step, logEvent flows.EventCallback) (flows.ExitUUID, error)
+type routerFunc func(run flows.FlowRun, step flows.Step, logEvent flows.EventSink) (flows.ExitUUID, error)
 
 // RouteTimeout routes in the case that this router's wait timed out
-func (r *baseRouter) RouteTimeout(run flows.FlowRun, step flows.Step, logEvent flows.EventCallback) (flows.ExitUUID, error) {
+func (r *baseRouter) RouteTimeout(run flows.FlowRun, step flows.Step, logEvent flows.EventSink) (flows.ExitUUID, error) {
 	if !r.AllowTimeout() {
 		return "", errors.New("can't call route timeout on router with no timeout")
 	}
@@ -157,7 +161,7 @@ func (r *baseRouter) RouteTimeout(run flows.FlowRun, step flows.Step, logEvent f
 	return r.routeToCategory(run, step, r.wait.Timeout().CategoryUUID(), dates.FormatISO(timedOutOn), "", nil, logEvent)
 }
 
-func (r *baseRouter) routeToCategory(run flows.FlowRun, step flows.Step, categoryUUID flows.CategoryUUID, match string, input string, extra *types.XObject, logEvent flows.EventCallback) (flows.ExitUUID, error) {
+func (r *baseRouter) routeToCategory(run flows.FlowRun, step flows.Step, categoryUUID flows.CategoryUUID, match string, input string, extra *types.XObject, logEvent flows.EventSink) (flows.ExitUUID, error) {
 	// router failed to pick a category
 	if categoryUUID == "" {
 		return "", nil
@@ -187,7 +191,7 @@ func (r *baseRouter) routeToCategory(run flows.FlowRun, step flows.Step, categor
 		}
 		result := flows.NewResult(r.resultName, match, category.Name(), localizedCategory, step.NodeUUID(), input, extraJSON, dates.Now())
 		run.SaveResult(result)
-		logEvent(events.NewRunResultChanged(result))
+		logEvent.Add(events.NewRunResultChanged(result))
 	}
 
 	return category.ExitUUID(), nil
@@ -204,6 +208,19 @@ type baseRouterEnvelope struct {
 	Categories []json.RawMessage `json:"categories,omitempty"  validate:"required,min=1"`
 }
 
+// EnvelopeTypes returns the types which each type of router is read from, e.g. for generating a schema
+func EnvelopeTypes() map[string]reflect.Type {
+	return map[string]reflect.Type{
+		TypeRandom: reflect.TypeOf(baseRouterEnvelope{}),
+		TypeSwitch: reflect.TypeOf(switchRouterEnvelope{}),
+	}
+}
+
+// CategoryEnvelopeType returns the type which router categories are read from, e.g. for generating a schema
+func CategoryEnvelopeType() reflect.Type {
+	return reflect.TypeOf(categoryEnvelope{})
+}
+
 // ReadRouter reads a router from the given JSON
 func ReadRouter(data json.RawMessage) (flows.Router, error) {
 	typeName, err := utils.ReadTypeFromJSON(data)
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/jsonx"
@@ -72,7 +71,7 @@ func testRouterType(t *testing.T, assetsJSON json.RawMessage, typeName string) {
 	defer random.SetGenerator(random.DefaultGenerator)
 
 	for i, tc := range tests {
-		dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 10, 18, 14, 20, 30, 123456, time.UTC)))
+		dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-10-18T14:20:30.000123456Z")))
 		uuids.SetGenerator(uuids.NewSeededGenerator(12345))
 		random.SetGenerator(random.NewSeededGenerator(123456))
 
@@ -167,3 +166,18 @@ func TestReadRouter(t *testing.T) {
 	_, err = routers.ReadRouter([]byte(`{"type": "do_the_foo", "foo": "bar"}`))
 	assert.EqualError(t, err, "unknown type: 'do_the_foo'")
 }
+
+func TestRouterResultCategories(t *testing.T) {
+	router := routers.NewSwitch(nil, "Likes Beer", []flows.Category{
+		routers.NewCategory("598ae7a5-2f81-48f1-afac-595262514aa1", "Yes", "49a47f31-ec90-42b5-a0d8-6efb5b1fa57b"),
+		routers.NewCategory("c70fe86c-9aac-4cc2-a5cb-d35cbe3fed6e", "No", "5bd6a427-2b9a-4a4d-ad3f-eb39eaaa7e5a"),
+		routers.NewCategory("fc4ee6b0-af6f-42e3-ae84-153c313e390a", "yes", "49a47f31-ec90-42b5-a0d8-6efb5b1fa57b"),
+		routers.NewCategory("78ae8f05-f92e-43b2-a886-406eaea1b8e0", "Other", "b787ffe3-c21a-46ad-9475-954614b52477"),
+	}, "@input.text", nil, "78ae8f05-f92e-43b2-a886-406eaea1b8e0")
+
+	var results []*flows.ResultInfo
+	router.EnumerateResults(func(r *flows.ResultInfo) { results = append(results, r) })
+
+	// categories with the same name are aggregated into a single outcome
+	assert.Equal(t, []*flows.ResultInfo{flows.NewResultInfo("Likes Beer", []string{"Yes", "No", "Other"})}, results)
+}
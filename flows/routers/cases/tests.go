@@ -656,6 +656,19 @@ func HasDistrict(env envs.Environment, args ...types.XValue) types.XValue {
 		}
 	}
 
+	// finally try a similarity search in case the district name is misspelled
+	var state *envs.Location
+	if len(states) > 0 {
+		state = states[0]
+	}
+	similar, canFindSimilar := locations.(envs.SimilarLocationResolver)
+	if canFindSimilar && (state != nil || stateText.Empty()) {
+		districts := similar.FindLocationsSimilar(text.Native(), flows.LocationLevelDistrict, state)
+		if len(districts) > 0 {
+			return NewTrueResult(types.NewXText(string(districts[0].Path())))
+		}
+	}
+
 	return FalseResult
 }
 
@@ -297,6 +297,9 @@ var testTests = []struct {
 	{"has_district", []types.XValue{xs("I live in gasabo"), xs("kigali")}, result(xs("Rwanda > Kigali City > Gasabo"))},
 	{"has_district", []types.XValue{xs("Gasabo")}, result(xs("Rwanda > Kigali City > Gasabo"))},
 	{"has_district", []types.XValue{xs("xyz"), xs("kigali")}, falseResult},
+	{"has_district", []types.XValue{xs("Gasabbo"), xs("kigali")}, result(xs("Rwanda > Kigali City > Gasabo"))},
+	{"has_district", []types.XValue{xs("Nyarugenje")}, result(xs("Rwanda > Kigali City > Nyarugenge"))},
+	{"has_district", []types.XValue{xs("Gasabbo"), xs("Boston")}, falseResult},
 	{"has_district", []types.XValue{ERROR}, ERROR},
 
 	{"has_ward", []types.XValue{xs("Gisozi"), xs("Gasabo"), xs("kigali")}, result(xs("Rwanda > Kigali City > Gasabo > Gisozi"))},
@@ -455,7 +458,7 @@ var testTests = []struct {
 }
 
 func TestTests(t *testing.T) {
-	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 4, 11, 13, 24, 30, 123456000, time.UTC)))
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-04-11T13:24:30.123456Z")))
 	defer dates.SetNowSource(dates.DefaultNowSource)
 
 	env := envs.NewBuilder().
@@ -487,6 +490,33 @@ func TestTests(t *testing.T) {
 	}
 }
 
+func TestLocationTests(t *testing.T) {
+	env := envs.NewBuilder().WithDefaultCountry(envs.Country("RW")).Build()
+
+	hierarchy, err := envs.ReadLocationHierarchy([]byte(locationHierarchyJSON))
+	require.NoError(t, err)
+
+	la := flows.NewLocationAssets([]assets.LocationHierarchy{hierarchy})
+	env = flows.NewEnvironment(env, la)
+
+	tests := []struct {
+		test     string
+		args     []types.XValue
+		expected string
+	}{
+		{"has_state", []types.XValue{xs("I live in kigari")}, "Rwanda > Kigali City"},
+		{"has_district", []types.XValue{xs("gasabo"), xs("Kigali")}, "Rwanda > Kigali City > Gasabo"},
+		{"has_ward", []types.XValue{xs("ndera"), xs("Gasabo"), xs("kigali")}, "Rwanda > Kigali City > Gasabo > Ndera"},
+	}
+
+	for _, tc := range tests {
+		expected := la.FindByPath(tc.expected)
+		require.NotNil(t, expected, "no such location %s", tc.expected)
+
+		test.AssertXEqual(t, result(xs(string(expected.Path()))), cases.XTESTS[tc.test](env, tc.args...), "result mismatch for %s", tc.test)
+	}
+}
+
 func TestEvaluateTemplate(t *testing.T) {
 	vars := types.NewXObject(map[string]types.XValue{
 		"int1":   types.NewXNumberFromInt(1),
@@ -34,7 +34,7 @@ func (r *RandomRouter) Validate(flow flows.Flow, exits []flows.Exit) error {
 }
 
 // Route determines which exit to take from a node
-func (r *RandomRouter) Route(run flows.FlowRun, step flows.Step, logEvent flows.EventCallback) (flows.ExitUUID, error) {
+func (r *RandomRouter) Route(run flows.FlowRun, step flows.Step, logEvent flows.EventSink) (flows.ExitUUID, error) {
 	// pick a random category
 	rand := random.Decimal()
 	categoryNum := rand.Mul(decimal.New(int64(len(r.categories)), 0)).IntPart()
@@ -117,7 +117,7 @@ func (r *SwitchRouter) Validate(flow flows.Flow, exits []flows.Exit) error {
 }
 
 // Route determines which exit to take from a node
-func (r *SwitchRouter) Route(run flows.FlowRun, step flows.Step, logEvent flows.EventCallback) (flows.ExitUUID, error) {
+func (r *SwitchRouter) Route(run flows.FlowRun, step flows.Step, logEvent flows.EventSink) (flows.ExitUUID, error) {
 	env := run.Environment()
 
 	// first evaluate our operand
@@ -126,12 +126,9 @@ func (r *SwitchRouter) Route(run flows.FlowRun, step flows.Step, logEvent flows.
 		run.LogError(step, err)
 	}
 
-	var input string
-
-	if operand != nil {
-		asText, _ := types.ToXText(env, operand)
-		input = asText.Native()
-	}
+	// and convert it to text once, as that's used as both the input and the match of the default category
+	asText, textErr := types.ToXText(env, operand)
+	input := asText.Native()
 
 	// find first matching case
 	match, categoryUUID, extra, err := r.matchCase(run, step, operand)
@@ -141,13 +138,11 @@ func (r *SwitchRouter) Route(run flows.FlowRun, step flows.Step, logEvent flows.
 
 	// none of our cases matched, so try to use the default
 	if categoryUUID == "" && r.defaultCategoryUUID != "" {
-		// evaluate our operand as a string
-		value, xerr := types.ToXText(env, operand)
-		if xerr != nil {
-			run.LogError(step, xerr)
+		if textErr != nil {
+			run.LogError(step, textErr)
 		}
 
-		match = value.Native()
+		match = input
 		categoryUUID = r.defaultCategoryUUID
 	}
 
@@ -213,10 +208,10 @@ func (r *SwitchRouter) matchCase(run flows.FlowRun, step flows.Step, operand typ
 }
 
 // EnumerateTemplates enumerates all expressions on this object and its children
-func (r *SwitchRouter) EnumerateTemplates(localization flows.Localization, include func(envs.Language, string)) {
-	include(envs.NilLanguage, r.operand)
+func (r *SwitchRouter) EnumerateTemplates(localization flows.Localization, include func(string, envs.Language, string)) {
+	include("operand", envs.NilLanguage, r.operand)
 
-	inspect.Templates(r.cases, localization, include)
+	inspect.Templates(r.cases, localization, func(f string, l envs.Language, t string) { include("cases."+f, l, t) })
 }
 
 // EnumerateDependencies enumerates all dependencies on this object and its children
@@ -2,6 +2,7 @@ package waits
 
 import (
 	"encoding/json"
+	"reflect"
 
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/utils"
@@ -73,6 +74,14 @@ type baseWaitEnvelope struct {
 	Timeout *Timeout `json:"timeout,omitempty" validate:"omitempty,dive"`
 }
 
+// EnvelopeTypes returns the types which each type of wait is read from, e.g. for generating a schema
+func EnvelopeTypes() map[string]reflect.Type {
+	return map[string]reflect.Type{
+		TypeDial: reflect.TypeOf(dialWaitEnvelope{}),
+		TypeMsg:  reflect.TypeOf(msgWaitEnvelope{}),
+	}
+}
+
 // ReadWait reads a wait from the given JSON
 func ReadWait(data json.RawMessage) (flows.Wait, error) {
 	typeName, err := utils.ReadTypeFromJSON(data)
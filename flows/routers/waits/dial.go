@@ -39,19 +39,19 @@ func (w *DialWait) AllowedFlowTypes() []flows.FlowType {
 }
 
 // Begin beings waiting at this wait
-func (w *DialWait) Begin(run flows.FlowRun, log flows.EventCallback) flows.ActivatedWait {
+func (w *DialWait) Begin(run flows.FlowRun, log flows.EventSink) flows.ActivatedWait {
 	phone, err := run.EvaluateTemplate(w.phone)
 	if err != nil {
-		log(events.NewError(err))
+		log.Add(events.NewError(err))
 	}
 
 	urn, err := urns.NewTelURNForCountry(phone, string(run.Environment().DefaultCountry()))
 	if err != nil {
-		log(events.NewError(err))
+		log.Add(events.NewError(err))
 		return nil
 	}
 
-	log(events.NewDialWait(urn))
+	log.Add(events.NewDialWait(urn))
 
 	return NewActivatedDialWait(urn)
 }
@@ -34,7 +34,7 @@ func TestDialWait(t *testing.T) {
 
 	// try activating the wait
 	log := test.NewEventLog()
-	activated := wait.Begin(run, log.Log)
+	activated := wait.Begin(run, log)
 
 	assert.Equal(t, "dial", activated.Type())
 	assert.Equal(t, 1, len(log.Events))
@@ -57,7 +57,7 @@ func TestDialWait(t *testing.T) {
 	wait, err = waits.ReadWait([]byte(`{"type": "dial", "phone": "+593979123456@(1 / 0)"}`))
 
 	log = test.NewEventLog()
-	activated = wait.Begin(run, log.Log)
+	activated = wait.Begin(run, log)
 
 	assert.Equal(t, "dial", activated.Type())
 	assert.Equal(t, urns.URN("tel:+593979123456"), activated.(*waits.ActivatedDialWait).URN())
@@ -69,7 +69,7 @@ func TestDialWait(t *testing.T) {
 	wait, err = waits.ReadWait([]byte(`{"type": "dial", "phone": "@(\"\")"}`))
 
 	log = test.NewEventLog()
-	activated = wait.Begin(run, log.Log)
+	activated = wait.Begin(run, log)
 
 	assert.Nil(t, activated)
 	assert.Equal(t, 1, len(log.Events))
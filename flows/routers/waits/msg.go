@@ -40,6 +40,16 @@ func NewMsgWait(timeout *Timeout, hint flows.Hint) *MsgWait {
 	}
 }
 
+// NewDigitsWait creates a new message wait for an IVR call which expects the given number of digits
+func NewDigitsWait(timeout *Timeout, count int) *MsgWait {
+	return NewMsgWait(timeout, hints.NewFixedDigitsHint(count))
+}
+
+// NewLocationWait creates a new message wait which expects the contact to provide a location
+func NewLocationWait(timeout *Timeout) *MsgWait {
+	return NewMsgWait(timeout, hints.NewLocationHint())
+}
+
 // Hint returns the hint (optional)
 func (w *MsgWait) Hint() flows.Hint { return w.hint }
 
@@ -49,7 +59,7 @@ func (w *MsgWait) AllowedFlowTypes() []flows.FlowType {
 }
 
 // Begin beings waiting at this wait
-func (w *MsgWait) Begin(run flows.FlowRun, log flows.EventCallback) flows.ActivatedWait {
+func (w *MsgWait) Begin(run flows.FlowRun, log flows.EventSink) flows.ActivatedWait {
 	var timeoutSeconds *int
 
 	if w.timeout != nil {
@@ -64,7 +74,7 @@ func (w *MsgWait) Begin(run flows.FlowRun, log flows.EventCallback) flows.Activa
 		return nil
 	}
 
-	log(events.NewMsgWait(timeoutSeconds, w.hint))
+	log.Add(events.NewMsgWait(timeoutSeconds, w.hint))
 
 	return NewActivatedMsgWait(timeoutSeconds, w.hint)
 }
@@ -74,6 +84,16 @@ func (w *MsgWait) End(resume flows.Resume) error {
 	switch resume.Type() {
 	case resumes.TypeMsg, resumes.TypeRunExpiration:
 		return nil
+	case resumes.TypeDigits:
+		if _, isDigits := w.hint.(*hints.DigitsHint); !isDigits {
+			return errors.Errorf("can't end with digits as wait doesn't have a digits hint")
+		}
+		return nil
+	case resumes.TypeLocation:
+		if _, isLocation := w.hint.(*hints.LocationHint); !isLocation {
+			return errors.Errorf("can't end with location as wait doesn't have a location hint")
+		}
+		return nil
 	case resumes.TypeWaitTimeout:
 		if w.timeout == nil {
 			return errors.Errorf("can't end with timeout as wait doesn't have a timeout")
@@ -78,7 +78,7 @@ func TestMsgWait(t *testing.T) {
 
 	// try activating the wait
 	log := test.NewEventLog()
-	activated := wait.Begin(run, log.Log)
+	activated := wait.Begin(run, log)
 
 	assert.Equal(t, "msg", activated.Type())
 	assert.Equal(t, 1, len(log.Events))
@@ -96,6 +96,36 @@ func TestMsgWait(t *testing.T) {
 	// try to end with timeout resume type
 	err = wait.End(resumes.NewWaitTimeout(nil, nil))
 	assert.NoError(t, err)
+
+	// can't end with digits if the wait isn't expecting digits
+	err = wait.End(resumes.NewDigits(nil, nil, "123"))
+	assert.EqualError(t, err, "can't end with digits as wait doesn't have a digits hint")
+
+	// or with a location if the wait isn't expecting a location
+	err = wait.End(resumes.NewLocation(nil, nil, -2.90875, -79.0117686, 0))
+	assert.EqualError(t, err, "can't end with location as wait doesn't have a location hint")
+}
+
+func TestLocationWait(t *testing.T) {
+	wait := waits.NewLocationWait(waits.NewTimeout(60, flows.CategoryUUID("63fca57d-5ef6-4afd-9bcd-7bdcf653cea8")))
+
+	marshaled, err := jsonx.Marshal(wait)
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"msg","timeout":{"seconds":60,"category_uuid":"63fca57d-5ef6-4afd-9bcd-7bdcf653cea8"},"hint":{"type":"location"}}`, string(marshaled))
+
+	assert.NoError(t, wait.End(resumes.NewLocation(nil, nil, -2.90875, -79.0117686, 0)))
+}
+
+func TestDigitsWait(t *testing.T) {
+	wait := waits.NewDigitsWait(nil, 4)
+
+	marshaled, err := jsonx.Marshal(wait)
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"msg","hint":{"type":"digits","count":4}}`, string(marshaled))
+
+	// can be ended with digits or a message
+	assert.NoError(t, wait.End(resumes.NewDigits(nil, nil, "1234")))
+	assert.NoError(t, wait.End(resumes.NewMsg(nil, nil, flows.NewMsgIn(flows.MsgUUID(uuids.New()), urns.NilURN, nil, "1234", nil))))
 }
 
 func TestMsgWaitSkipIfInitial(t *testing.T) {
@@ -11,13 +11,15 @@ import (
 type runEnvironment struct {
 	envs.Environment
 
-	run *flowRun
+	base envs.Environment
+	run  *flowRun
 }
 
 // creates a run environment based on the given run
-func newRunEnvironment(base envs.Environment, run *flowRun) envs.Environment {
+func newRunEnvironment(base envs.Environment, run *flowRun) *runEnvironment {
 	return &runEnvironment{
 		flows.NewEnvironment(base, run.Session().Assets().Locations()),
+		base,
 		run,
 	}
 }
@@ -36,8 +38,8 @@ func (e *runEnvironment) DefaultLanguage() envs.Language {
 	contact := e.run.Contact()
 
 	// if we have a contact and they have a language and it's an allowed language that overrides the base environment's languuage
-	if contact != nil && contact.Language() != envs.NilLanguage && isAllowedLanguage(e, contact.Language()) {
-		return contact.Language()
+	if contact != nil {
+		return e.Environment.ResolveLanguage(contact.Language())
 	}
 	return e.Environment.DefaultLanguage()
 }
@@ -58,12 +60,3 @@ func (e *runEnvironment) DefaultCountry() envs.Country {
 func (e *runEnvironment) DefaultLocale() envs.Locale {
 	return envs.NewLocale(e.DefaultLanguage(), e.DefaultCountry())
 }
-
-func isAllowedLanguage(e envs.Environment, language envs.Language) bool {
-	for _, l := range e.AllowedLanguages() {
-		if language == l {
-			return true
-		}
-	}
-	return false
-}
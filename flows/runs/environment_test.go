@@ -135,4 +135,23 @@ func TestRunEnvironment(t *testing.T) {
 	run.Contact().SetLanguage(envs.Language("spa"))
 	assert.Equal(t, envs.Language("eng"), runEnv.DefaultLanguage())
 	assert.Equal(t, "en-US", runEnv.DefaultLocale().ToBCP47())
+
+	// values not overridden by the contact come from the session environment
+	assert.Equal(t, sessionEnv.DateFormat(), runEnv.DateFormat())
+	assert.Equal(t, sessionEnv.RedactionPolicy(), runEnv.RedactionPolicy())
+
+	// and if the session environment is replaced, e.g. by a resume, the run environment is based on the new one
+	session.SetEnvironment(envs.NewBuilder().
+		WithDateFormat(envs.DateFormatMonthDayYear).
+		WithDefaultLanguage("eng").
+		WithAllowedLanguages([]envs.Language{"eng", "spa"}).
+		WithDefaultCountry("EC").
+		Build())
+
+	runEnv = run.Environment()
+	assert.Equal(t, envs.DateFormatMonthDayYear, runEnv.DateFormat())
+	assert.Equal(t, []envs.Language{"eng", "spa"}, runEnv.AllowedLanguages())
+	assert.Equal(t, envs.Language("spa"), runEnv.DefaultLanguage())
+	assert.Equal(t, envs.Country("US"), runEnv.DefaultCountry())
+	assert.Equal(t, tzUK, runEnv.Timezone())
 }
@@ -2,6 +2,7 @@ package runs
 
 import (
 	"encoding/json"
+	"regexp"
 	"time"
 
 	"github.com/nyaruka/gocommon/dates"
@@ -21,7 +22,7 @@ import (
 type flowRun struct {
 	uuid        flows.RunUUID
 	session     flows.Session
-	environment envs.Environment
+	environment *runEnvironment
 
 	flow    flows.Flow
 	flowRef *assets.FlowReference
@@ -66,9 +67,18 @@ func NewRun(session flows.Session, flow flows.Flow, parent flows.FlowRun) flows.
 	return r
 }
 
-func (r *flowRun) UUID() flows.RunUUID           { return r.uuid }
-func (r *flowRun) Session() flows.Session        { return r.session }
-func (r *flowRun) Environment() envs.Environment { return r.environment }
+func (r *flowRun) UUID() flows.RunUUID    { return r.uuid }
+func (r *flowRun) Session() flows.Session { return r.session }
+
+// Environment returns the environment of this run, which is the session's environment with some values overridden by
+// the contact
+func (r *flowRun) Environment() envs.Environment {
+	// resumes can replace the session environment so make sure we're based on the current one
+	if r.environment.base != r.session.Environment() {
+		r.environment = newRunEnvironment(r.session.Environment(), r)
+	}
+	return r.environment
+}
 
 func (r *flowRun) Flow() flows.Flow                     { return r.flow }
 func (r *flowRun) FlowReference() *assets.FlowReference { return r.flowRef }
@@ -82,6 +92,7 @@ func (r *flowRun) SaveResult(result *flows.Result) {
 
 	r.results.Save(result)
 	r.modifiedOn = dates.Now()
+	r.clearExpressionCache()
 
 	r.legacyExtra.addResult(result)
 }
@@ -93,6 +104,7 @@ func (r *flowRun) Exit(status flows.RunStatus) {
 	r.exitedOn = &now
 	r.expiresOn = nil
 	r.modifiedOn = now
+	r.clearExpressionCache()
 
 	// if we have a parent, it's expiration should no longer include our expiration
 	if r.ParentInSession() != nil {
@@ -103,6 +115,7 @@ func (r *flowRun) Status() flows.RunStatus { return r.status }
 func (r *flowRun) SetStatus(status flows.RunStatus) {
 	r.status = status
 	r.modifiedOn = dates.Now()
+	r.clearExpressionCache()
 }
 
 func (r *flowRun) Webhook() types.XValue {
@@ -110,6 +123,12 @@ func (r *flowRun) Webhook() types.XValue {
 }
 func (r *flowRun) SetWebhook(value types.XValue) {
 	r.webhook = value
+	r.clearExpressionCache()
+}
+
+// clears any cached template evaluations as they may depend on state which has changed
+func (r *flowRun) clearExpressionCache() {
+	r.session.ExpressionCache().Clear()
 }
 
 // ParentInSession returns the parent of the run within the same session if one exists
@@ -150,6 +169,7 @@ func (r *flowRun) LogEvent(s flows.Step, event flows.Event) {
 
 	r.events = append(r.events, event)
 	r.modifiedOn = dates.Now()
+	r.clearExpressionCache()
 }
 
 func (r *flowRun) LogError(step flows.Step, err error) {
@@ -176,6 +196,7 @@ func (r *flowRun) CreateStep(node flows.Node) flows.Step {
 	step := NewStep(node, now)
 	r.path = append(r.path, step)
 	r.modifiedOn = now
+	r.clearExpressionCache()
 	return step
 }
 
@@ -222,6 +243,14 @@ func (r *flowRun) ResetExpiration(from *time.Time) {
 
 func (r *flowRun) ExitedOn() *time.Time { return r.exitedOn }
 
+// Elapsed returns the time between the creation of this run and its exit, or now if it hasn't exited
+func (r *flowRun) Elapsed() time.Duration {
+	if r.exitedOn != nil {
+		return r.exitedOn.Sub(r.createdOn)
+	}
+	return dates.Now().Sub(r.createdOn)
+}
+
 // RootContext returns the root context for expression evaluation
 //
 //   contact:contact -> the contact
@@ -262,7 +291,7 @@ func (r *flowRun) RootContext(env envs.Environment) map[string]types.XValue {
 
 		// shortcuts to things on the current run
 		"contact": flows.Context(env, r.Contact()),
-		"results": flows.Context(env, r.Results()),
+		"results": resultsContext(env, r),
 		"urns":    urns,
 		"fields":  fields,
 
@@ -301,7 +330,7 @@ func (r *flowRun) Context(env envs.Environment) map[string]types.XValue {
 		"contact":     flows.Context(env, r.Contact()),
 		"flow":        flows.Context(env, r.Flow()),
 		"status":      types.NewXText(string(r.Status())),
-		"results":     flows.Context(env, r.Results()),
+		"results":     resultsContext(env, r),
 		"path":        r.path.ToXValue(env),
 		"created_on":  types.NewXDateTime(r.CreatedOn()),
 		"exited_on":   exitedOn,
@@ -336,11 +365,24 @@ func (r *flowRun) EvaluateTemplateValue(template string) (types.XValue, error) {
 	return excellent.EvaluateTemplateValue(r.Environment(), context, template)
 }
 
+// matches calls to functions which don't always return the same value for the same context
+var nonDeterministicCall = regexp.MustCompile(`(?i)\b(rand|rand_between|now|today)\s*\(`)
+
 // EvaluateTemplateText evaluates the given template as text in the context of this run
 func (r *flowRun) EvaluateTemplateText(template string, escaping excellent.Escaping, truncate bool) (string, error) {
-	context := types.NewXObject(r.RootContext(r.Environment()))
+	// escaping functions can't be compared so only unescaped evaluations are cached, and templates which call functions
+	// that return different values each time can't be cached at all
+	cache := r.session.ExpressionCache()
+	if escaping != nil || nonDeterministicCall.MatchString(template) {
+		cache = nil
+	}
+
+	value, err := cache.Lookup(string(r.uuid)+":"+template, func() (string, error) {
+		context := types.NewXObject(r.RootContext(r.Environment()))
+
+		return excellent.EvaluateTemplate(r.Environment(), context, template, escaping)
+	})
 
-	value, err := excellent.EvaluateTemplate(r.Environment(), context, template, escaping)
 	if truncate {
 		value = utils.TruncateEllipsis(value, r.Session().Engine().MaxTemplateChars())
 	}
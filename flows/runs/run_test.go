@@ -150,7 +150,7 @@ func TestRunContext(t *testing.T) {
 	uuids.SetGenerator(uuids.NewSeededGenerator(12345))
 	defer uuids.SetGenerator(uuids.DefaultGenerator)
 
-	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 9, 13, 13, 36, 30, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-09-13T13:36:30.123456789Z")))
 	defer dates.SetNowSource(dates.DefaultNowSource)
 
 	// create a run with no parent or child
@@ -282,7 +282,7 @@ func TestSaveResult(t *testing.T) {
 
 	run := session.Runs()[0]
 
-	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2020, 4, 20, 12, 39, 30, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2020-04-20T12:39:30.123456789Z")))
 	defer dates.SetNowSource(dates.DefaultNowSource)
 
 	// no results means empty object with default of empty string
@@ -293,17 +293,112 @@ func TestSaveResult(t *testing.T) {
 	// name is snaked
 	assert.Equal(t, "red", run.Results().Get("response_1").Value)
 	assert.Equal(t, "Red", run.Results().Get("response_1").Category)
-	assert.Equal(t, time.Date(2020, 4, 20, 12, 39, 30, 123456789, time.UTC), run.ModifiedOn())
+	assert.Equal(t, test.MustParseTime("2020-04-20T12:39:30.123456789Z"), run.ModifiedOn())
 
 	run.SaveResult(flows.NewResult("Response 1", "blue", "Blue", "Azul", "6d35528e-cae3-4e30-b842-8fe6ed7d5c02", "I like blue", nil, dates.Now()))
 
 	// result is overwritten
 	assert.Equal(t, "blue", run.Results().Get("response_1").Value)
 	assert.Equal(t, "Blue", run.Results().Get("response_1").Category)
-	assert.Equal(t, time.Date(2020, 4, 20, 12, 39, 30, 123456789, time.UTC), run.ModifiedOn())
+	assert.Equal(t, test.MustParseTime("2020-04-20T12:39:30.123456789Z"), run.ModifiedOn())
 
 	// long values should truncated
 	run.SaveResult(flows.NewResult("Response 1", strings.Repeat("創", 700), "Blue", "Azul", "6d35528e-cae3-4e30-b842-8fe6ed7d5c02", "I like blue", nil, dates.Now()))
 
 	assert.Equal(t, strings.Repeat("創", 640), run.Results().Get("response_1").Value)
 }
+
+func TestRunElapsed(t *testing.T) {
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2020-04-20T12:39:30Z")))
+	defer dates.SetNowSource(dates.DefaultNowSource)
+
+	session, _, err := test.CreateSession([]byte(`{
+		"flows": [
+			{
+				"uuid": "5472a1c3-63e1-484f-8485-cc8ecb16a058",
+				"name": "Waiting",
+				"spec_version": "13.1",
+				"language": "eng",
+				"type": "messaging",
+				"nodes": [
+					{
+						"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507",
+						"router": {
+							"type": "switch",
+							"wait": {"type": "msg"},
+							"categories": [{"uuid": "9a43d8f5-a4d0-4d8e-9b5c-4ff0fd5be7ca", "name": "All", "exit_uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6"}],
+							"operand": "@input.text",
+							"default_category_uuid": "9a43d8f5-a4d0-4d8e-9b5c-4ff0fd5be7ca"
+						},
+						"exits": [{"uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6"}]
+					}
+				]
+			}
+		]
+	}`), assets.FlowUUID("5472a1c3-63e1-484f-8485-cc8ecb16a058"))
+	require.NoError(t, err)
+
+	run := session.Runs()[0]
+	assert.Equal(t, flows.RunStatusWaiting, run.Status())
+
+	// an active run has been running until now
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2020-04-20T12:40:00Z")))
+	assert.Equal(t, 30*time.Second, run.Elapsed())
+
+	// an active run created in the past has a positive elapsed time
+	dates.SetNowSource(dates.DefaultNowSource)
+	assert.True(t, run.Elapsed() > 0)
+
+	// a completed run ran until it exited
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2020-04-20T12:41:15Z")))
+	run.Exit(flows.RunStatusCompleted)
+
+	dates.SetNowSource(dates.DefaultNowSource)
+	assert.Equal(t, 105*time.Second, run.Elapsed())
+}
+
+func TestRunPath(t *testing.T) {
+	// a flow with three nodes which exit unconditionally to the next
+	session, _, err := test.CreateSession([]byte(`{
+		"flows": [
+			{
+				"uuid": "5472a1c3-63e1-484f-8485-cc8ecb16a058",
+				"name": "Three Nodes",
+				"spec_version": "13.1",
+				"language": "eng",
+				"type": "messaging",
+				"nodes": [
+					{
+						"uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507",
+						"exits": [{"uuid": "37d8813f-1402-4ad2-9cc2-e9054a96525b", "destination_uuid": "32bc60ad-5c86-465e-a6b8-049c44ecce49"}]
+					},
+					{
+						"uuid": "32bc60ad-5c86-465e-a6b8-049c44ecce49",
+						"exits": [{"uuid": "d2a4052a-3fa9-4608-ab3e-5b9631440447", "destination_uuid": "3e0b4d30-6aeb-4b6e-8b3a-fa7a5a3cb5df"}]
+					},
+					{
+						"uuid": "3e0b4d30-6aeb-4b6e-8b3a-fa7a5a3cb5df",
+						"exits": [{"uuid": "118221f7-e637-4cdb-83ca-7f0a5aae98c6"}]
+					}
+				]
+			}
+		]
+	}`), assets.FlowUUID("5472a1c3-63e1-484f-8485-cc8ecb16a058"))
+	require.NoError(t, err)
+
+	run := session.Runs()[0]
+	assert.Equal(t, flows.RunStatusCompleted, run.Status())
+
+	path := run.Path()
+	require.Equal(t, 3, len(path))
+	assert.Equal(t, flows.NodeUUID("a58be63b-907d-4a1a-856b-0bb5579d7507"), path[0].NodeUUID())
+	assert.Equal(t, flows.ExitUUID("37d8813f-1402-4ad2-9cc2-e9054a96525b"), path[0].ExitUUID())
+	assert.Equal(t, flows.NodeUUID("32bc60ad-5c86-465e-a6b8-049c44ecce49"), path[1].NodeUUID())
+	assert.Equal(t, flows.ExitUUID("d2a4052a-3fa9-4608-ab3e-5b9631440447"), path[1].ExitUUID())
+	assert.Equal(t, flows.NodeUUID("3e0b4d30-6aeb-4b6e-8b3a-fa7a5a3cb5df"), path[2].NodeUUID())
+	assert.Equal(t, flows.ExitUUID("118221f7-e637-4cdb-83ca-7f0a5aae98c6"), path[2].ExitUUID())
+
+	// steps are in the order they were arrived at
+	assert.False(t, path[1].ArrivedOn().Before(path[0].ArrivedOn()))
+	assert.False(t, path[2].ArrivedOn().Before(path[1].ArrivedOn()))
+}
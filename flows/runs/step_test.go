@@ -2,7 +2,6 @@ package runs_test
 
 import (
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/jsonx"
 	"github.com/nyaruka/gocommon/uuids"
@@ -23,7 +22,7 @@ func TestStep(t *testing.T) {
 
 	node := definition.NewNode(flows.NodeUUID("5fb4f555-7662-4c4c-8387-226e359526e4"), nil, nil, nil)
 
-	d := time.Date(2018, 10, 26, 14, 50, 30, 1234567890, time.UTC)
+	d := test.MustParseTime("2018-10-26T14:50:31.23456789Z")
 	step := runs.NewStep(node, d)
 
 	assert.Equal(t, flows.StepUUID("c00e5d67-c275-4389-aded-7d8b151cbd5b"), step.UUID())
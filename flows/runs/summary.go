@@ -81,7 +81,7 @@ func (c *relatedRunContext) Context(env envs.Environment) map[string]types.XValu
 		"flow":        flows.Context(env, c.run.Flow()),
 		"urns":        urns,
 		"fields":      fields,
-		"results":     flows.Context(env, c.run.Results()),
+		"results":     resultsContext(env, c.run),
 		"status":      types.NewXText(string(c.run.Status())),
 	}
 }
@@ -95,10 +95,20 @@ func (c *relatedRunContext) RunContext(env envs.Environment) map[string]types.XV
 		"contact":     flows.Context(env, c.run.Contact()),
 		"flow":        flows.Context(env, c.run.Flow()),
 		"status":      types.NewXText(string(c.run.Status())),
-		"results":     flows.Context(env, c.run.Results()),
+		"results":     resultsContext(env, c.run),
 	}
 }
 
+// returns the results of the given run for use in expressions, which under full redaction can't include the URNs of
+// the run's contact, e.g. in input
+func resultsContext(env envs.Environment, run flows.RunSummary) types.XValue {
+	results := run.Results()
+	if env.RedactionPolicy().RedactsContactDetails() && run.Contact() != nil {
+		results = results.Redacted(run.Contact().URNs().RedactIn)
+	}
+	return flows.Context(env, results)
+}
+
 // FormatRunSummary formats an instance of the RunSummary interface
 func FormatRunSummary(env envs.Environment, run flows.RunSummary) string {
 	var flow, contact string
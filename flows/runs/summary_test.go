@@ -2,7 +2,6 @@ package runs_test
 
 import (
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/jsonx"
@@ -20,7 +19,7 @@ import (
 
 func TestRunSummary(t *testing.T) {
 	uuids.SetGenerator(uuids.NewSeededGenerator(123456))
-	dates.SetNowSource(dates.NewSequentialNowSource(time.Date(2018, 7, 6, 12, 30, 0, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewSequentialNowSource(test.MustParseTime("2018-07-06T12:30:00.123456789Z")))
 	defer uuids.SetGenerator(uuids.DefaultGenerator)
 	defer dates.SetNowSource(dates.DefaultNowSource)
 
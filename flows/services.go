@@ -1,10 +1,15 @@
 package flows
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/nyaruka/gocommon/httpx"
+	"github.com/nyaruka/gocommon/jsonx"
 	"github.com/nyaruka/gocommon/urns"
 	"github.com/nyaruka/gocommon/uuids"
 	"github.com/nyaruka/goflow/utils"
@@ -12,8 +17,19 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// service types which are resolved by Services.Service
+const (
+	ServiceTypeEmail   = "email"
+	ServiceTypeWebhook = "webhook"
+	ServiceTypeAirtime = "airtime"
+)
+
 // Services groups together interfaces for several services whose implementation is provided outside of the flow engine.
+// Services are resolved by type using Service, and the other methods are typed convenience wrappers. Classification
+// and ticket services are resolved per classifier and ticketer.
 type Services interface {
+	Service(Session, string) (interface{}, error)
+
 	Email(Session) (EmailService, error)
 	Webhook(Session) (WebhookService, error)
 	Classification(Session, *Classifier) (ClassificationService, error)
@@ -72,6 +88,26 @@ type Classification struct {
 	Entities map[string][]ExtractedEntity `json:"entities,omitempty"`
 }
 
+// TopIntent returns the intent with the highest confidence, or the earliest of those with equal highest confidence
+func (c *Classification) TopIntent() (*ExtractedIntent, bool) {
+	var top *ExtractedIntent
+	for i := range c.Intents {
+		if top == nil || c.Intents[i].Confidence.GreaterThan(top.Confidence) {
+			top = &c.Intents[i]
+		}
+	}
+	return top, top != nil
+}
+
+// IntentAboveThreshold returns the top intent if its confidence is greater than or equal to the given threshold
+func (c *Classification) IntentAboveThreshold(threshold decimal.Decimal) (*ExtractedIntent, bool) {
+	top, found := c.TopIntent()
+	if !found || top.Confidence.LessThan(threshold) {
+		return nil, false
+	}
+	return top, true
+}
+
 // ClassificationService provides NLU functionality to the engine
 type ClassificationService interface {
 	Classify(session Session, input string, logHTTP HTTPLogCallback) (*Classification, error)
@@ -108,29 +144,112 @@ type AirtimeService interface {
 	Transfer(session Session, sender urns.URN, recipient urns.URN, amounts map[string]decimal.Decimal, logHTTP HTTPLogCallback) (*AirtimeTransfer, error)
 }
 
-// HTTPLog describes an HTTP request/response
+// HTTPLog describes an HTTP request/response. Services can optionally record the request and response bodies
+// separately from the traces. Logs which record bodies always include both in their JSON, with empty bodies as null,
+// and logs which don't record bodies omit them.
 type HTTPLog struct {
-	URL       string     `json:"url" validate:"required"`
-	Status    CallStatus `json:"status" validate:"required"`
-	Request   string     `json:"request" validate:"required"`
-	Response  string     `json:"response,omitempty"`
-	CreatedOn time.Time  `json:"created_on" validate:"required"`
-	ElapsedMS int        `json:"elapsed_ms"`
+	URL          string     `json:"url" validate:"required"`
+	Status       CallStatus `json:"status" validate:"required"`
+	Request      string     `json:"request" validate:"required"`
+	Response     string     `json:"response,omitempty"`
+	RequestBody  *string    `json:"request_body,omitempty"`
+	ResponseBody *string    `json:"response_body,omitempty"`
+	CreatedOn    time.Time  `json:"created_on" validate:"required"`
+	ElapsedMS    int        `json:"elapsed_ms"`
+
+	bodiesRecorded bool
+}
+
+// RecordsBodies returns whether this log records request and response bodies
+func (h *HTTPLog) RecordsBodies() bool {
+	return h.bodiesRecorded || h.RequestBody != nil || h.ResponseBody != nil
+}
+
+// type alias used to marshal and unmarshal HTTP logs without recursing
+type httpLog HTTPLog
+
+// MarshalJSON marshals this HTTP log into JSON
+func (h HTTPLog) MarshalJSON() ([]byte, error) {
+	if !h.RecordsBodies() {
+		return jsonx.Marshal(httpLog(h))
+	}
+
+	return jsonx.Marshal(&struct {
+		httpLog
+		RequestBody  *string `json:"request_body"`
+		ResponseBody *string `json:"response_body"`
+	}{httpLog(h), h.RequestBody, h.ResponseBody})
+}
+
+// UnmarshalJSON unmarshals this HTTP log from JSON
+func (h *HTTPLog) UnmarshalJSON(data []byte) error {
+	if err := jsonx.Unmarshal(data, (*httpLog)(h)); err != nil {
+		return err
+	}
+
+	// if there are body fields, even null ones, then this log records bodies
+	fields := make(map[string]json.RawMessage)
+	if err := jsonx.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	_, h.bodiesRecorded = fields["request_body"]
+	return nil
 }
 
 // HTTPLogCallback is a function that handles an HTTP log
 type HTTPLogCallback func(*HTTPLog)
 
-// HTTPLogger logs HTTP logs
+// HTTPLogger logs HTTP logs, truncating request and response bodies to MaxBodyBytes if that is set
 type HTTPLogger struct {
-	Logs []*HTTPLog
+	Logs         []*HTTPLog
+	MaxBodyBytes int
+}
+
+// NewHTTPLogger creates a new HTTP logger which truncates request and response bodies to the given number of bytes,
+// or zero for no truncation
+func NewHTTPLogger(maxBodyBytes int) *HTTPLogger {
+	return &HTTPLogger{MaxBodyBytes: maxBodyBytes}
 }
 
 // Log logs the given HTTP log
 func (l *HTTPLogger) Log(h *HTTPLog) {
+	if l.MaxBodyBytes > 0 {
+		h.Request = truncateTraceBody(h.Request, l.MaxBodyBytes)
+		h.Response = truncateTraceBody(h.Response, l.MaxBodyBytes)
+		h.RequestBody = truncateBody(h.RequestBody, l.MaxBodyBytes)
+		h.ResponseBody = truncateBody(h.ResponseBody, l.MaxBodyBytes)
+	}
+
 	l.Logs = append(l.Logs, h)
 }
 
+// TruncatedBodyMarker is appended to bodies which have been truncated
+const TruncatedBodyMarker = "...[truncated]"
+
+// truncates the given body to at most max bytes without splitting a UTF-8 sequence, and appends a marker
+func truncateBody(body *string, max int) *string {
+	if body == nil || len(*body) <= max {
+		return body
+	}
+
+	cut := max
+	for cut > 0 && !utf8.RuneStart((*body)[cut]) {
+		cut--
+	}
+
+	truncated := (*body)[:cut] + TruncatedBodyMarker
+	return &truncated
+}
+
+// truncates the body of the given HTTP trace, leaving its headers as they are
+func truncateTraceBody(trace string, max int) string {
+	headers, body := splitTrace(trace)
+	if body == nil {
+		return trace
+	}
+	return headers + *truncateBody(body, max)
+}
+
 // HTTPStatusResolver is a function that determines the status of an HTTP log from the response
 type HTTPStatusResolver func(t *httpx.Trace) CallStatus
 
@@ -152,6 +271,49 @@ func NewHTTPLog(trace *httpx.Trace, statusFn HTTPStatusResolver, redact utils.Re
 	return newHTTPLogWithStatus(trace, statusFn(trace), redact)
 }
 
+// NewHTTPLogWithBodies creates a new HTTP log from a trace which also records the request and response bodies
+func NewHTTPLogWithBodies(trace *httpx.Trace, statusFn HTTPStatusResolver, redact utils.Redactor) *HTTPLog {
+	log := NewHTTPLog(trace, statusFn, redact)
+
+	// request trace is the request headers and body separated by a blank line
+	var requestBody []byte
+	if parts := bytes.SplitN(trace.RequestTrace, []byte("\r\n\r\n"), 2); len(parts) == 2 {
+		requestBody = parts[1]
+	}
+
+	log.bodiesRecorded = true
+	log.RequestBody = bodyToLog(requestBody, redact)
+	log.ResponseBody = bodyToLog(trace.ResponseBody, redact)
+	return log
+}
+
+// converts a body to a value for an HTTP log, which is nil if the body is empty
+func bodyToLog(body []byte, redact utils.Redactor) *string {
+	if len(body) == 0 {
+		return nil
+	}
+
+	s := strings.ToValidUTF8(string(body), "\uFFFD")
+	if redact != nil {
+		s = redact(s)
+	}
+	return &s
+}
+
+// splits an HTTP trace into its headers, including the blank line which ends them, and its body if it has one
+func splitTrace(trace string) (string, *string) {
+	parts := strings.SplitN(trace, "\r\n\r\n", 2)
+	if len(parts) < 2 {
+		return trace, nil
+	}
+
+	headers, body := parts[0]+"\r\n\r\n", parts[1]
+	if body == "" {
+		return headers, nil
+	}
+	return headers, &body
+}
+
 // creates a new HTTP log from a trace with an explicit status
 func newHTTPLogWithStatus(trace *httpx.Trace, status CallStatus, redact utils.Redactor) *HTTPLog {
 	url := trace.Request.URL.String()
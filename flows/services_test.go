@@ -6,9 +6,11 @@ import (
 	"testing"
 
 	"github.com/nyaruka/gocommon/httpx"
+	"github.com/nyaruka/gocommon/jsonx"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/utils"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -81,3 +83,161 @@ func TestHTTPLogsRedaction(t *testing.T) {
 	assert.Equal(t, "GET /code/****************/ HTTP/1.1\r\nHost: temba.io\r\nUser-Agent: Go-http-client/1.1\r\nContent-Length: 20\r\nAccept-Encoding: gzip\r\n\r\nMy code is ****************", log2.Request)
 	assert.Equal(t, "HTTP/1.0 400 Bad Request\r\nContent-Length: 39\r\n\r\nThe code is ****************, I said ****************", log2.Response)
 }
+
+func TestHTTPLogsWithBodies(t *testing.T) {
+	defer httpx.SetRequestor(httpx.DefaultRequestor)
+
+	httpx.SetRequestor(httpx.NewMockRequestor(map[string][]httpx.MockResponse{
+		"http://temba.io/code/987654321/": {
+			httpx.NewMockResponse(200, nil, `{"value": "987654321", "text": "Привет"}`),
+			httpx.NewMockResponse(204, nil, ``),
+		},
+	}))
+
+	redactor := utils.NewRedactor(flows.RedactionMask, "987654321")
+
+	req1, err := httpx.NewRequest("POST", "http://temba.io/code/987654321/", strings.NewReader(`{"q": "book flight"}`), nil)
+	require.NoError(t, err)
+	trace1, err := httpx.DoTrace(http.DefaultClient, req1, nil, nil, -1)
+	require.NoError(t, err)
+
+	log1 := flows.NewHTTPLogWithBodies(trace1, flows.HTTPStatusFromCode, redactor)
+	assert.Equal(t, `{"q": "book flight"}`, *log1.RequestBody)
+	assert.Equal(t, `{"value": "****************", "text": "Привет"}`, *log1.ResponseBody)
+
+	// traces still include the bodies
+	assert.Equal(t, "POST /code/****************/ HTTP/1.1\r\nHost: temba.io\r\nUser-Agent: Go-http-client/1.1\r\nContent-Length: 20\r\nAccept-Encoding: gzip\r\n\r\n{\"q\": \"book flight\"}", log1.Request)
+	assert.Equal(t, "HTTP/1.0 200 OK\r\nContent-Length: 46\r\n\r\n{\"value\": \"****************\", \"text\": \"Привет\"}", log1.Response)
+
+	// empty bodies are recorded as nil and serialized as null
+	req2, err := httpx.NewRequest("GET", "http://temba.io/code/987654321/", nil, nil)
+	require.NoError(t, err)
+	trace2, err := httpx.DoTrace(http.DefaultClient, req2, nil, nil, -1)
+	require.NoError(t, err)
+
+	log2 := flows.NewHTTPLogWithBodies(trace2, flows.HTTPStatusFromCode, redactor)
+	assert.True(t, log2.RecordsBodies())
+	assert.Nil(t, log2.RequestBody)
+	assert.Nil(t, log2.ResponseBody)
+
+	marshaled, err := jsonx.Marshal(log2)
+	require.NoError(t, err)
+	assert.Contains(t, string(marshaled), `"request_body":null`)
+	assert.Contains(t, string(marshaled), `"response_body":null`)
+
+	// and still are after a round trip
+	log2 = &flows.HTTPLog{}
+	require.NoError(t, jsonx.Unmarshal(marshaled, log2))
+	assert.True(t, log2.RecordsBodies())
+
+	remarshaled, err := jsonx.Marshal(log2)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(marshaled), string(remarshaled))
+
+	// logs from services which don't record bodies don't include them at all
+	log3 := flows.NewHTTPLog(trace2, flows.HTTPStatusFromCode, redactor)
+	assert.False(t, log3.RecordsBodies())
+
+	marshaled, err = jsonx.Marshal(log3)
+	require.NoError(t, err)
+	assert.NotContains(t, string(marshaled), "request_body")
+	assert.NotContains(t, string(marshaled), "response_body")
+
+	log3 = &flows.HTTPLog{}
+	require.NoError(t, jsonx.Unmarshal(marshaled, log3))
+	assert.False(t, log3.RecordsBodies())
+
+	// loggers can truncate bodies without splitting characters
+	logger := flows.NewHTTPLogger(42)
+	logger.Log(log1)
+	logger.Log(log2)
+
+	assert.Equal(t, `{"q": "book flight"}`, *logger.Logs[0].RequestBody)
+	assert.Equal(t, `{"value": "****************", "text": "П...[truncated]`, *logger.Logs[0].ResponseBody)
+	assert.Nil(t, logger.Logs[1].RequestBody)
+
+	// truncation only happens for bodies over the limit
+	for _, tc := range []struct {
+		body     string
+		max      int
+		expected string
+	}{
+		{"0123456789", 11, "0123456789"},
+		{"0123456789", 10, "0123456789"},
+		{"0123456789", 9, "012345678...[truncated]"},
+		{"0123456789", 1, "0...[truncated]"},
+	} {
+		body := tc.body
+		logger = flows.NewHTTPLogger(tc.max)
+		logger.Log(&flows.HTTPLog{RequestBody: &body, ResponseBody: &body})
+
+		assert.Equal(t, tc.expected, *logger.Logs[0].RequestBody, "request body mismatch for max %d", tc.max)
+		assert.Equal(t, tc.expected, *logger.Logs[0].ResponseBody, "response body mismatch for max %d", tc.max)
+	}
+
+	// bodies which are still in the traces are also truncated
+	logger = flows.NewHTTPLogger(10)
+	logger.Log(flows.NewHTTPLog(trace1, flows.HTTPStatusFromCode, redactor))
+
+	assert.Equal(t, "POST /code/****************/ HTTP/1.1\r\nHost: temba.io\r\nUser-Agent: Go-http-client/1.1\r\nContent-Length: 20\r\nAccept-Encoding: gzip\r\n\r\n{\"q\": \"boo...[truncated]", logger.Logs[0].Request)
+	assert.Equal(t, "HTTP/1.0 200 OK\r\nContent-Length: 46\r\n\r\n{\"value\": ...[truncated]", logger.Logs[0].Response)
+
+	// or leave them as is
+	log1 = flows.NewHTTPLogWithBodies(trace1, flows.HTTPStatusFromCode, redactor)
+	logger = flows.NewHTTPLogger(0)
+	logger.Log(log1)
+
+	assert.Equal(t, `{"value": "****************", "text": "Привет"}`, *logger.Logs[0].ResponseBody)
+}
+
+func TestClassificationTopIntent(t *testing.T) {
+	d := decimal.RequireFromString
+
+	// no intents
+	c := &flows.Classification{}
+	top, found := c.TopIntent()
+	assert.False(t, found)
+	assert.Nil(t, top)
+	top, found = c.IntentAboveThreshold(d("0"))
+	assert.False(t, found)
+	assert.Nil(t, top)
+
+	// single intent
+	c = &flows.Classification{Intents: []flows.ExtractedIntent{{Name: "book_flight", Confidence: d("0.5")}}}
+	top, found = c.TopIntent()
+	assert.True(t, found)
+	assert.Equal(t, "book_flight", top.Name)
+
+	// multiple intents, not in confidence order
+	c = &flows.Classification{Intents: []flows.ExtractedIntent{
+		{Name: "book_hotel", Confidence: d("0.3")},
+		{Name: "book_flight", Confidence: d("0.7")},
+		{Name: "book_car", Confidence: d("0.1")},
+	}}
+	top, found = c.TopIntent()
+	assert.True(t, found)
+	assert.Equal(t, "book_flight", top.Name)
+	assert.Equal(t, "book_hotel", c.Intents[0].Name) // intents aren't re-ordered
+
+	// multiple intents with equal confidence, earliest wins
+	c = &flows.Classification{Intents: []flows.ExtractedIntent{
+		{Name: "book_hotel", Confidence: d("0.5")},
+		{Name: "book_flight", Confidence: d("0.5")},
+	}}
+	top, found = c.TopIntent()
+	assert.True(t, found)
+	assert.Equal(t, "book_hotel", top.Name)
+
+	// threshold boundaries
+	top, found = c.IntentAboveThreshold(d("0.49"))
+	assert.True(t, found)
+	assert.Equal(t, "book_hotel", top.Name)
+
+	top, found = c.IntentAboveThreshold(d("0.5"))
+	assert.True(t, found)
+	assert.Equal(t, "book_hotel", top.Name)
+
+	top, found = c.IntentAboveThreshold(d("0.51"))
+	assert.False(t, found)
+	assert.Nil(t, top)
+}
@@ -0,0 +1,25 @@
+package flows
+
+// SliceEventSink is an event sink which appends events to a slice
+type SliceEventSink []Event
+
+// Add appends the given event
+func (s *SliceEventSink) Add(e Event) { *s = append(*s, e) }
+
+// FilteringEventSink returns an event sink which only passes events matching the given predicate to the inner sink
+func FilteringEventSink(predicate func(Event) bool, inner EventSink) EventSink {
+	return EventCallback(func(e Event) {
+		if predicate(e) {
+			inner.Add(e)
+		}
+	})
+}
+
+// FanOutEventSink returns an event sink which passes all events to each of the given sinks
+func FanOutEventSink(sinks ...EventSink) EventSink {
+	return EventCallback(func(e Event) {
+		for _, s := range sinks {
+			s.Add(e)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package flows_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/events"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventSinks(t *testing.T) {
+	event1 := events.NewErrorf("boom")
+	event2 := events.NewContactNameChanged("Bob")
+	event3 := events.NewErrorf("bang")
+
+	// slice sink just appends events
+	all := &flows.SliceEventSink{}
+	all.Add(event1)
+	all.Add(event2)
+	assert.Equal(t, flows.SliceEventSink{event1, event2}, *all)
+
+	// filtering sink only passes on matching events
+	errors := &flows.SliceEventSink{}
+	filtering := flows.FilteringEventSink(func(e flows.Event) bool { return e.Type() == events.TypeError }, errors)
+	filtering.Add(event1)
+	filtering.Add(event2)
+	filtering.Add(event3)
+	assert.Equal(t, flows.SliceEventSink{event1, event3}, *errors)
+
+	// fan out sink passes events to all its sinks
+	sink1 := &flows.SliceEventSink{}
+	sink2 := &flows.SliceEventSink{}
+	var called []flows.Event
+	fanOut := flows.FanOutEventSink(sink1, sink2, flows.EventCallback(func(e flows.Event) { called = append(called, e) }))
+	fanOut.Add(event1)
+	fanOut.Add(event2)
+	assert.Equal(t, flows.SliceEventSink{event1, event2}, *sink1)
+	assert.Equal(t, flows.SliceEventSink{event1, event2}, *sink2)
+	assert.Equal(t, []flows.Event{event1, event2}, called)
+
+	// and they can be composed
+	sink3 := &flows.SliceEventSink{}
+	composed := flows.FanOutEventSink(sink3, flows.FilteringEventSink(func(e flows.Event) bool { return false }, sink3))
+	composed.Add(event3)
+	assert.Equal(t, flows.SliceEventSink{event3}, *sink3)
+}
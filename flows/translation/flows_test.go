@@ -6,7 +6,6 @@ import (
 	"io/ioutil"
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/jsonx"
@@ -24,7 +23,7 @@ import (
 
 func TestExtractFromFlows(t *testing.T) {
 	defer dates.SetNowSource(dates.DefaultNowSource)
-	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2020, 3, 25, 13, 57, 30, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2020-03-25T13:57:30.123456789Z")))
 
 	tests := []struct {
 		assets       string
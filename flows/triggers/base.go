@@ -70,7 +70,7 @@ func (t *baseTrigger) History() *flows.SessionHistory { return t.history }
 func (t *baseTrigger) TriggeredOn() time.Time         { return t.triggeredOn }
 
 // Initialize initializes the session
-func (t *baseTrigger) Initialize(session flows.Session, logEvent flows.EventCallback) error {
+func (t *baseTrigger) Initialize(session flows.Session, logEvent flows.EventSink) error {
 	// try to load the flow
 	flow, err := session.Assets().Flows().Get(t.Flow().UUID)
 	if err != nil {
@@ -97,7 +97,7 @@ func (t *baseTrigger) Initialize(session flows.Session, logEvent flows.EventCall
 }
 
 // InitializeRun performs additional initialization when we create our first run
-func (t *baseTrigger) InitializeRun(run flows.FlowRun, logEvent flows.EventCallback) error {
+func (t *baseTrigger) InitializeRun(run flows.FlowRun, logEvent flows.EventSink) error {
 	return nil
 }
 
@@ -6,7 +6,6 @@ import (
 	"io/ioutil"
 	"sort"
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/jsonx"
@@ -62,7 +61,7 @@ func testTriggerType(t *testing.T, assetsJSON json.RawMessage, typeName string)
 	defer uuids.SetGenerator(uuids.DefaultGenerator)
 
 	for i, tc := range tests {
-		dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 10, 18, 14, 20, 30, 123456, time.UTC)))
+		dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-10-18T14:20:30.000123456Z")))
 		uuids.SetGenerator(uuids.NewSeededGenerator(12345))
 
 		testName := fmt.Sprintf("test '%s' for trigger type '%s'", tc.Description, typeName)
@@ -155,7 +154,7 @@ var assetsJSON = `{
 
 func TestTriggerMarshaling(t *testing.T) {
 	defer dates.SetNowSource(dates.DefaultNowSource)
-	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 10, 20, 9, 49, 30, 1234567890, time.UTC)))
+	dates.SetNowSource(dates.NewFixedNowSource(test.MustParseTime("2018-10-20T09:49:31.23456789Z")))
 
 	uuids.SetGenerator(uuids.NewSeededGenerator(1234))
 	defer uuids.SetGenerator(uuids.DefaultGenerator)
@@ -258,6 +257,13 @@ func TestTriggerMarshaling(t *testing.T) {
 				Build(),
 			"msg",
 		},
+		{
+			triggers.NewBuilder(env, flow, contact).
+				Scheduled(test.MustParseTime("2018-10-20T09:00:00Z")).
+				WithCampaign(triggers.NewCampaignReference("8cd472c4-bb85-459a-8c9a-c04708af799e", "Reminders")).
+				Build(),
+			"scheduled",
+		},
 	}
 
 	for _, tc := range triggerTests {
@@ -372,3 +378,52 @@ func TestTriggerContext(t *testing.T) {
 		"origin":  types.NewXText("api"),
 	}, trigger.Context(env))
 }
+
+func TestScheduledTrigger(t *testing.T) {
+	env := envs.NewBuilder().Build()
+
+	source, err := static.NewSource([]byte(assetsJSON))
+	require.NoError(t, err)
+
+	sa, err := engine.NewSessionAssets(env, source, nil)
+	require.NoError(t, err)
+
+	flow := assets.NewFlowReference(assets.FlowUUID("7c37d7e5-6468-4b31-8109-ced2ef8b5ddc"), "Registration")
+	campaign := triggers.NewCampaignReference("8cd472c4-bb85-459a-8c9a-c04708af799e", "Reminders")
+	scheduledOn := test.MustParseTime("2018-10-20T09:00:00Z")
+
+	trigger := triggers.NewBuilder(env, flow, flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil)).
+		Scheduled(scheduledOn).
+		WithCampaign(campaign).
+		Build()
+
+	assert.Equal(t, triggers.TypeScheduled, trigger.Type())
+	assert.Equal(t, scheduledOn, trigger.ScheduledOn())
+	assert.Equal(t, campaign, trigger.Campaign())
+
+	eng := engine.NewBuilder().Build()
+	session, _, err := eng.NewSession(sa, trigger)
+	require.NoError(t, err)
+
+	assert.Equal(t, trigger, session.Trigger())
+
+	triggerType, err := session.Runs()[0].EvaluateTemplate(`@trigger.type`)
+	assert.NoError(t, err)
+	assert.Equal(t, "scheduled", triggerType)
+
+	// scheduled time and campaign are available in expressions
+	scheduled, err := session.Runs()[0].EvaluateTemplate(`@trigger.scheduled_on from @trigger.campaign.name (@trigger.campaign.uuid)`)
+	assert.NoError(t, err)
+	assert.Equal(t, "2018-10-20T09:00:00.000000Z from Reminders (8cd472c4-bb85-459a-8c9a-c04708af799e)", scheduled)
+
+	// campaign is optional
+	trigger = triggers.NewBuilder(env, flow, flows.NewEmptyContact(sa, "Bob", envs.Language("eng"), nil)).Scheduled(scheduledOn).Build()
+	assert.Nil(t, trigger.Campaign())
+
+	session, _, err = eng.NewSession(sa, trigger)
+	require.NoError(t, err)
+
+	campaignName, err := session.Runs()[0].EvaluateTemplate(`@(default(trigger.campaign.name, "none"))`)
+	assert.NoError(t, err)
+	assert.Equal(t, "none", campaignName)
+}
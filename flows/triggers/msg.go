@@ -72,12 +72,12 @@ func NewKeywordMatch(typeName KeywordMatchType, keyword string) *KeywordMatch {
 }
 
 // InitializeRun performs additional initialization when we visit our first node
-func (t *MsgTrigger) InitializeRun(run flows.FlowRun, logEvent flows.EventCallback) error {
+func (t *MsgTrigger) InitializeRun(run flows.FlowRun, logEvent flows.EventSink) error {
 	// update our input
 	input := inputs.NewMsg(run.Session().Assets(), t.msg, t.triggeredOn)
 
 	run.Session().SetInput(input)
-	logEvent(events.NewMsgReceived(t.msg))
+	logEvent.Add(events.NewMsgReceived(t.msg))
 
 	return t.baseTrigger.InitializeRun(run, logEvent)
 }
@@ -0,0 +1,139 @@
+package triggers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/types"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/utils"
+)
+
+func init() {
+	registerType(TypeScheduled, readScheduledTrigger)
+}
+
+// TypeScheduled is the type for sessions triggered by a schedule
+const TypeScheduled string = "scheduled"
+
+// ScheduledTrigger is used when a session was triggered by a schedule, optionally one belonging to a campaign
+//
+//   {
+//     "type": "scheduled",
+//     "flow": {"uuid": "50c3706e-fedb-42c0-8eab-dda3335714b7", "name": "Registration"},
+//     "contact": {
+//       "uuid": "9f7ede93-4b16-4692-80ad-b7dc54a1cd81",
+//       "name": "Bob",
+//       "created_on": "2018-01-01T12:00:00.000000Z"
+//     },
+//     "scheduled_on": "2000-01-01T00:00:00.000000000-00:00",
+//     "campaign": {"uuid": "58e9b092-fe42-4173-876c-ff45a14a24fe", "name": "New Mothers"},
+//     "triggered_on": "2000-01-01T00:00:00.000000000-00:00"
+//   }
+//
+// @trigger scheduled
+type ScheduledTrigger struct {
+	baseTrigger
+
+	scheduledOn time.Time
+	campaign    *CampaignReference
+}
+
+// ScheduledOn returns the time the session was scheduled to start
+func (t *ScheduledTrigger) ScheduledOn() time.Time { return t.scheduledOn }
+
+// Campaign returns the campaign the schedule belongs to, if any
+func (t *ScheduledTrigger) Campaign() *CampaignReference { return t.campaign }
+
+// Context for scheduled triggers also includes the scheduled time and the campaign if there is one
+func (t *ScheduledTrigger) Context(env envs.Environment) map[string]types.XValue {
+	var campaign types.XValue
+	if t.campaign != nil {
+		campaign = types.NewXObject(map[string]types.XValue{
+			"uuid": types.NewXText(string(t.campaign.UUID)),
+			"name": types.NewXText(t.campaign.Name),
+		})
+	}
+
+	c := t.context().asMap()
+	c["scheduled_on"] = types.NewXDateTime(t.scheduledOn)
+	c["campaign"] = campaign
+	return c
+}
+
+var _ flows.Trigger = (*ScheduledTrigger)(nil)
+
+//------------------------------------------------------------------------------------------
+// Builder
+//------------------------------------------------------------------------------------------
+
+// ScheduledBuilder is a builder for scheduled type triggers
+type ScheduledBuilder struct {
+	t *ScheduledTrigger
+}
+
+// Scheduled returns a scheduled trigger builder
+func (b *Builder) Scheduled(scheduledOn time.Time) *ScheduledBuilder {
+	return &ScheduledBuilder{
+		t: &ScheduledTrigger{
+			baseTrigger: newBaseTrigger(TypeScheduled, b.environment, b.flow, b.contact, nil, false, nil),
+			scheduledOn: scheduledOn,
+		},
+	}
+}
+
+// WithCampaign sets the campaign the schedule belongs to
+func (b *ScheduledBuilder) WithCampaign(campaign *CampaignReference) *ScheduledBuilder {
+	b.t.campaign = campaign
+	return b
+}
+
+// Build builds the trigger
+func (b *ScheduledBuilder) Build() *ScheduledTrigger {
+	return b.t
+}
+
+//------------------------------------------------------------------------------------------
+// JSON Encoding / Decoding
+//------------------------------------------------------------------------------------------
+
+type scheduledTriggerEnvelope struct {
+	baseTriggerEnvelope
+	ScheduledOn time.Time          `json:"scheduled_on" validate:"required"`
+	Campaign    *CampaignReference `json:"campaign,omitempty" validate:"omitempty,dive"`
+}
+
+func readScheduledTrigger(sessionAssets flows.SessionAssets, data json.RawMessage, missing assets.MissingCallback) (flows.Trigger, error) {
+	e := &scheduledTriggerEnvelope{}
+	if err := utils.UnmarshalAndValidate(data, e); err != nil {
+		return nil, err
+	}
+
+	t := &ScheduledTrigger{
+		scheduledOn: e.ScheduledOn,
+		campaign:    e.Campaign,
+	}
+
+	if err := t.unmarshal(sessionAssets, &e.baseTriggerEnvelope, missing); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// MarshalJSON marshals this trigger into JSON
+func (t *ScheduledTrigger) MarshalJSON() ([]byte, error) {
+	e := &scheduledTriggerEnvelope{
+		ScheduledOn: t.scheduledOn,
+		Campaign:    t.campaign,
+	}
+
+	if err := t.marshal(&e.baseTriggerEnvelope); err != nil {
+		return nil, err
+	}
+
+	return jsonx.Marshal(e)
+}
@@ -3,6 +3,7 @@ package flows
 import (
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/nyaruka/gocommon/urns"
 	"github.com/nyaruka/goflow/assets"
@@ -10,6 +11,7 @@ import (
 	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/utils"
 
+	"github.com/pkg/errors"
 	validator "gopkg.in/go-playground/validator.v9"
 )
 
@@ -96,6 +98,23 @@ func (u *ContactURN) SetChannel(channel *Channel) {
 	u.urn = urn
 }
 
+// Normalized returns a new contact URN with the same channel but with its URN normalized according to its scheme,
+// e.g. tel URNs are converted to E.164 using the given country if they are in a local format
+func (u *ContactURN) Normalized(country envs.Country) (*ContactURN, error) {
+	normalized := u.urn
+
+	// numbers already in international format are left as is, as normalizing will strip the + from numbers which
+	// aren't valid for their country code
+	if u.urn.Scheme() != urns.TelScheme || !strings.HasPrefix(u.urn.Path(), "+") {
+		normalized = u.urn.Normalize(string(country))
+	}
+
+	if err := normalized.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "unable to normalize URN '%s'", u.urn)
+	}
+	return NewContactURN(normalized, u.channel), nil
+}
+
 func (u *ContactURN) String() string {
 	return string(u.urn)
 }
@@ -105,6 +124,20 @@ func (u *ContactURN) Equal(other *ContactURN) bool {
 	return other != nil && u.String() == other.String()
 }
 
+// RedactIn replaces any occurrences of the path or formatted version of this URN in the given text
+func (u *ContactURN) RedactIn(text string) string {
+	path := u.urn.Path()
+	if path == "" {
+		return text
+	}
+	text = strings.ReplaceAll(text, path, redacted)
+
+	if formatted := u.urn.Format(); formatted != path {
+		text = strings.ReplaceAll(text, formatted, redacted)
+	}
+	return text
+}
+
 // returns this URN as a raw URN without the query portion (i.e. only scheme, path, display)
 func (u *ContactURN) withoutQuery(redact bool) urns.URN {
 	scheme, path, _, display := u.urn.ToParts()
@@ -120,7 +153,7 @@ func (u *ContactURN) withoutQuery(redact bool) urns.URN {
 
 // ToXValue returns a representation of this object for use in expressions
 func (u *ContactURN) ToXValue(env envs.Environment) types.XValue {
-	redact := env.RedactionPolicy() == envs.RedactionPolicyURNs
+	redact := env.RedactionPolicy().RedactsURNs()
 
 	return types.NewXText(string(u.withoutQuery(redact)))
 }
@@ -186,6 +219,31 @@ func (l URNList) WithScheme(scheme string) URNList {
 	return matching
 }
 
+// RedactIn replaces any occurrences of the URNs in this list in the given text
+func (l URNList) RedactIn(text string) string {
+	for _, u := range l {
+		text = u.RedactIn(text)
+	}
+	return text
+}
+
+// PreferredChannelURN returns the first URN of the given scheme which has a preferred channel, or if there isn't one,
+// the first URN of the given scheme. Returns nil if this list has no URNs of the given scheme.
+func (l URNList) PreferredChannelURN(scheme string) *ContactURN {
+	var first *ContactURN
+	for _, u := range l {
+		if u.urn.Scheme() == scheme {
+			if u.channel != nil {
+				return u
+			}
+			if first == nil {
+				first = u
+			}
+		}
+	}
+	return first
+}
+
 // ToXValue returns a representation of this object for use in expressions
 func (l URNList) ToXValue(env envs.Environment) types.XValue {
 	return types.NewXLazyArray(func() []types.XValue {
@@ -94,6 +94,28 @@ func TestContactURN(t *testing.T) {
 	assert.Equal(t, channel, urn.Channel())
 }
 
+func TestContactURNNormalized(t *testing.T) {
+	channel := test.NewChannel("Android", "tel:+250781234567", []string{"tel"}, []assets.ChannelRole{assets.ChannelRoleSend}, nil)
+
+	// local number is converted to E.164 and channel is preserved
+	urn := flows.NewContactURN(urns.URN("tel:0788383383"), channel)
+	normalized, err := urn.Normalized(envs.Country("RW"))
+	require.NoError(t, err)
+	assert.Equal(t, urns.URN("tel:+250788383383"), normalized.URN())
+	assert.Equal(t, channel, normalized.Channel())
+	assert.Equal(t, urns.URN("tel:0788383383"), urn.URN()) // original unchanged
+
+	// number already in international format is left alone
+	normalized, err = flows.NewContactURN(urns.URN("tel:+1234567890"), nil).Normalized(envs.Country("US"))
+	require.NoError(t, err)
+	assert.Equal(t, urns.URN("tel:+1234567890"), normalized.URN())
+
+	// other schemes are normalized according to their own rules
+	normalized, err = flows.NewContactURN(urns.URN("twitter:JimBob"), nil).Normalized(envs.NilCountry)
+	require.NoError(t, err)
+	assert.Equal(t, urns.URN("twitter:jimbob"), normalized.URN())
+}
+
 func TestURNList(t *testing.T) {
 	urn1 := flows.NewContactURN("tel:+250781234567", nil)
 	urn2 := flows.NewContactURN("twitter:134252511151#billy_bob", nil)
@@ -114,3 +136,29 @@ func TestURNList(t *testing.T) {
 		types.NewXText("tel:+250781111222"),
 	), urnList.ToXValue(env))
 }
+
+func TestURNListPreferredChannelURN(t *testing.T) {
+	channel1 := test.NewTelChannel("Nexmo", "+12345", []assets.ChannelRole{assets.ChannelRoleSend}, nil, "RW", nil, false)
+	channel2 := test.NewTelChannel("Twilio", "+23456", []assets.ChannelRole{assets.ChannelRoleSend}, nil, "RW", nil, false)
+
+	twitter := flows.NewContactURN("twitter:134252511151#billy_bob", nil)
+
+	// all URNs of the scheme have preferred channels so first is returned
+	tel1 := flows.NewContactURN("tel:+250781234567", channel1)
+	tel2 := flows.NewContactURN("tel:+250781111222", channel2)
+	assert.Equal(t, tel1, flows.URNList{twitter, tel1, tel2}.PreferredChannelURN("tel"))
+
+	// mix of URNs with and without preferred channels
+	tel1 = flows.NewContactURN("tel:+250781234567", nil)
+	tel2 = flows.NewContactURN("tel:+250781111222", channel2)
+	assert.Equal(t, tel2, flows.URNList{tel1, twitter, tel2}.PreferredChannelURN("tel"))
+
+	// none have preferred channels so first of that scheme is returned
+	tel2 = flows.NewContactURN("tel:+250781111222", nil)
+	assert.Equal(t, tel1, flows.URNList{twitter, tel1, tel2}.PreferredChannelURN("tel"))
+	assert.Equal(t, twitter, flows.URNList{twitter, tel1, tel2}.PreferredChannelURN("twitter"))
+
+	// no URNs of that scheme
+	assert.Nil(t, flows.URNList{twitter, tel1, tel2}.PreferredChannelURN("mailto"))
+	assert.Nil(t, flows.URNList{}.PreferredChannelURN("tel"))
+}
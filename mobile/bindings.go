@@ -4,6 +4,15 @@ package mobile
 //
 // go get golang.org/x/mobile/cmd/gomobile
 // gomobile bind -target android -javapkg=com.nyaruka.goflow -o mobile/goflow.aar github.com/nyaruka/goflow/mobile
+//
+// To build an iOS framework (requires macOS with Xcode):
+//
+// go get golang.org/x/mobile/cmd/gomobile
+// gomobile bind -target ios -o mobile/ios_test/Goflow.xcframework github.com/nyaruka/goflow/mobile
+//
+// The exported API must only use types supported by gomobile on both platforms, i.e. basic types, strings, errors and
+// pointers to structs in this package, so slices are wrapped in types like StringSlice. The Swift smoke tests in
+// mobile/ios_test can be run against the built framework with xcodebuild.
 
 import (
 	"encoding/json"
@@ -20,6 +29,7 @@ import (
 	"github.com/nyaruka/goflow/flows/engine"
 	"github.com/nyaruka/goflow/flows/resumes"
 	"github.com/nyaruka/goflow/flows/routers/waits"
+	"github.com/nyaruka/goflow/flows/routers/waits/hints"
 	"github.com/nyaruka/goflow/flows/triggers"
 	"github.com/nyaruka/goflow/utils"
 
@@ -111,6 +121,21 @@ func NewEmptyContact(sa *SessionAssets) *Contact {
 	}
 }
 
+// Anonymize returns a copy of this contact with its name, URNs and field values removed
+func (c *Contact) Anonymize() *Contact {
+	return &Contact{target: c.target.Anonymize()}
+}
+
+// CreatedOn gets the created on time of this contact formatted as RFC3339
+func (c *Contact) CreatedOn() string {
+	return c.target.CreatedOn().Format(time.RFC3339)
+}
+
+// HasGroup returns whether this contact belongs to the group with the given UUID
+func (c *Contact) HasGroup(uuid string) bool {
+	return c.target.HasGroup(assets.GroupUUID(uuid))
+}
+
 // MsgIn is an incoming message
 type MsgIn struct {
 	target *flows.MsgIn
@@ -131,10 +156,28 @@ func NewMsgIn(uuid string, text string, attachments *StringSlice) *MsgIn {
 	}
 }
 
+// SetQuickReply sets the quick reply payload, if the message was sent by selecting a quick reply
+func (m *MsgIn) SetQuickReply(quickReply string) {
+	m.target.SetQuickReply(quickReply)
+}
+
+// SetExternalID sets the channel specific ID of the message, if the channel provided one
+func (m *MsgIn) SetExternalID(externalID string) {
+	m.target.SetExternalID(externalID)
+}
+
 func (m *MsgIn) Text() string {
 	return m.target.Text()
 }
 
+func (m *MsgIn) ExternalID() string {
+	return m.target.ExternalID()
+}
+
+func (m *MsgIn) QuickReply() string {
+	return m.target.QuickReply()
+}
+
 func (m *MsgIn) Attachments() *StringSlice {
 	attachments := NewStringSlice(len(m.target.Attachments()))
 	for _, attachment := range m.target.Attachments() {
@@ -167,6 +210,24 @@ func NewManualTrigger(environment *Environment, contact *Contact, flow *FlowRefe
 	}
 }
 
+// NewScheduledTrigger creates a new scheduled trigger. The scheduled time should be formatted as RFC3339 and the
+// campaign UUID and name can be empty if the schedule doesn't belong to a campaign.
+func NewScheduledTrigger(environment *Environment, contact *Contact, flow *FlowReference, scheduledOn string, campaignUUID string, campaignName string) (*Trigger, error) {
+	scheduledTime, err := time.Parse(time.RFC3339, scheduledOn)
+	if err != nil {
+		return nil, err
+	}
+
+	flowRef := assets.NewFlowReference(assets.FlowUUID(flow.uuid), flow.name)
+	builder := triggers.NewBuilder(environment.target, flowRef, contact.target).Scheduled(scheduledTime)
+
+	if campaignUUID != "" {
+		builder.WithCampaign(triggers.NewCampaignReference(triggers.CampaignUUID(campaignUUID), campaignName))
+	}
+
+	return &Trigger{target: builder.Build()}, nil
+}
+
 // Resume represents something which can resume a session
 type Resume struct {
 	target flows.Resume
@@ -188,6 +249,22 @@ func NewMsgResume(environment *Environment, contact *Contact, msg *MsgIn) *Resum
 	}
 }
 
+// NewLocationResume creates a new location resume
+func NewLocationResume(environment *Environment, contact *Contact, latitude float64, longitude float64) *Resume {
+	var e envs.Environment
+	if environment != nil {
+		e = environment.target
+	}
+	var c *flows.Contact
+	if contact != nil {
+		c = contact.target
+	}
+
+	return &Resume{
+		target: resumes.NewLocation(e, c, latitude, longitude, 0),
+	}
+}
+
 type Event struct {
 	type_   string
 	payload string
@@ -216,7 +293,24 @@ func (m *Modifier) Payload() string {
 
 // Sprint is an interaction with the engine - i.e. a start or resume of a session
 type Sprint struct {
-	target flows.Sprint
+	target     flows.Sprint
+	pathLength int
+	resultKeys []string
+}
+
+func newSprint(sprint flows.Sprint, session flows.Session) *Sprint {
+	// use the current run, or if the session has ended, the run it ended in
+	run := session.CurrentRun()
+	if run == nil && len(session.Runs()) > 0 {
+		run = session.Runs()[len(session.Runs())-1]
+	}
+
+	s := &Sprint{target: sprint}
+	if run != nil {
+		s.pathLength = len(run.Path())
+		s.resultKeys = run.Results().Keys()
+	}
+	return s
 }
 
 // Modifiers returns the modifiers created during this sprint
@@ -239,12 +333,31 @@ func (s *Sprint) Events() *EventSlice {
 	return events
 }
 
+// DurationMillis returns how long this sprint took in milliseconds
+func (s *Sprint) DurationMillis() int {
+	return int(s.target.Duration() / time.Millisecond)
+}
+
+// PathLength returns the number of steps in the path of the run the session was left in at the end of this sprint
+func (s *Sprint) PathLength() int {
+	return s.pathLength
+}
+
+// ResultKeys returns the sorted keys of the results of the run the session was left in at the end of this sprint
+func (s *Sprint) ResultKeys() *StringSlice {
+	keys := NewStringSlice(len(s.resultKeys))
+	for _, key := range s.resultKeys {
+		keys.Add(key)
+	}
+	return keys
+}
+
 // Session represents a session with the flow engine
 type Session struct {
 	target flows.Session
 }
 
-// Status returns the status of this session
+// Status returns the status of this session, one of active, waiting, completed, failed, expired or interrupted
 func (s *Session) Status() string {
 	return string(s.target.Status())
 }
@@ -260,7 +373,7 @@ func (s *Session) Resume(resume *Resume) (*Sprint, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Sprint{target: sprint}, nil
+	return newSprint(sprint, s.target), nil
 }
 
 // GetWait gets the current wait of this session.. can't call this Wait() because Object in Java already has a wait() method
@@ -271,6 +384,22 @@ func (s *Session) GetWait() *Wait {
 	return nil
 }
 
+// CurrentRunUUID returns the UUID of the current run of this session, or empty string if it has ended
+func (s *Session) CurrentRunUUID() string {
+	if run := s.target.CurrentRun(); run != nil {
+		return string(run.UUID())
+	}
+	return ""
+}
+
+// CurrentFlowUUID returns the UUID of the flow of the current run of this session, or empty string if it has ended
+func (s *Session) CurrentFlowUUID() string {
+	if run := s.target.CurrentRun(); run != nil && run.Flow() != nil {
+		return string(run.Flow().UUID())
+	}
+	return ""
+}
+
 // ToJSON serializes this session as JSON
 func (s *Session) ToJSON() (string, error) {
 	data, err := jsonx.Marshal(s.target)
@@ -280,6 +409,19 @@ func (s *Session) ToJSON() (string, error) {
 	return string(data), nil
 }
 
+// ToJSONIndented serializes this session as JSON indented with the given string, or two spaces if that's empty
+func (s *Session) ToJSONIndented(indent string) (string, error) {
+	if indent == "" {
+		indent = "  "
+	}
+
+	data, err := json.MarshalIndent(s.target, "", indent)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
 type Hint struct {
 	target flows.Hint
 }
@@ -304,6 +446,63 @@ func (w *Wait) Hint() *Hint {
 	return nil
 }
 
+// Timeout gets the number of seconds after which this wait will time out, or -1 if it has no timeout
+func (w *Wait) Timeout() int {
+	if w.target.TimeoutSeconds() != nil {
+		return *w.target.TimeoutSeconds()
+	}
+	return -1
+}
+
+// DialURN gets the URN being dialed if this is a dial wait, otherwise an empty string
+func (w *Wait) DialURN() string {
+	asDialWait, isDialWait := w.target.(*waits.ActivatedDialWait)
+	if isDialWait {
+		return string(asDialWait.URN())
+	}
+	return ""
+}
+
+// GetIVRHint gets the IVR specific details of this wait, or nil if this isn't an IVR wait. Message waits with a digits
+// hint and dial waits are both considered IVR waits.
+func (w *Wait) GetIVRHint() *IVRHint {
+	timeoutSeconds := 0
+	if w.target.TimeoutSeconds() != nil {
+		timeoutSeconds = *w.target.TimeoutSeconds()
+	}
+
+	switch typed := w.target.(type) {
+	case *waits.ActivatedMsgWait:
+		digits, isDigits := typed.Hint().(*hints.DigitsHint)
+		if isDigits {
+			maxDigits := 0
+			if digits.Count != nil {
+				maxDigits = *digits.Count
+			}
+			return &IVRHint{maxDigits: maxDigits, termChar: digits.TerminatedBy, timeoutSeconds: timeoutSeconds}
+		}
+	case *waits.ActivatedDialWait:
+		return &IVRHint{timeoutSeconds: timeoutSeconds}
+	}
+	return nil
+}
+
+// IVRHint describes what an IVR wait is expecting from the caller
+type IVRHint struct {
+	maxDigits      int
+	termChar       string
+	timeoutSeconds int
+}
+
+// MaxDigits gets the number of digits to collect, or zero if there is no fixed number
+func (h *IVRHint) MaxDigits() int { return h.maxDigits }
+
+// TermChar gets the key which terminates digit collection, or an empty string if there is none
+func (h *IVRHint) TermChar() string { return h.termChar }
+
+// TimeoutSeconds gets the number of seconds to wait, or zero if there is no timeout
+func (h *IVRHint) TimeoutSeconds() int { return h.timeoutSeconds }
+
 type Engine struct {
 	target flows.Engine
 }
@@ -323,7 +522,7 @@ func (e *Engine) NewSession(sa *SessionAssets, trigger *Trigger) (*SessionAndSpr
 
 	return &SessionAndSprint{
 		session: &Session{target: session},
-		sprint:  &Sprint{target: sprint},
+		sprint:  newSprint(sprint, session),
 	}, nil
 }
 
@@ -1,12 +1,17 @@
 package mobile_test
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/nyaruka/gocommon/uuids"
 	"github.com/nyaruka/goflow/flows/definition"
 	"github.com/nyaruka/goflow/mobile"
+	"github.com/nyaruka/goflow/test"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -46,6 +51,11 @@ func TestMobileBindings(t *testing.T) {
 	require.NoError(t, err)
 
 	contact := mobile.NewEmptyContact(sa)
+	createdOn, err := time.Parse(time.RFC3339, contact.CreatedOn())
+	require.NoError(t, err)
+	assert.False(t, createdOn.IsZero())
+	assert.Equal(t, contact.CreatedOn(), contact.Anonymize().CreatedOn())
+	assert.False(t, contact.HasGroup("b7cf0d83-f1c9-411c-96fd-c511a4cfa86d"))
 
 	trigger := mobile.NewManualTrigger(environment, contact, mobile.NewFlowReference("7c3db26f-e12a-48af-9673-e2feefdf8516", "Two Questions"))
 
@@ -64,17 +74,26 @@ func TestMobileBindings(t *testing.T) {
 
 	modifiers := sprint.Modifiers()
 	assert.Equal(t, 0, modifiers.Length())
+	assert.True(t, sprint.DurationMillis() >= 0)
+	assert.Equal(t, 1, sprint.PathLength())
+	assert.Equal(t, 0, sprint.ResultKeys().Length())
+
+	assert.Equal(t, "7c3db26f-e12a-48af-9673-e2feefdf8516", session.CurrentFlowUUID())
+	assert.NotEqual(t, "", session.CurrentRunUUID())
 
 	wait := session.GetWait()
 	assert.Equal(t, "msg", wait.Type())
 	assert.Nil(t, wait.Hint())
+	assert.Equal(t, -1, wait.Timeout())
 
 	attachments := mobile.NewStringSlice(1)
 	attachments.Add("content://io.rapidpro.surveyor/files/selfie.jpg")
 	msg := mobile.NewMsgIn("8e6f0213-a122-4c50-a430-442085754c16", "Hi there", attachments)
+	msg.SetExternalID("SMS123")
 
 	assert.Equal(t, "Hi there", msg.Text())
 	assert.Equal(t, 1, msg.Attachments().Length())
+	assert.Equal(t, "SMS123", msg.ExternalID())
 
 	resume := mobile.NewMsgResume(nil, nil, msg)
 
@@ -85,9 +104,13 @@ func TestMobileBindings(t *testing.T) {
 	assert.Equal(t, 4, events.Length())
 	assert.Equal(t, "msg_received", events.Get(0).Type())
 	assert.Equal(t, `{"type":"msg_received","created_`, events.Get(0).Payload()[:32])
+	assert.Contains(t, events.Get(0).Payload(), `"external_id":"SMS123"`)
 	assert.Equal(t, "run_result_changed", events.Get(1).Type())
 	assert.Equal(t, "msg_created", events.Get(2).Type())
 	assert.Equal(t, "msg_wait", events.Get(3).Type())
+	assert.Equal(t, 2, sprint.PathLength())
+	assert.Equal(t, 1, sprint.ResultKeys().Length())
+	assert.Equal(t, "favorite_color", sprint.ResultKeys().Get(0))
 
 	// convert session to JSON
 	marshaled, err := session.ToJSON()
@@ -100,4 +123,246 @@ func TestMobileBindings(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, "waiting", session2.Status())
+
+	// or convert it to indented JSON for debugging
+	indented, err := session.ToJSONIndented("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "{\n  \"uuid\": \"cdf7ed27-5ad5-4028-b664-880fc7581c77\",", indented[:51])
+
+	indented, err = session.ToJSONIndented("\t")
+	require.NoError(t, err)
+
+	assert.Equal(t, "{\n\t\"uuid\": \"cdf7ed27-5ad5-4028-b664-880fc7581c77\",", indented[:50])
+
+	session2, err = eng.ReadSession(sa, indented)
+	require.NoError(t, err)
+
+	assert.Equal(t, "waiting", session2.Status())
+
+	// sessions which have been interrupted can also be read
+	interrupted := test.JSONDelete(test.JSONReplace([]byte(marshaled), []string{"status"}, []byte(`"interrupted"`)), []string{"wait"})
+
+	session3, err := eng.ReadSession(sa, string(interrupted))
+	require.NoError(t, err)
+
+	assert.Equal(t, "interrupted", session3.Status())
+}
+
+func TestMobileScheduledTrigger(t *testing.T) {
+	assetsJSON, err := ioutil.ReadFile("../test/testdata/runner/two_questions_offline.json")
+	require.NoError(t, err)
+
+	source, err := mobile.NewAssetsSource(string(assetsJSON))
+	require.NoError(t, err)
+
+	environment, err := mobile.NewEnvironment("DD-MM-YYYY", "tt:mm", "Africa/Kigali", "eng", mobile.NewStringSlice(0), "RW", "none")
+	require.NoError(t, err)
+
+	sa, err := mobile.NewSessionAssets(environment, source)
+	require.NoError(t, err)
+
+	flow := mobile.NewFlowReference("7c3db26f-e12a-48af-9673-e2feefdf8516", "Two Questions")
+
+	// error if scheduled time isn't valid
+	_, err = mobile.NewScheduledTrigger(environment, mobile.NewEmptyContact(sa), flow, "xx", "", "")
+	assert.Error(t, err)
+
+	trigger, err := mobile.NewScheduledTrigger(environment, mobile.NewEmptyContact(sa), flow, "2018-10-20T09:00:00Z", "58e9b092-fe42-4173-876c-ff45a14a24fe", "New Mothers")
+	require.NoError(t, err)
+
+	ss, err := mobile.NewEngine().NewSession(sa, trigger)
+	require.NoError(t, err)
+	assert.Equal(t, "waiting", ss.Session().Status())
+
+	marshaled, err := ss.Session().ToJSON()
+	require.NoError(t, err)
+	assert.Contains(t, marshaled, `"scheduled_on":"2018-10-20T09:00:00Z","campaign":{"uuid":"58e9b092-fe42-4173-876c-ff45a14a24fe","name":"New Mothers"}`)
+}
+
+func TestMobileLocationResume(t *testing.T) {
+	assetsJSON, err := ioutil.ReadFile("../test/testdata/runner/two_questions_offline.json")
+	require.NoError(t, err)
+
+	// make the first question a location question
+	assetsJSON = test.JSONReplace(assetsJSON, []string{"flows", "[0]", "nodes", "[0]", "router", "wait"}, []byte(`{"type": "msg", "hint": {"type": "location"}}`))
+
+	source, err := mobile.NewAssetsSource(string(assetsJSON))
+	require.NoError(t, err)
+
+	environment, err := mobile.NewEnvironment("DD-MM-YYYY", "tt:mm", "Africa/Kigali", "eng", mobile.NewStringSlice(0), "RW", "none")
+	require.NoError(t, err)
+
+	sa, err := mobile.NewSessionAssets(environment, source)
+	require.NoError(t, err)
+
+	trigger := mobile.NewManualTrigger(environment, mobile.NewEmptyContact(sa), mobile.NewFlowReference("7c3db26f-e12a-48af-9673-e2feefdf8516", "Two Questions"))
+
+	ss, err := mobile.NewEngine().NewSession(sa, trigger)
+	require.NoError(t, err)
+	assert.Equal(t, "location", ss.Session().GetWait().Hint().Type())
+
+	sprint, err := ss.Session().Resume(mobile.NewLocationResume(nil, nil, -2.90875, -79.0117686))
+	require.NoError(t, err)
+
+	events := sprint.Events()
+	require.True(t, events.Length() > 0)
+	assert.Equal(t, "location_received", events.Get(0).Type())
+}
+
+func TestMsgInAttachments(t *testing.T) {
+	attachments := mobile.NewStringSlice(2)
+	attachments.Add("image/png:https://example.com/a.png")
+	attachments.Add("audio/mp3:https://example.com/b.mp3")
+
+	msg := mobile.NewMsgIn("8e6f0213-a122-4c50-a430-442085754c16", "Hi there", attachments)
+
+	actual := msg.Attachments()
+	require.Equal(t, 2, actual.Length())
+	assert.Equal(t, "image/png:https://example.com/a.png", actual.Get(0))
+	assert.Equal(t, "audio/mp3:https://example.com/b.mp3", actual.Get(1))
+
+	// no attachments is an empty slice
+	msg = mobile.NewMsgIn("8e6f0213-a122-4c50-a430-442085754c16", "Hi there", nil)
+	assert.Equal(t, 0, msg.Attachments().Length())
+	assert.Equal(t, "", msg.QuickReply())
+
+	// messages can have a quick reply payload
+	msg = mobile.NewMsgIn("8e6f0213-a122-4c50-a430-442085754c16", "Yes", nil)
+	msg.SetQuickReply("CONFIRM_YES")
+	assert.Equal(t, "CONFIRM_YES", msg.QuickReply())
+}
+
+func TestMobileIVRWaits(t *testing.T) {
+	assetsJSON, err := ioutil.ReadFile("../test/testdata/runner/two_questions_offline.json")
+	require.NoError(t, err)
+
+	source, err := mobile.NewAssetsSource(string(assetsJSON))
+	require.NoError(t, err)
+
+	environment, err := mobile.NewEnvironment("DD-MM-YYYY", "tt:mm", "Africa/Kigali", "eng", mobile.NewStringSlice(0), "RW", "none")
+	require.NoError(t, err)
+
+	sa, err := mobile.NewSessionAssets(environment, source)
+	require.NoError(t, err)
+
+	trigger := mobile.NewManualTrigger(environment, mobile.NewEmptyContact(sa), mobile.NewFlowReference("7c3db26f-e12a-48af-9673-e2feefdf8516", "Two Questions"))
+
+	eng := mobile.NewEngine()
+	ss, err := eng.NewSession(sa, trigger)
+	require.NoError(t, err)
+
+	// regular message waits don't have IVR hints
+	assert.Nil(t, ss.Session().GetWait().GetIVRHint())
+	assert.Equal(t, "", ss.Session().GetWait().DialURN())
+
+	marshaled, err := ss.Session().ToJSON()
+	require.NoError(t, err)
+
+	// mobile can't start voice sessions, so swap in the waits an IVR flow would create
+	readWithWait := func(wait string) *mobile.Wait {
+		session, err := eng.ReadSession(sa, string(test.JSONReplace([]byte(marshaled), []string{"wait"}, []byte(wait))))
+		require.NoError(t, err)
+		return session.GetWait()
+	}
+
+	wait := readWithWait(`{"type": "msg", "timeout_seconds": 30, "hint": {"type": "digits", "count": 4}}`)
+	assert.Equal(t, 30, wait.Timeout())
+	hint := wait.GetIVRHint()
+	require.NotNil(t, hint)
+	assert.Equal(t, 4, hint.MaxDigits())
+	assert.Equal(t, "", hint.TermChar())
+	assert.Equal(t, 30, hint.TimeoutSeconds())
+
+	wait = readWithWait(`{"type": "msg", "hint": {"type": "digits", "terminated_by": "#"}}`)
+	hint = wait.GetIVRHint()
+	require.NotNil(t, hint)
+	assert.Equal(t, 0, hint.MaxDigits())
+	assert.Equal(t, "#", hint.TermChar())
+	assert.Equal(t, 0, hint.TimeoutSeconds())
+
+	wait = readWithWait(`{"type": "dial", "urn": "tel:+593979123456"}`)
+	assert.Equal(t, "dial", wait.Type())
+	assert.Equal(t, "tel:+593979123456", wait.DialURN())
+	hint = wait.GetIVRHint()
+	require.NotNil(t, hint)
+	assert.Equal(t, 0, hint.MaxDigits())
+	assert.Equal(t, "", hint.TermChar())
+	assert.Equal(t, 0, hint.TimeoutSeconds())
+
+	// non-digit hints aren't IVR hints
+	wait = readWithWait(`{"type": "msg", "hint": {"type": "image"}}`)
+	assert.Equal(t, "image", wait.Hint().Type())
+	assert.Nil(t, wait.GetIVRHint())
+}
+
+func TestGomobileCompatibility(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", nil, 0)
+	require.NoError(t, err)
+
+	pkg := pkgs["mobile"]
+	require.NotNil(t, pkg)
+
+	// gather the exported struct types in this package
+	structs := make(map[string]bool)
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			if gen, isGen := decl.(*ast.GenDecl); isGen && gen.Tok == token.TYPE {
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					if _, isStruct := typeSpec.Type.(*ast.StructType); isStruct && typeSpec.Name.IsExported() {
+						structs[typeSpec.Name.Name] = true
+					}
+				}
+			}
+		}
+	}
+
+	// types which gomobile can bind for both Android and iOS
+	isSupported := func(expr ast.Expr) bool {
+		switch typed := expr.(type) {
+		case *ast.Ident:
+			switch typed.Name {
+			case "bool", "int", "int8", "int16", "int32", "int64", "uint8", "float32", "float64", "string", "error":
+				return true
+			}
+		case *ast.StarExpr:
+			ident, isIdent := typed.X.(*ast.Ident)
+			return isIdent && structs[ident.Name]
+		case *ast.ArrayType:
+			ident, isIdent := typed.Elt.(*ast.Ident)
+			return typed.Len == nil && isIdent && ident.Name == "byte"
+		}
+		return false
+	}
+
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			fn, isFunc := decl.(*ast.FuncDecl)
+			if !isFunc || !fn.Name.IsExported() {
+				continue
+			}
+
+			if fn.Type.Params != nil {
+				for _, param := range fn.Type.Params.List {
+					assert.True(t, isSupported(param.Type), "unsupported parameter type in %s at %s", fn.Name.Name, fset.Position(param.Pos()))
+				}
+			}
+			if fn.Type.Results != nil {
+				results := fn.Type.Results.List
+				assert.LessOrEqual(t, len(results), 2, "too many results from %s", fn.Name.Name)
+
+				for i, result := range results {
+					assert.True(t, isSupported(result.Type), "unsupported result type in %s at %s", fn.Name.Name, fset.Position(result.Pos()))
+
+					// a second result must be an error
+					if i == 1 {
+						ident, isIdent := result.Type.(*ast.Ident)
+						assert.True(t, isIdent && ident.Name == "error", "second result of %s isn't an error", fn.Name.Name)
+					}
+				}
+			}
+		}
+	}
 }
@@ -0,0 +1,20 @@
+// swift-tools-version:5.3
+
+// Smoke tests for the iOS bindings. Build the framework first with:
+//
+//   gomobile bind -target ios -o mobile/ios_test/Goflow.xcframework github.com/nyaruka/goflow/mobile
+//
+// and then run the tests on a simulator with:
+//
+//   xcodebuild test -scheme GoflowTests -destination 'platform=iOS Simulator,name=iPhone 12'
+
+import PackageDescription
+
+let package = Package(
+    name: "GoflowTests",
+    platforms: [.iOS(.v11)],
+    targets: [
+        .binaryTarget(name: "Goflow", path: "Goflow.xcframework"),
+        .testTarget(name: "GoflowTests", dependencies: ["Goflow"])
+    ]
+)
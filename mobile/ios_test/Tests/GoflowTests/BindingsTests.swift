@@ -0,0 +1,105 @@
+import XCTest
+import Goflow
+
+let assetsJSON = """
+{
+    "flows": [
+        {
+            "uuid": "7c3db26f-e12a-48af-9673-e2feefdf8516",
+            "name": "Two Questions",
+            "spec_version": "13.1",
+            "language": "eng",
+            "type": "messaging_offline",
+            "nodes": [
+                {
+                    "uuid": "46d51f50-58de-49da-8d13-dadbf322685d",
+                    "actions": [
+                        {"uuid": "e97cd6d5-3354-4dbd-85bc-6c1f87849eec", "type": "send_msg", "text": "What is your favorite color?"}
+                    ],
+                    "router": {
+                        "type": "switch",
+                        "wait": {"type": "msg"},
+                        "operand": "@input.text",
+                        "categories": [
+                            {"uuid": "3ffb6f24-2ed8-4fd5-bcc0-b1e2c9ed43d1", "name": "All Responses", "exit_uuid": "100f2d68-2481-4137-a0a3-177620ba3c5f"}
+                        ],
+                        "default_category_uuid": "3ffb6f24-2ed8-4fd5-bcc0-b1e2c9ed43d1",
+                        "result_name": "Favorite Color"
+                    },
+                    "exits": [{"uuid": "100f2d68-2481-4137-a0a3-177620ba3c5f"}]
+                }
+            ]
+        }
+    ]
+}
+"""
+
+class BindingsTests: XCTestCase {
+    func newSessionAssets() throws -> (MobileEnvironment, MobileSessionAssets) {
+        var error: NSError?
+
+        let langs = MobileNewStringSlice(2)!
+        langs.add("eng")
+        langs.add("fra")
+
+        let environment = MobileNewEnvironment("DD-MM-YYYY", "tt:mm", "Africa/Kigali", "eng", langs, "RW", "none", &error)
+        if let error = error { throw error }
+
+        let source = MobileNewAssetsSource(assetsJSON, &error)
+        if let error = error { throw error }
+
+        let sa = MobileNewSessionAssets(environment, source, &error)
+        if let error = error { throw error }
+
+        return (environment!, sa!)
+    }
+
+    func testEnvironment() {
+        var error: NSError?
+
+        let environment = MobileNewEnvironment("DD-MM-YYYY", "tt:mm", "Africa/Kigali", "eng", MobileNewStringSlice(0), "RW", "none", &error)
+        XCTAssertNil(error)
+        XCTAssertNotNil(environment)
+
+        // invalid timezones are errors
+        _ = MobileNewEnvironment("DD-MM-YYYY", "tt:mm", "Cuenca", "eng", MobileNewStringSlice(0), "EC", "none", &error)
+        XCTAssertNotNil(error)
+    }
+
+    func testMsgIn() {
+        let attachments = MobileNewStringSlice(1)!
+        attachments.add("image/jpeg:content://io.rapidpro.surveyor/files/selfie.jpg")
+
+        let msg = MobileNewMsgIn("8e6f0213-a122-4c50-a430-442085754c16", "Hi there", attachments)!
+
+        XCTAssertEqual(msg.text(), "Hi there")
+        XCTAssertEqual(msg.attachments()!.length(), 1)
+    }
+
+    func testSessionLifecycle() throws {
+        let (environment, sa) = try newSessionAssets()
+
+        let contact = MobileNewEmptyContact(sa)
+        let trigger = MobileNewManualTrigger(environment, contact, MobileNewFlowReference("7c3db26f-e12a-48af-9673-e2feefdf8516", "Two Questions"))
+
+        let engine = MobileNewEngine()!
+        let ss = try engine.newSession(sa, trigger: trigger)
+        let session = ss.session()!
+
+        XCTAssertEqual(session.status(), "waiting")
+        XCTAssertEqual(ss.sprint()!.events()!.length(), 2)
+        XCTAssertEqual(session.getWait()!.type(), "msg")
+
+        let msg = MobileNewMsgIn("8e6f0213-a122-4c50-a430-442085754c16", "Blue", MobileNewStringSlice(0))
+        let sprint = try session.resume(MobileNewMsgResume(nil, nil, msg))
+
+        XCTAssertEqual(session.status(), "completed")
+        XCTAssertEqual(sprint.events()!.get(0)!.type(), "msg_received")
+
+        // sessions can be serialized and read back
+        let json = try session.toJSON()
+        let session2 = try engine.readSession(sa, data: json)
+
+        XCTAssertEqual(session2.status(), "completed")
+    }
+}
@@ -3,7 +3,6 @@ package dtone_test
 import (
 	"net/http"
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/httpx"
@@ -357,7 +356,7 @@ func TestClient(t *testing.T) {
 	})
 
 	httpx.SetRequestor(mocks)
-	dates.SetNowSource(dates.NewSequentialNowSource(time.Date(2019, 10, 9, 15, 25, 30, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewSequentialNowSource(test.MustParseTime("2019-10-09T15:25:30.123456789Z")))
 
 	cl := dtone.NewClient(http.DefaultClient, nil, "key123", "sesame")
 
@@ -3,7 +3,6 @@ package dtone_test
 import (
 	"net/http"
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/httpx"
@@ -46,13 +45,13 @@ func TestServiceWithSuccessfulTranfer(t *testing.T) {
 	})
 
 	uuids.SetGenerator(uuids.NewSeededGenerator(12345))
-	dates.SetNowSource(dates.NewSequentialNowSource(time.Date(2019, 10, 7, 15, 21, 30, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewSequentialNowSource(test.MustParseTime("2019-10-07T15:21:30.123456789Z")))
 	httpx.SetRequestor(mocks)
-	dates.SetNowSource(dates.NewSequentialNowSource(time.Date(2019, 10, 9, 15, 25, 30, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewSequentialNowSource(test.MustParseTime("2019-10-09T15:25:30.123456789Z")))
 
 	svc := dtone.NewService(http.DefaultClient, nil, "key123", "sesame")
 
-	httpLogger := &flows.HTTPLogger{}
+	httpLogger := flows.NewHTTPLogger(4096)
 
 	transfer, err := svc.Transfer(
 		session,
@@ -110,13 +109,13 @@ func TestServiceFailedTransfers(t *testing.T) {
 	})
 
 	uuids.SetGenerator(uuids.NewSeededGenerator(12345))
-	dates.SetNowSource(dates.NewSequentialNowSource(time.Date(2019, 10, 7, 15, 21, 30, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewSequentialNowSource(test.MustParseTime("2019-10-07T15:21:30.123456789Z")))
 	httpx.SetRequestor(mocks)
-	dates.SetNowSource(dates.NewSequentialNowSource(time.Date(2019, 10, 9, 15, 25, 30, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewSequentialNowSource(test.MustParseTime("2019-10-09T15:25:30.123456789Z")))
 
 	svc := dtone.NewService(http.DefaultClient, nil, "key123", "sesame")
 
-	httpLogger := &flows.HTTPLogger{}
+	httpLogger := flows.NewHTTPLogger(4096)
 	amounts := map[string]decimal.Decimal{"USD": decimal.RequireFromString("3.5")}
 
 	// try when phone number lookup gives a connection error
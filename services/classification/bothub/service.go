@@ -31,7 +31,7 @@ func (s *service) Classify(session flows.Session, input string, logHTTP flows.HT
 
 	response, trace, err := s.client.Parse(input, localeStr)
 	if trace != nil {
-		logHTTP(flows.NewHTTPLog(trace, flows.HTTPStatusFromCode, s.redactor))
+		logHTTP(flows.NewHTTPLogWithBodies(trace, flows.HTTPStatusFromCode, s.redactor))
 	}
 	if err != nil {
 		return nil, err
@@ -3,7 +3,6 @@ package bothub_test
 import (
 	"net/http"
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/httpx"
@@ -27,7 +26,7 @@ func TestService(t *testing.T) {
 	defer httpx.SetRequestor(httpx.DefaultRequestor)
 
 	uuids.SetGenerator(uuids.NewSeededGenerator(12345))
-	dates.SetNowSource(dates.NewSequentialNowSource(time.Date(2019, 10, 7, 15, 21, 30, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewSequentialNowSource(test.MustParseTime("2019-10-07T15:21:30.123456789Z")))
 	httpx.SetRequestor(httpx.NewMockRequestor(map[string][]httpx.MockResponse{
 		"https://nlp.bothub.it/parse": {
 			httpx.NewMockResponse(200, nil, `{
@@ -76,7 +75,7 @@ func TestService(t *testing.T) {
 		"f96abf2f-3b53-4766-8ea6-09a655222a02",
 	)
 
-	httpLogger := &flows.HTTPLogger{}
+	httpLogger := flows.NewHTTPLogger(4096)
 
 	classification, err := svc.Classify(session, "book my flight to Quito", httpLogger.Log)
 	assert.NoError(t, err)
@@ -27,7 +27,7 @@ func NewService(httpClient *http.Client, httpRetries *httpx.RetryConfig, httpAcc
 func (s *service) Classify(session flows.Session, input string, logHTTP flows.HTTPLogCallback) (*flows.Classification, error) {
 	response, trace, err := s.client.Predict(input)
 	if trace != nil {
-		logHTTP(flows.NewHTTPLog(trace, flows.HTTPStatusFromCode, s.redactor))
+		logHTTP(flows.NewHTTPLogWithBodies(trace, flows.HTTPStatusFromCode, s.redactor))
 	}
 	if err != nil {
 		return nil, err
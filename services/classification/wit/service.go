@@ -28,7 +28,7 @@ func NewService(httpClient *http.Client, httpRetries *httpx.RetryConfig, classif
 func (s *service) Classify(session flows.Session, input string, logHTTP flows.HTTPLogCallback) (*flows.Classification, error) {
 	response, trace, err := s.client.Message(input)
 	if trace != nil {
-		logHTTP(flows.NewHTTPLog(trace, flows.HTTPStatusFromCode, s.redactor))
+		logHTTP(flows.NewHTTPLogWithBodies(trace, flows.HTTPStatusFromCode, s.redactor))
 	}
 	if err != nil {
 		return nil, err
@@ -3,7 +3,6 @@ package wit_test
 import (
 	"net/http"
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/httpx"
@@ -27,7 +26,7 @@ func TestService(t *testing.T) {
 	defer httpx.SetRequestor(httpx.DefaultRequestor)
 
 	uuids.SetGenerator(uuids.NewSeededGenerator(12345))
-	dates.SetNowSource(dates.NewSequentialNowSource(time.Date(2019, 10, 7, 15, 21, 30, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewSequentialNowSource(test.MustParseTime("2019-10-07T15:21:30.123456789Z")))
 	httpx.SetRequestor(httpx.NewMockRequestor(map[string][]httpx.MockResponse{
 		"https://api.wit.ai/message?v=20200513&q=book+flight+to+Quito": {
 			httpx.NewMockResponse(200, nil, `{
@@ -75,7 +74,7 @@ func TestService(t *testing.T) {
 		"23532624376",
 	)
 
-	httpLogger := &flows.HTTPLogger{}
+	httpLogger := flows.NewHTTPLogger(4096)
 
 	classification, err := svc.Classify(session, "book flight to Quito", httpLogger.Log)
 	assert.NoError(t, err)
@@ -89,4 +88,6 @@ func TestService(t *testing.T) {
 	assert.Equal(t, 1, len(httpLogger.Logs))
 	assert.Equal(t, "https://api.wit.ai/message?v=20200513&q=book+flight+to+Quito", httpLogger.Logs[0].URL)
 	assert.Equal(t, "GET /message?v=20200513&q=book+flight+to+Quito HTTP/1.1\r\nHost: api.wit.ai\r\nUser-Agent: Go-http-client/1.1\r\nAuthorization: Bearer ****************\r\nAccept-Encoding: gzip\r\n\r\n", httpLogger.Logs[0].Request)
+	assert.Nil(t, httpLogger.Logs[0].RequestBody)
+	assert.Contains(t, *httpLogger.Logs[0].ResponseBody, `"name": "book_flight"`)
 }
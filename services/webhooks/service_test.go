@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/httpx"
@@ -214,7 +213,7 @@ func TestGzipEncoding(t *testing.T) {
 
 	defer dates.SetNowSource(dates.DefaultNowSource)
 
-	dates.SetNowSource(dates.NewSequentialNowSource(time.Date(2019, 10, 7, 15, 21, 30, 123456789, time.UTC)))
+	dates.SetNowSource(dates.NewSequentialNowSource(test.MustParseTime("2019-10-07T15:21:30.123456789Z")))
 
 	server := test.NewTestHTTPServer(52025)
 
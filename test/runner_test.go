@@ -9,7 +9,6 @@ import (
 	"regexp"
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/httpx"
@@ -201,7 +200,7 @@ func TestFlows(t *testing.T) {
 		fmt.Printf("running %s\n", tc)
 
 		uuids.SetGenerator(uuids.NewSeededGenerator(123456))
-		dates.SetNowSource(dates.NewSequentialNowSource(time.Date(2018, 7, 6, 12, 30, 0, 123456789, time.UTC)))
+		dates.SetNowSource(dates.NewSequentialNowSource(MustParseTime("2018-07-06T12:30:00.123456789Z")))
 		smtpx.SetSender(smtpx.NewMockSender(nil, nil, nil, nil, nil, nil))
 
 		testJSON, err := ioutil.ReadFile(tc.outputFile)
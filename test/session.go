@@ -597,7 +597,7 @@ func ResumeSession(session flows.Session, assetsJSON json.RawMessage, msgText st
 	return session, sprint, err
 }
 
-// EventLog is a utility for testing things which take an event logger function
+// EventLog is a utility for testing things which take an event sink
 type EventLog struct {
 	Events []flows.Event
 }
@@ -607,6 +607,7 @@ func NewEventLog() *EventLog {
 	return &EventLog{make([]flows.Event, 0)}
 }
 
-func (l *EventLog) Log(e flows.Event) {
+// Add adds the given event to this log
+func (l *EventLog) Add(e flows.Event) {
 	l.Events = append(l.Events, e)
 }
@@ -3,7 +3,10 @@ package test
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/nyaruka/gocommon/jsonx"
 	"github.com/nyaruka/goflow/excellent/types"
@@ -24,6 +27,24 @@ func AssertXEqual(t *testing.T, expected types.XValue, actual types.XValue, msgA
 	return true
 }
 
+// MustParseTime parses the given ISO 8601 datetime, e.g. "2020-03-25T11:50:30.123Z", and panics if it isn't valid
+func MustParseTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// MustParseDate parses the given ISO 8601 date, e.g. "2020-03-25", as midnight UTC and panics if it isn't valid
+func MustParseDate(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
 // NormalizeJSON re-formats the given JSON
 func NormalizeJSON(data json.RawMessage) ([]byte, error) {
 	var asGeneric interface{}
@@ -51,12 +72,85 @@ func AssertEqualJSON(t *testing.T, expected json.RawMessage, actual json.RawMess
 	diffs := differ.DiffMain(string(expectedNormalized), string(actualNormalized), false)
 
 	if len(diffs) != 1 || diffs[0].Type != diff.DiffEqual {
-		assert.Fail(t, message, differ.DiffPrettyText(diffs))
+		assert.Fail(t, message, "%s\n%s", JSONDiff(expected, actual), differ.DiffPrettyText(diffs))
 		return false
 	}
 	return true
 }
 
+// JSONDiff returns a line for each value which has been added (+), removed (-) or changed (~) between the given JSON
+// documents, identified by its JSON pointer path
+func JSONDiff(expected, actual []byte) string {
+	var exp, act interface{}
+	if err := jsonx.Unmarshal(expected, &exp); err != nil {
+		return fmt.Sprintf("invalid expected JSON: %s", err)
+	}
+	if err := jsonx.Unmarshal(actual, &act); err != nil {
+		return fmt.Sprintf("invalid actual JSON: %s", err)
+	}
+
+	lines := make([]string, 0)
+	diffJSONValues("", exp, act, func(line string) { lines = append(lines, line) })
+	return strings.Join(lines, "\n")
+}
+
+func diffJSONValues(path string, exp, act interface{}, report func(string)) {
+	format := func(v interface{}) string {
+		b, _ := jsonx.Marshal(v)
+		return string(b)
+	}
+
+	switch typedExp := exp.(type) {
+	case map[string]interface{}:
+		if typedAct, isMap := act.(map[string]interface{}); isMap {
+			keys := make([]string, 0, len(typedExp)+len(typedAct))
+			for k := range typedExp {
+				keys = append(keys, k)
+			}
+			for k := range typedAct {
+				if _, seen := typedExp[k]; !seen {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				childPath := path + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(k)
+				e, inExp := typedExp[k]
+				a, inAct := typedAct[k]
+
+				if !inAct {
+					report(fmt.Sprintf("- %s: %s", childPath, format(e)))
+				} else if !inExp {
+					report(fmt.Sprintf("+ %s: %s", childPath, format(a)))
+				} else {
+					diffJSONValues(childPath, e, a, report)
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if typedAct, isSlice := act.([]interface{}); isSlice {
+			for i := 0; i < len(typedExp) || i < len(typedAct); i++ {
+				childPath := fmt.Sprintf("%s/%d", path, i)
+
+				if i >= len(typedAct) {
+					report(fmt.Sprintf("- %s: %s", childPath, format(typedExp[i])))
+				} else if i >= len(typedExp) {
+					report(fmt.Sprintf("+ %s: %s", childPath, format(typedAct[i])))
+				} else {
+					diffJSONValues(childPath, typedExp[i], typedAct[i], report)
+				}
+			}
+			return
+		}
+	}
+
+	if expStr, actStr := format(exp), format(act); expStr != actStr {
+		report(fmt.Sprintf("~ %s: %s -> %s", path, expStr, actStr))
+	}
+}
+
 // JSONReplace replaces a node in JSON
 func JSONReplace(data json.RawMessage, path []string, value json.RawMessage) json.RawMessage {
 	newData, err := jsonparser.Set(data, value, path...)
@@ -3,6 +3,7 @@ package test_test
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/nyaruka/goflow/test"
 
@@ -13,6 +14,39 @@ func TestAssertEqualJSON(t *testing.T) {
 	assert.True(t, test.AssertEqualJSON(t, json.RawMessage(`{"foo":1,"bar":2}`), json.RawMessage(`{"bar": 2, "foo": 1}`), "doh!"))
 }
 
+func TestJSONDiff(t *testing.T) {
+	// no differences
+	assert.Equal(t, "", test.JSONDiff([]byte(`{"foo": 1, "bar": [1, 2]}`), []byte(`{"bar": [1, 2], "foo": 1.0}`)))
+
+	// added values
+	assert.Equal(t, "+ /bar/2: 3\n+ /baz: {\"a\":true}", test.JSONDiff([]byte(`{"foo": 1, "bar": [1, 2]}`), []byte(`{"foo": 1, "bar": [1, 2, 3], "baz": {"a": true}}`)))
+
+	// removed values
+	assert.Equal(t, "- /bar/1: 2\n- /foo: 1", test.JSONDiff([]byte(`{"foo": 1, "bar": [1, 2]}`), []byte(`{"bar": [1]}`)))
+
+	// changed values, including with pointer escaping and type changes
+	assert.Equal(t, "~ /a~1b/c~0d: \"x\" -> \"y\"\n~ /list: [1] -> {\"0\":1}", test.JSONDiff([]byte(`{"a/b": {"c~d": "x"}, "list": [1]}`), []byte(`{"a/b": {"c~d": "y"}, "list": {"0": 1}}`)))
+	assert.Equal(t, "~ : 1 -> 2", test.JSONDiff([]byte(`1`), []byte(`2`)))
+
+	// invalid JSON
+	assert.Equal(t, "invalid actual JSON: unexpected end of JSON input", test.JSONDiff([]byte(`{}`), []byte(`{"foo": `)))
+}
+
+func TestMustParseTime(t *testing.T) {
+	assert.Equal(t, time.Date(2020, 3, 25, 11, 50, 30, 123000000, time.UTC), test.MustParseTime("2020-03-25T11:50:30.123Z"))
+	assert.Equal(t, time.Date(2020, 3, 25, 16, 50, 30, 0, time.UTC), test.MustParseTime("2020-03-25T11:50:30-05:00").UTC())
+
+	assert.Panics(t, func() { test.MustParseTime("2020-03-25") })
+	assert.Panics(t, func() { test.MustParseTime("xyz") })
+}
+
+func TestMustParseDate(t *testing.T) {
+	assert.Equal(t, time.Date(2020, 3, 25, 0, 0, 0, 0, time.UTC), test.MustParseDate("2020-03-25"))
+
+	assert.Panics(t, func() { test.MustParseDate("2020-03-25T11:50:30Z") })
+	assert.Panics(t, func() { test.MustParseDate("2020-13-25") })
+}
+
 func TestJSONReplace(t *testing.T) {
 	assert.Equal(t, json.RawMessage(`{"foo":"x","bar":2}`), test.JSONReplace(json.RawMessage(`{"foo":1,"bar":2}`), []string{"foo"}, json.RawMessage(`"x"`)))
 }
@@ -31,3 +31,18 @@ func (a Attachment) URL() string {
 	_, url := a.ToParts()
 	return url
 }
+
+// IsImage returns whether this attachment has an image content type
+func (a Attachment) IsImage() bool { return a.mediaType() == "image" }
+
+// IsAudio returns whether this attachment has an audio content type
+func (a Attachment) IsAudio() bool { return a.mediaType() == "audio" }
+
+// IsVideo returns whether this attachment has a video content type
+func (a Attachment) IsVideo() bool { return a.mediaType() == "video" }
+
+// gets the top-level type of our content type, e.g. image for image/jpeg
+func (a Attachment) mediaType() string {
+	mediaType := strings.SplitN(a.ContentType(), "/", 2)[0]
+	return strings.ToLower(mediaType)
+}
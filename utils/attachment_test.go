@@ -14,7 +14,37 @@ func TestAttachment(t *testing.T) {
 	assert.Equal(t, "image/jpeg", attachment.ContentType())
 	assert.Equal(t, "https://example.com/test.jpg", attachment.URL())
 
+	// URLs can contain further colons
+	attachment = utils.Attachment("audio/mp3:http://example.com:8080/test.mp3?t=12:30")
+	assert.Equal(t, "audio/mp3", attachment.ContentType())
+	assert.Equal(t, "http://example.com:8080/test.mp3?t=12:30", attachment.URL())
+
 	// be lenient with invalid attachments
 	assert.Equal(t, "", utils.Attachment("foo").ContentType())
 	assert.Equal(t, "foo", utils.Attachment("foo").URL())
+
+	tcs := []struct {
+		attachment utils.Attachment
+		isImage    bool
+		isAudio    bool
+		isVideo    bool
+	}{
+		{"image/jpeg:https://example.com/test.jpg", true, false, false},
+		{"image:https://example.com/test.jpg", true, false, false},
+		{"IMAGE/PNG:https://example.com/test.png", true, false, false},
+		{"audio/mp3:https://example.com/test.mp3", false, true, false},
+		{"audio:https://example.com/test.mp3", false, true, false},
+		{"video/mp4:https://example.com/test.mp4", false, false, true},
+		{"video:https://example.com/test.mp4", false, false, true},
+		{"application/pdf:https://example.com/test.pdf", false, false, false},
+		{"geo:-2.90875,-79.0117686", false, false, false},
+		{"https://example.com/test.jpg", false, false, false},
+		{"", false, false, false},
+	}
+
+	for _, tc := range tcs {
+		assert.Equal(t, tc.isImage, tc.attachment.IsImage(), "is image mismatch for %s", tc.attachment)
+		assert.Equal(t, tc.isAudio, tc.attachment.IsAudio(), "is audio mismatch for %s", tc.attachment)
+		assert.Equal(t, tc.isVideo, tc.attachment.IsVideo(), "is video mismatch for %s", tc.attachment)
+	}
 }
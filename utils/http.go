@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LogRequests wraps the transport of the given HTTP client so that the method, URL, status and elapsed time of each
+// request it makes is written to the given writer. URLs are logged without their query strings or user info as these
+// can contain credentials or personal data. Passing a nil writer disables any logging previously enabled.
+func LogRequests(client *http.Client, w io.Writer) {
+	base := client.Transport
+	if logging, isLogging := base.(*loggingTransport); isLogging {
+		base = logging.base
+	}
+
+	if w == nil {
+		client.Transport = base
+	} else {
+		client.Transport = &loggingTransport{base: base, w: w}
+	}
+}
+
+// round tripper which logs requests made via the transport it wraps
+type loggingTransport struct {
+	base  http.RoundTripper
+	w     io.Writer
+	mutex sync.Mutex
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	// strip anything from the URL which might be sensitive
+	u := *req.URL
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if err != nil {
+		fmt.Fprintf(t.w, "%s %s error: %s (%s)\n", req.Method, u.String(), err, elapsed)
+	} else {
+		fmt.Fprintf(t.w, "%s %s %d (%s)\n", req.Method, u.String(), resp.StatusCode, elapsed)
+	}
+
+	return resp, err
+}
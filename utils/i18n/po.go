@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -166,10 +167,30 @@ func (c *POComment) String() string {
 
 // POEntry is an entry in a PO catalog
 type POEntry struct {
-	Comment    POComment // Comment
-	MsgContext string    // msgctxt context
-	MsgID      string    // msgid untranslated-string
-	MsgStr     string    // msgstr translated-string
+	Comment      POComment // Comment
+	MsgContext   string    // msgctxt context
+	MsgID        string    // msgid untranslated-string
+	MsgIDPlural  string    // msgid_plural untranslated-string-plural
+	MsgStr       string    // msgstr translated-string
+	MsgStrPlural []string  // msgstr[N] translated-string-case-n
+}
+
+// IsPlural returns whether this entry has plural forms
+func (e *POEntry) IsPlural() bool {
+	return e.MsgIDPlural != ""
+}
+
+// IsTranslated returns whether this entry has a translation, or for plural entries, any translated plural form
+func (e *POEntry) IsTranslated() bool {
+	if e.IsPlural() {
+		for _, str := range e.MsgStrPlural {
+			if str != "" {
+				return true
+			}
+		}
+		return false
+	}
+	return e.MsgStr != ""
 }
 
 func (e *POEntry) Write(w io.Writer) {
@@ -181,7 +202,15 @@ func (e *POEntry) Write(w io.Writer) {
 		fmt.Fprintf(w, "msgctxt %s\n", EncodePOString(e.MsgContext))
 	}
 	fmt.Fprintf(w, "msgid %s\n", EncodePOString(e.MsgID))
-	fmt.Fprintf(w, "msgstr %s\n", EncodePOString(e.MsgStr))
+
+	if e.IsPlural() {
+		fmt.Fprintf(w, "msgid_plural %s\n", EncodePOString(e.MsgIDPlural))
+		for i, str := range e.MsgStrPlural {
+			fmt.Fprintf(w, "msgstr[%d] %s\n", i, EncodePOString(str))
+		}
+	} else {
+		fmt.Fprintf(w, "msgstr %s\n", EncodePOString(e.MsgStr))
+	}
 	fmt.Fprintln(w)
 }
 
@@ -219,6 +248,51 @@ func (p *PO) AddEntry(e *POEntry) {
 	}
 }
 
+// Merge updates the entries in this PO with the translations of matching entries in the other PO, e.g. one returned
+// by a translator. Entries are matched by ID and context, and entries in the other PO which are untranslated or have
+// no match in this PO are ignored. Matched entries take the translation and fuzzy flag of the other entry.
+func (p *PO) Merge(other *PO) {
+	for _, o := range other.Entries {
+		if !o.IsTranslated() {
+			continue
+		}
+
+		existing := p.contexts[o.MsgContext][o.MsgID]
+		if existing == nil {
+			continue
+		}
+
+		existing.MsgStr = o.MsgStr
+		existing.MsgStrPlural = append([]string(nil), o.MsgStrPlural...)
+
+		flags := make([]string, 0, len(existing.Comment.Flags)+1)
+		for _, f := range existing.Comment.Flags {
+			if f != "fuzzy" {
+				flags = append(flags, f)
+			}
+		}
+		if o.Comment.HasFlag("fuzzy") {
+			flags = append([]string{"fuzzy"}, flags...)
+		}
+		existing.Comment.Flags = flags
+	}
+}
+
+// Stats returns the number of entries in this PO which are translated, untranslated and fuzzy. Fuzzy entries are
+// only counted as fuzzy, regardless of whether they have a translation.
+func (p *PO) Stats() (translated, untranslated, fuzzy int) {
+	for _, e := range p.Entries {
+		if e.Comment.HasFlag("fuzzy") {
+			fuzzy++
+		} else if e.IsTranslated() {
+			translated++
+		} else {
+			untranslated++
+		}
+	}
+	return
+}
+
 // Sort sorts entries by ID and context
 func (p *PO) Sort() {
 	sort.SliceStable(p.Entries, func(i, j int) bool {
@@ -270,7 +344,7 @@ func ReadPO(r io.Reader) (*PO, error) {
 			return nil, err
 		}
 		if entry != nil {
-			if entry.MsgID == "" {
+			if entry.MsgID == "" && entry.MsgContext == "" {
 				po.Header = newPOHeaderFromEntry(entry)
 			} else {
 				po.AddEntry(entry)
@@ -323,12 +397,43 @@ func readPOEntry(nextLine func() (string, error)) (*POEntry, error) {
 		}
 	}
 
-	return &POEntry{
-		Comment:    ParsePOComment(comment),
-		MsgContext: DecodePOString(values["msgctxt"]),
-		MsgID:      DecodePOString(values["msgid"]),
-		MsgStr:     DecodePOString(values["msgstr"]),
-	}, nil
+	entry := &POEntry{
+		Comment:     ParsePOComment(comment),
+		MsgContext:  DecodePOString(values["msgctxt"]),
+		MsgID:       DecodePOString(values["msgid"]),
+		MsgIDPlural: DecodePOString(values["msgid_plural"]),
+		MsgStr:      DecodePOString(values["msgstr"]),
+	}
+
+	// plural translations are keyed msgstr[0], msgstr[1] etc
+	if entry.IsPlural() {
+		numPlurals := 0
+		for key := range values {
+			if isPluralKey(key) {
+				numPlurals++
+			}
+		}
+
+		for key, value := range values {
+			if isPluralKey(key) {
+				// indexes can't be more than the number of plural forms, so a bad index can't make us allocate a huge slice
+				index, err := strconv.Atoi(key[7 : len(key)-1])
+				if err != nil || index < 0 || index >= numPlurals {
+					return nil, fmt.Errorf("invalid plural index in '%s'", key)
+				}
+				for len(entry.MsgStrPlural) <= index {
+					entry.MsgStrPlural = append(entry.MsgStrPlural, "")
+				}
+				entry.MsgStrPlural[index] = DecodePOString(value)
+			}
+		}
+	}
+
+	return entry, nil
+}
+
+func isPluralKey(key string) bool {
+	return strings.HasPrefix(key, "msgstr[") && strings.HasSuffix(key, "]")
 }
 
 // EncodePOString encodes the string values that appear after msgid, mgstr etc
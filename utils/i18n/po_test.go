@@ -5,7 +5,6 @@ import (
 	"strings"
 	"testing"
 	"testing/iotest"
-	"time"
 
 	"github.com/nyaruka/goflow/test"
 	"github.com/nyaruka/goflow/utils/i18n"
@@ -41,7 +40,7 @@ func TestComments(t *testing.T) {
 }
 
 func TestPOCreation(t *testing.T) {
-	header := i18n.NewPOHeader("Generated for testing", time.Date(2020, 3, 25, 11, 50, 30, 123456789, time.UTC), "es")
+	header := i18n.NewPOHeader("Generated for testing", test.MustParseTime("2020-03-25T11:50:30.123456789Z"), "es")
 	header.Custom["Foo"] = "Bar"
 	po := i18n.NewPO(header)
 
@@ -106,7 +105,7 @@ func TestReadAndWritePO(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, "Testing\n", po.Header.InitialComment)
-	assert.True(t, time.Date(2020, 3, 25, 13, 57, 0, 0, time.UTC).Equal(po.Header.POTCreationDate))
+	assert.True(t, test.MustParseTime("2020-03-25T13:57:00Z").Equal(po.Header.POTCreationDate))
 	assert.Equal(t, "es", po.Header.Language)
 	assert.Equal(t, "1.0", po.Header.MIMEVersion)
 	assert.Equal(t, "text/plain; charset=UTF-8", po.Header.ContentType)
@@ -142,6 +141,139 @@ func TestReadAndWritePO(t *testing.T) {
 	test.AssertSnapshot(t, "write_po", b.String())
 }
 
+func TestPORoundTrip(t *testing.T) {
+	header := i18n.NewPOHeader("Generated for testing", test.MustParseTime("2020-03-25T11:50:00Z"), "es")
+	header.Custom["Plural-Forms"] = "nplurals=2; plural=(n != 1);"
+	po := i18n.NewPO(header)
+
+	po.AddEntry(&i18n.POEntry{
+		Comment: i18n.POComment{
+			Translator: []string{"check with Bob"},
+			Extracted:  []string{"has_text"},
+			References: []string{"src/foo.go", "src/bar.go"},
+			Flags:      []string{"fuzzy", "go-format"},
+		},
+		MsgID:  "Yes",
+		MsgStr: "Si",
+	})
+	po.AddEntry(&i18n.POEntry{
+		MsgContext: "context1",
+		MsgID:      "Hello\nWorld \"quoted\"\tand\\escaped",
+		MsgStr:     "Hola\nMundo \"citado\"\ty\\escapado",
+	})
+	po.AddEntry(&i18n.POEntry{
+		MsgContext: "context2",
+		MsgID:      "",
+		MsgStr:     "",
+	})
+	po.AddEntry(&i18n.POEntry{
+		Comment:      i18n.POComment{References: []string{"src/baz.go"}},
+		MsgID:        "One apple",
+		MsgIDPlural:  "%d apples",
+		MsgStrPlural: []string{"Una manzana", "%d manzanas"},
+	})
+
+	b := &strings.Builder{}
+	po.Write(b)
+	written := b.String()
+
+	assert.Contains(t, written, "msgid \"One apple\"\nmsgid_plural \"%d apples\"\nmsgstr[0] \"Una manzana\"\nmsgstr[1] \"%d manzanas\"\n")
+
+	po2, err := i18n.ReadPO(strings.NewReader(written))
+	require.NoError(t, err)
+
+	// header and all entries are preserved
+	assert.Equal(t, header.InitialComment, po2.Header.InitialComment)
+	assert.True(t, header.POTCreationDate.Equal(po2.Header.POTCreationDate))
+	assert.Equal(t, header.Language, po2.Header.Language)
+	assert.Equal(t, header.MIMEVersion, po2.Header.MIMEVersion)
+	assert.Equal(t, header.ContentType, po2.Header.ContentType)
+	assert.Equal(t, header.Custom, po2.Header.Custom)
+	assert.Equal(t, po.Entries, po2.Entries)
+
+	assert.True(t, po2.Entries[3].IsPlural())
+	assert.False(t, po2.Entries[0].IsPlural())
+
+	// and writing it again gives the same output
+	b = &strings.Builder{}
+	po2.Write(b)
+	assert.Equal(t, written, b.String())
+
+	// error if plural index isn't valid
+	_, err = i18n.ReadPO(strings.NewReader("msgid \"One\"\nmsgid_plural \"Many\"\nmsgstr[x] \"Uno\"\n"))
+	assert.EqualError(t, err, "invalid plural index in 'msgstr[x]'")
+
+	// or is more than the number of plural forms
+	_, err = i18n.ReadPO(strings.NewReader("msgid \"One\"\nmsgid_plural \"Many\"\nmsgstr[0] \"Uno\"\nmsgstr[999999999] \"Muchos\"\n"))
+	assert.EqualError(t, err, "invalid plural index in 'msgstr[999999999]'")
+}
+
+func TestPOMergeAndStats(t *testing.T) {
+	newTemplate := func() *i18n.PO {
+		po := i18n.NewPO(i18n.NewPOHeader("Template", test.MustParseTime("2020-03-25T11:50:00Z"), ""))
+		po.AddEntry(&i18n.POEntry{Comment: i18n.POComment{References: []string{"src/foo.go"}}, MsgID: "Yes"})
+		po.AddEntry(&i18n.POEntry{MsgID: "No"})
+		po.AddEntry(&i18n.POEntry{MsgContext: "color", MsgID: "Red"})
+		po.AddEntry(&i18n.POEntry{Comment: i18n.POComment{Flags: []string{"fuzzy", "go-format"}}, MsgID: "Maybe", MsgStr: "Quizas"})
+		po.AddEntry(&i18n.POEntry{MsgID: "One apple", MsgIDPlural: "%d apples", MsgStrPlural: []string{"", ""}})
+		return po
+	}
+
+	template := newTemplate()
+	translated, untranslated, fuzzy := template.Stats()
+	assert.Equal(t, []int{0, 4, 1}, []int{translated, untranslated, fuzzy})
+
+	// merge a partial translation
+	translation := i18n.NewPO(i18n.NewPOHeader("Translation", test.MustParseTime("2020-03-26T11:50:00Z"), "es"))
+	translation.AddEntry(&i18n.POEntry{Comment: i18n.POComment{Translator: []string{"checked"}}, MsgID: "Yes", MsgStr: "Si"})
+	translation.AddEntry(&i18n.POEntry{MsgID: "No", MsgStr: ""})           // untranslated so ignored
+	translation.AddEntry(&i18n.POEntry{MsgID: "Red", MsgStr: "Rojo"})      // context doesn't match so ignored
+	translation.AddEntry(&i18n.POEntry{MsgID: "Maybe", MsgStr: "Tal vez"}) // no longer fuzzy
+	translation.AddEntry(&i18n.POEntry{MsgID: "Blue", MsgStr: "Azul"})     // not in template so ignored
+	translation.AddEntry(&i18n.POEntry{MsgID: "One apple", MsgIDPlural: "%d apples", MsgStrPlural: []string{"Una manzana", "%d manzanas"}})
+
+	template.Merge(translation)
+
+	assert.Equal(t, 5, len(template.Entries))
+	assert.Equal(t, "Si", template.Entries[0].MsgStr)
+	assert.Equal(t, []string{"src/foo.go"}, template.Entries[0].Comment.References) // template comments are kept
+	assert.Nil(t, template.Entries[0].Comment.Translator)
+	assert.Equal(t, "", template.Entries[1].MsgStr)
+	assert.Equal(t, "", template.Entries[2].MsgStr)
+	assert.Equal(t, "Tal vez", template.Entries[3].MsgStr)
+	assert.Equal(t, []string{"go-format"}, template.Entries[3].Comment.Flags)
+	assert.Equal(t, []string{"Una manzana", "%d manzanas"}, template.Entries[4].MsgStrPlural)
+
+	assert.Equal(t, "Si", template.GetText("", "Yes"))
+	assert.Equal(t, "Red", template.GetText("color", "Red"))
+	assert.Equal(t, "Tal vez", template.GetText("", "Maybe"))
+
+	translated, untranslated, fuzzy = template.Stats()
+	assert.Equal(t, []int{3, 2, 0}, []int{translated, untranslated, fuzzy})
+
+	// merge a translation which is marked as fuzzy
+	fuzzyTranslation := i18n.NewPO(nil)
+	fuzzyTranslation.AddEntry(&i18n.POEntry{Comment: i18n.POComment{Flags: []string{"fuzzy"}}, MsgID: "No", MsgStr: "Non"})
+	fuzzyTranslation.AddEntry(&i18n.POEntry{Comment: i18n.POComment{Flags: []string{"fuzzy"}}, MsgContext: "color", MsgID: "Red", MsgStr: "Rojo"})
+
+	template.Merge(fuzzyTranslation)
+
+	assert.Equal(t, "Non", template.Entries[1].MsgStr)
+	assert.True(t, template.Entries[1].Comment.HasFlag("fuzzy"))
+	assert.Equal(t, "No", template.GetText("", "No")) // fuzzy translations aren't used
+
+	translated, untranslated, fuzzy = template.Stats()
+	assert.Equal(t, []int{3, 0, 2}, []int{translated, untranslated, fuzzy})
+
+	// merging a PO with no overlap changes nothing
+	template = newTemplate()
+	other := i18n.NewPO(nil)
+	other.AddEntry(&i18n.POEntry{MsgID: "Green", MsgStr: "Verde"})
+	template.Merge(other)
+
+	assert.Equal(t, newTemplate().Entries, template.Entries)
+}
+
 func TestGetText(t *testing.T) {
 	poFile, err := os.Open("testdata/locale/es/simple.po")
 	require.NoError(t, err)